@@ -0,0 +1,155 @@
+// cmd/aimap/apidiff.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/apidiff"
+)
+
+func runAPIDiff(args []string) error {
+	apidiffCmd := flag.NewFlagSet("apidiff", flag.ExitOnError)
+
+	// Flags
+	oldRev := apidiffCmd.String("old", "", "Revisão base (tag, branch ou commit)")
+	newRev := apidiffCmd.String("new", "HEAD", "Revisão comparada")
+	configFile := apidiffCmd.String("config", "superdoc.yml", "Caminho para o arquivo de configuração")
+	format := apidiffCmd.String("format", "markdown", "Formato do relatório (markdown, json)")
+	outputFile := apidiffCmd.String("output", "", "Arquivo do relatório (padrão: saída padrão)")
+	failOnBreaking := apidiffCmd.Bool("fail-on-breaking", false, "Retorna erro se houver mudanças incompatíveis (para CI)")
+	includeInternal := apidiffCmd.Bool("internal", false, "Inclui pacotes internal/ e main na comparação")
+
+	if err := apidiffCmd.Parse(args); err != nil {
+		return err
+	}
+	if *oldRev == "" {
+		return fmt.Errorf("informe a revisão base com -old")
+	}
+	if *format != "markdown" && *format != "json" {
+		return fmt.Errorf("formato não suportado: %s", *format)
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	root, err := git("", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return fmt.Errorf("erro ao localizar repositório git: %w", err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("erro ao obter diretório atual: %w", err)
+	}
+	// Os caminhos da configuração são relativos ao diretório atual, que pode ser um subdiretório do repositório
+	rel, err := filepath.Rel(root, cwd)
+	if err != nil {
+		return fmt.Errorf("erro ao calcular caminho relativo: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "aimap-apidiff-")
+	if err != nil {
+		return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDoc, err := analyzeRevision(root, rel, filepath.Join(tmpDir, "old"), *oldRev, cfg.Golang)
+	if err != nil {
+		return err
+	}
+	newDoc, err := analyzeRevision(root, rel, filepath.Join(tmpDir, "new"), *newRev, cfg.Golang)
+	if err != nil {
+		return err
+	}
+
+	diff := apidiff.Compare(oldDoc.API, newDoc.API, apidiff.Options{IncludeInternal: *includeInternal})
+	diff.Old, diff.New = *oldRev, *newRev
+
+	var data []byte
+	if *format == "json" {
+		if data, err = json.MarshalIndent(diff, "", "  "); err != nil {
+			return fmt.Errorf("erro ao gerar JSON: %w", err)
+		}
+	} else {
+		data = []byte(diff.Markdown())
+	}
+	if *outputFile == "" {
+		fmt.Println(string(data))
+	} else if err := os.WriteFile(*outputFile, data, 0644); err != nil {
+		return fmt.Errorf("erro ao escrever relatório: %w", err)
+	}
+
+	breaking := len(diff.Breaking())
+	if *failOnBreaking && breaking > 0 {
+		return fmt.Errorf("%d mudança(s) incompatível(is) na API entre %s e %s", breaking, *oldRev, *newRev)
+	}
+	if *outputFile != "" {
+		slog.Info("Comparação de API concluída",
+			"output", *outputFile,
+			"changes", len(diff.Changes),
+			"breaking", breaking)
+	}
+	return nil
+}
+
+// analyzeRevision extrai a revisão em um worktree temporário e documenta seus pacotes Go
+func analyzeRevision(root, rel, dir, rev string, cfg config.GolangConfig) (*godoc.ProjectDoc, error) {
+	if _, err := git(root, "worktree", "add", "--detach", dir, rev); err != nil {
+		return nil, fmt.Errorf("erro ao extrair revisão %s: %w", rev, err)
+	}
+	defer func() {
+		if _, err := git(root, "worktree", "remove", "--force", dir); err != nil {
+			slog.Warn("Erro ao remover worktree", "path", dir, "error", err)
+		}
+	}()
+
+	// Apenas a API exportada é comparada; as demais análises são desnecessárias
+	cfg, err := cfg.Only(config.FeatureAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, p := range cfg.Paths {
+		if filepath.IsAbs(p) {
+			return nil, fmt.Errorf("caminho absoluto não suportado no apidiff: %s", p)
+		}
+		path := filepath.Join(dir, rel, p)
+		if _, err := os.Stat(path); err != nil {
+			slog.Debug("Caminho inexistente na revisão", "rev", rev, "path", p)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	cfg.Paths = paths
+
+	doc, err := godoc.NewAnalyzer(cfg).Analyze()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao analisar revisão %s: %w", rev, err)
+	}
+	return doc, nil
+}
+
+// git executa um comando git e retorna a saída sem espaços nas extremidades
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/edgardnogueira/aimap/internal/docker"
+	"github.com/edgardnogueira/aimap/pkg/utils"
 )
 
 func runDocker(args []string) error {
@@ -18,6 +19,7 @@ func runDocker(args []string) error {
 	projectPath := dockerCmd.String("path", ".", "Caminho para o projeto com Dockerfile/docker-compose")
 	outputDir := dockerCmd.String("output", "docs/docker", "Diretório para os arquivos gerados")
 	format := dockerCmd.String("format", "plantuml", "Formato de saída (plantuml)")
+	sourceURL := dockerCmd.String("source-url-template", "", "Template dos links para o Dockerfile (ex.: https://git.example.com/repo/blob/{rev}/{path}#L{line}); vazio gera links relativos")
 
 	if err := dockerCmd.Parse(args); err != nil {
 		return err
@@ -44,6 +46,7 @@ func runDocker(args []string) error {
 	switch *format {
 	case "plantuml":
 		generator := docker.NewPlantUMLGenerator(project)
+		generator.SetSourceLinker(utils.NewSourceLinker(*sourceURL, *outputDir).URL)
 		content := generator.Generate()
 
 		outputFile := filepath.Join(*outputDir, "docker.puml")
@@ -0,0 +1,40 @@
+// cmd/aimap/golang.go
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+)
+
+// analyzeGo analisa o código Go configurado e acrescenta os diagnósticos
+// das verificações que dependem da configuração global
+func analyzeGo(cfg *config.Config) (*godoc.ProjectDoc, error) {
+	docs, err := godoc.NewAnalyzer(cfg.Golang).Analyze()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao analisar código Go: %w", err)
+	}
+
+	docs.Diagnostics = append(docs.Diagnostics, depgraph.CheckArchitecture(docs.Dependencies, cfg.Architecture)...)
+	docs.Diagnostics = append(docs.Diagnostics, godoc.CheckDocCoverage(docs.DocCoverage, cfg.Golang.DocCoverage.Min)...)
+	return docs, nil
+}
+
+// logDiagnostics registra os diagnósticos no log conforme a severidade
+func logDiagnostics(diagnostics []analysis.Diagnostic) {
+	for _, d := range diagnostics {
+		attrs := []any{"rule", d.Rule, "file", d.File, "line", d.Line}
+		switch d.Severity {
+		case analysis.SeverityError:
+			slog.Error(d.Message, attrs...)
+		case analysis.SeverityWarning:
+			slog.Warn(d.Message, attrs...)
+		default:
+			slog.Info(d.Message, attrs...)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// cmd/aimap/lintarch.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+)
+
+func runLintArch(args []string) error {
+	lintCmd := flag.NewFlagSet("lint-arch", flag.ExitOnError)
+
+	// Flags
+	configFile := lintCmd.String("config", "superdoc.yml", "Caminho para o arquivo de configuração")
+
+	if err := lintCmd.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+	if !cfg.Golang.Enabled {
+		return fmt.Errorf("análise Go desabilitada na configuração")
+	}
+
+	// As regras só dependem do grafo de dependências, extraído sempre
+	goCfg, err := cfg.Golang.Only()
+	if err != nil {
+		return err
+	}
+	docs, err := godoc.NewAnalyzer(goCfg).Analyze()
+	if err != nil {
+		return fmt.Errorf("erro ao analisar código Go: %w", err)
+	}
+
+	violations := depgraph.CheckArchitecture(docs.Dependencies, cfg.Architecture)
+	for _, v := range violations {
+		fmt.Println(v.String())
+	}
+
+	if analysis.HasErrors(violations) {
+		return fmt.Errorf("%d violação(ões) de arquitetura encontrada(s)", len(violations))
+	}
+
+	slog.Info("Nenhuma violação de arquitetura encontrada",
+		"packages", len(docs.Dependencies.Packages),
+		"dependencies", len(docs.Dependencies.Edges))
+	return nil
+}
@@ -8,6 +8,10 @@ import (
 	"os"
 
 	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/kubedoc"
+	"github.com/edgardnogueira/aimap/internal/output"
 )
 
 var (
@@ -29,6 +33,7 @@ func main() {
 	configFile := generateCmd.String("config", "superdoc.yml", "Caminho para o arquivo de configuração")
 	outputFormat := generateCmd.String("format", "", "Formato de saída (sobrescreve o do arquivo de configuração)")
 	outputPath := generateCmd.String("output", "", "Caminho de saída (sobrescreve o do arquivo de configuração)")
+	strict := generateCmd.Bool("strict", false, "Falha se a análise encontrar diagnósticos de erro (ex.: violações de arquitetura); golang.doc_coverage.min falha mesmo sem -strict")
 
 	// Verificar argumentos
 	if len(os.Args) < 2 {
@@ -67,6 +72,31 @@ func main() {
 			slog.Error("Erro ao executar comando swagger", "error", err)
 			os.Exit(1)
 		}
+	case "openapi":
+		if err := runOpenAPI(os.Args[2:]); err != nil {
+			slog.Error("Erro ao gerar especificação OpenAPI", "error", err)
+			os.Exit(1)
+		}
+	case "schema":
+		if err := runSchema(os.Args[2:]); err != nil {
+			slog.Error("Erro ao gerar JSON Schema", "error", err)
+			os.Exit(1)
+		}
+	case "apidiff":
+		if err := runAPIDiff(os.Args[2:]); err != nil {
+			slog.Error("Erro ao comparar API", "error", err)
+			os.Exit(1)
+		}
+	case "sql":
+		if err := runSQL(os.Args[2:]); err != nil {
+			slog.Error("Erro ao relacionar consultas SQL", "error", err)
+			os.Exit(1)
+		}
+	case "lint-arch":
+		if err := runLintArch(os.Args[2:]); err != nil {
+			slog.Error("Erro na verificação de arquitetura", "error", err)
+			os.Exit(1)
+		}
 	case "init":
 		initCmd.Parse(os.Args[2:])
 		if err := runInit(); err != nil {
@@ -76,7 +106,7 @@ func main() {
 
 	case "generate":
 		generateCmd.Parse(os.Args[2:])
-		if err := runGenerate(*configFile, *outputFormat, *outputPath); err != nil {
+		if err := runGenerate(*configFile, *outputFormat, *outputPath, *strict); err != nil {
 			slog.Error("Erro ao gerar documentação", "error", err)
 			os.Exit(1)
 		}
@@ -99,6 +129,11 @@ Uso:
 Comandos:
   init      Inicializa um novo projeto com arquivo de configuração
   generate  Gera a documentação baseada na configuração
+  lint-arch Verifica as regras de arquitetura (dependências entre pacotes)
+  schema    Gera JSON Schema a partir de uma struct Go (-type pkg.Tipo)
+  openapi   Gera uma especificação OpenAPI 3 a partir das rotas e tipos Go (-from go)
+  apidiff   Compara a API Go exportada entre duas revisões git (-old v1.2.0 -new HEAD)
+  sql       Relaciona as consultas SQL do código às tabelas do PostgreSQL/MySQL
   version   Mostra a versão do superdoc
 
 Execute 'superdoc <comando> -h' para mais informações sobre um comando específico.`)
@@ -115,6 +150,8 @@ func runInit() error {
 output:
   format: "markdown" # Pode ser: html, markdown, json, yaml
   path: "./docs"     # Diretório onde a documentação será gerada
+  # Links para o código-fonte; vazio gera links relativos ao diretório de saída
+  # source_url_template: "https://git.example.com/repo/blob/{rev}/{path}#L{line}"
 
 golang:
   enabled: true
@@ -122,8 +159,8 @@ golang:
   report_options:
     show_imports: true
     show_internal_funcs: true
-    show_tests: false
-    show_examples: true
+    show_tests: false     # Lista Test*/Benchmark*/Fuzz* por pacote (lê _test.go mesmo se ignorados)
+    show_examples: true   # Anexa funções Example* (com // Output:) aos símbolos documentados
   paths:
     - "./cmd"
     - "./internal"
@@ -132,6 +169,69 @@ golang:
     - ".*_test\\.go$"
     - "vendor/.*"
     - "node_modules/.*"
+  # Cobertura de testes por função e pacote (arquivo de go test -coverprofile=coverage.out ./...)
+  # coverage_profile: "coverage.out"
+  # Perfil pprof (CPU, heap, ...): custo flat/cumulativo por função, hot paths e grafo de calor
+  # pprof: "cpu.pprof"
+  call_graph:
+    enabled: false
+    max_depth: 5      # Profundidade dos grafos por ponto de entrada (0 = ilimitado)
+    entry_points: []  # Além de main.main, ex.: "service.Handler.ServeHTTP"
+  metrics:
+    enabled: true
+    thresholds:       # Limites que marcam hotspots (0 = padrão, -1 = desativado)
+      cyclomatic: 10
+      statements: 50
+      params: 5
+      nesting: 4
+      package_loc: 3000
+      efferent: 10
+  doc_coverage:
+    enabled: true
+    min: 0            # Cobertura mínima de documentação (%); abaixo dela a geração falha, mesmo sem -strict
+  routes:
+    enabled: true     # Rotas HTTP (net/http, chi, gin, echo)
+    http_files: true  # Gera arquivos .http em <output>/http
+  build:
+    enabled: false    # Avalia //go:build e sufixos _GOOS/_GOARCH
+    goos: "linux"     # Padrão: plataforma atual
+    goarch: "amd64"
+    tags: []
+    # matrix:         # Documenta em quais plataformas cada símbolo existe
+    #   - goos: "linux"
+    #     goarch: "amd64"
+    #   - goos: "windows"
+    #     goarch: "amd64"
+  concurrency:
+    enabled: true     # Goroutines, canais, mutexes e WaitGroup/errgroup por pacote
+  errors:
+    enabled: true     # Catálogo de erros: sentinelas, tipos, wraps %w e errors.Is/As
+  config_reference:
+    enabled: true     # Variáveis de ambiente (os.Getenv, tags env/envconfig) e flags lidas pelo código
+  sql:
+    enabled: true     # Instruções SQL em db.Query/Exec e arquivos do sqlc, com tabelas e colunas
+  logging:
+    enabled: true     # Chamadas slog/log por pacote: nível, mensagem e chaves dos atributos
+  class_diagram:
+    enabled: true     # Diagramas de classes por pacote em <output>/classes (.mmd e .puml)
+    depth: 1          # Vizinhos incluídos (campos, embeds, implementações); 0 = ilimitado
+    max_methods: 10   # 0 = todos
+    exported_only: false
+    focus: []         # Diagramas centrados em tipos, ex.: "godoc.Analyzer"
+  cli:
+    enabled: true     # Comandos e flags dos pacotes main (flag, cobra, urfave/cli) em <output>/cli
+  sequence:
+    enabled: false    # Diagramas de sequência a partir de rotas e funções, em <output>/sequence
+    routes: true      # Um diagrama por rota HTTP com handler resolvido
+    entry_points: []  # Ex.: "service.Service.Create"
+    max_depth: 4      # 0 = ilimitado
+    group_by: "receiver" # package ou receiver (tipos agrupados por pacote)
+  dead_code:
+    enabled: false    # Código não utilizado; suprima com //aimap:ignore dead-code na declaração
+  messaging:
+    enabled: false    # Tópicos Kafka/NATS/RabbitMQ com produtores e consumidores, ligados às cargas do Kubernetes
+  boot:
+    enabled: false    # Como cada binário inicializa: ordem dos pacotes, variáveis de pacote, init() e main()
 
 kubernetes:
   enabled: true
@@ -140,6 +240,12 @@ kubernetes:
   ignores:
     - ".*\\.bak$"
     - ".*\\.tmp$"
+
+# Regras de dependência entre pacotes (aimap lint-arch / generate -strict)
+architecture:
+  rules: []
+  #  - package: "internal/output/..."
+  #    deny: ["internal/postgres"]
 `
 
 	if err := os.WriteFile(configPath, []byte(template), 0644); err != nil {
@@ -150,7 +256,7 @@ kubernetes:
 	return nil
 }
 
-func runGenerate(configFile, outputFormat, outputPath string) error {
+func runGenerate(configFile, outputFormat, outputPath string, strict bool) error {
 	// Carregar configuração
 	cfg, err := config.Load(configFile)
 	if err != nil {
@@ -170,8 +276,50 @@ func runGenerate(configFile, outputFormat, outputPath string) error {
 		return fmt.Errorf("erro ao criar diretório de saída: %w", err)
 	}
 
-	// Resto da lógica de geração...
-	// [Use seu código existente aqui]
+	generator := output.NewGenerator(cfg.Output.Format, cfg.Output.Path)
+	generator.SetSourceURLTemplate(cfg.Output.SourceURLTemplate)
+	var diagnostics []analysis.Diagnostic
+
+	// Documentação Go
+	if cfg.Golang.Enabled {
+		slog.Info("Gerando documentação Go")
+		docs, err := analyzeGo(cfg)
+		if err != nil {
+			return err
+		}
+		diagnostics = docs.Diagnostics
+		if err := generator.AddGoDocumentation(docs, cfg.Golang); err != nil {
+			return fmt.Errorf("erro ao adicionar documentação Go: %w", err)
+		}
+	}
+
+	// Documentação Kubernetes
+	if cfg.Kubernetes.Enabled {
+		slog.Info("Gerando documentação Kubernetes")
+		docs, err := kubedoc.NewAnalyzer(cfg.Kubernetes).Analyze()
+		if err != nil {
+			return fmt.Errorf("erro ao analisar recursos Kubernetes: %w", err)
+		}
+		if err := generator.AddKubernetesDocumentation(docs); err != nil {
+			return fmt.Errorf("erro ao adicionar documentação Kubernetes: %w", err)
+		}
+	}
+
+	if err := generator.Generate(); err != nil {
+		return fmt.Errorf("erro ao gerar documentação: %w", err)
+	}
+
+	logDiagnostics(diagnostics)
+	// A cobertura mínima (golang.doc_coverage.min) falha a geração mesmo fora do modo estrito
+	for _, d := range diagnostics {
+		if d.Rule == godoc.RuleDocCoverage {
+			return fmt.Errorf("%s", d.Message)
+		}
+	}
+	if strict && analysis.HasErrors(diagnostics) {
+		return fmt.Errorf("modo estrito: a análise encontrou diagnósticos de erro")
+	}
 
+	slog.Info("Documentação gerada com sucesso", "output_path", cfg.Output.Path)
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,88 @@
+// cmd/aimap/openapi.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/schema"
+	"github.com/edgardnogueira/aimap/internal/swagger"
+)
+
+func runOpenAPI(args []string) error {
+	openapiCmd := flag.NewFlagSet("openapi", flag.ExitOnError)
+
+	// Flags
+	from := openapiCmd.String("from", "go", "Origem da especificação (suportado: go)")
+	configFile := openapiCmd.String("config", "superdoc.yml", "Caminho para o arquivo de configuração")
+	outputFile := openapiCmd.String("output", "openapi.json", "Arquivo OpenAPI gerado")
+	title := openapiCmd.String("title", "", "Título da API (padrão: module path)")
+	version := openapiCmd.String("version", "1.0.0", "Versão da API")
+	httpDir := openapiCmd.String("http", "", "Se definido, gera também os arquivos .http neste diretório")
+
+	if err := openapiCmd.Parse(args); err != nil {
+		return err
+	}
+	if *from != "go" {
+		return fmt.Errorf("origem não suportada: %s", *from)
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+	// A especificação depende da extração de rotas, mesmo que desabilitada na documentação
+	goCfg, err := cfg.Golang.Only(config.FeatureRoutes)
+	if err != nil {
+		return err
+	}
+	docs, err := godoc.NewAnalyzer(goCfg).Analyze()
+	if err != nil {
+		return fmt.Errorf("erro ao analisar código Go: %w", err)
+	}
+	if len(docs.Routes) == 0 {
+		return fmt.Errorf("nenhuma rota HTTP encontrada")
+	}
+
+	apiTitle := *title
+	if apiTitle == "" && len(docs.Modules) > 0 {
+		apiTitle = docs.Modules[0].Path
+	}
+	spec := schema.OpenAPI(docs, apiTitle, *version)
+
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao gerar JSON: %w", err)
+	}
+	if dir := filepath.Dir(*outputFile); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("erro ao criar diretório de saída: %w", err)
+		}
+	}
+	if err := os.WriteFile(*outputFile, data, 0644); err != nil {
+		return fmt.Errorf("erro ao escrever especificação: %w", err)
+	}
+
+	// Relê o arquivo com o parser do swagger para garantir que o restante do toolchain o aceita
+	parsed, err := swagger.Parse(*outputFile)
+	if err != nil {
+		return fmt.Errorf("especificação gerada inválida: %w", err)
+	}
+	if *httpDir != "" {
+		if err := parsed.GenerateHTTPFiles(*httpDir); err != nil {
+			return fmt.Errorf("erro ao gerar arquivos .http: %w", err)
+		}
+	}
+
+	slog.Info("Especificação OpenAPI gerada com sucesso",
+		"output", *outputFile,
+		"paths", len(spec.Paths),
+		"routes", len(docs.Routes))
+	return nil
+}
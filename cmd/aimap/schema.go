@@ -0,0 +1,78 @@
+// cmd/aimap/schema.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/schema"
+)
+
+func runSchema(args []string) error {
+	schemaCmd := flag.NewFlagSet("schema", flag.ExitOnError)
+
+	// Flags
+	typeName := schemaCmd.String("type", "", "Struct de origem (pkg.Tipo ou import/path.Tipo)")
+	srcPath := schemaCmd.String("path", ".", "Diretório com o código Go a analisar")
+	tagFormat := schemaCmd.String("tag", "", "Tags usadas como nome das propriedades: json ou yaml (padrão: yaml se a struct só tiver tags yaml, senão json)")
+	required := schemaCmd.String("required", schema.RequiredTags, "Campos obrigatórios: tags (validate/binding:\"required\") ou fields (também os sem omitempty)")
+	outputFile := schemaCmd.String("output", "", "Arquivo de saída (padrão: stdout)")
+
+	if err := schemaCmd.Parse(args); err != nil {
+		return err
+	}
+	if *typeName == "" {
+		return fmt.Errorf("tipo não especificado (use -type pkg.Tipo)")
+	}
+	if *tagFormat != "" && *tagFormat != "json" && *tagFormat != "yaml" {
+		return fmt.Errorf("tag não suportada: %s", *tagFormat)
+	}
+	if *required != schema.RequiredFields && *required != schema.RequiredTags {
+		return fmt.Errorf("modo de obrigatoriedade inválido: %s", *required)
+	}
+
+	docs, err := godoc.NewAnalyzer(config.GolangConfig{
+		Paths:   []string{*srcPath},
+		Ignores: config.DefaultGolangIgnores,
+	}).Analyze()
+	if err != nil {
+		return fmt.Errorf("erro ao analisar código Go: %w", err)
+	}
+
+	matches := schema.FindStruct(docs, *typeName)
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("struct não encontrada: %s", *typeName)
+	case 1:
+	default:
+		return fmt.Errorf("tipo ambíguo %s, use o import path completo: %s", *typeName, strings.Join(matches, ", "))
+	}
+
+	if *tagFormat == "" {
+		*tagFormat = schema.DefaultFormat(docs, matches[0])
+	}
+	jsonSchema, err := schema.JSONSchemaFor(docs, matches[0], *tagFormat, *required)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(jsonSchema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao gerar JSON: %w", err)
+	}
+
+	if *outputFile == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err := os.WriteFile(*outputFile, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("erro ao escrever schema: %w", err)
+	}
+	slog.Info("JSON Schema gerado com sucesso", "type", matches[0], "output", *outputFile, "definitions", len(jsonSchema.Defs))
+	return nil
+}
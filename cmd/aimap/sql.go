@@ -0,0 +1,163 @@
+// cmd/aimap/sql.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/sqlquery"
+	"github.com/edgardnogueira/aimap/internal/mysql"
+	"github.com/edgardnogueira/aimap/internal/postgres"
+)
+
+func runSQL(args []string) error {
+	sqlCmd := flag.NewFlagSet("sql", flag.ExitOnError)
+
+	// Flags
+	configFile := sqlCmd.String("config", "superdoc.yml", "Caminho para o arquivo de configuração")
+	driver := sqlCmd.String("driver", "postgres", "Banco do esquema (postgres, mysql)")
+	conn := sqlCmd.String("conn", "", "String de conexão ou DSN; vazio lista apenas as tabelas usadas pelo código")
+	dbName := sqlCmd.String("db", "", "Nome do banco de dados")
+	format := sqlCmd.String("format", "markdown", "Formato do relatório (markdown, json)")
+	outputFile := sqlCmd.String("output", "", "Arquivo do relatório (padrão: saída padrão)")
+	failOnIssues := sqlCmd.Bool("fail-on-issues", false, "Retorna erro se o código referenciar tabelas ou colunas inexistentes (para CI)")
+
+	if err := sqlCmd.Parse(args); err != nil {
+		return err
+	}
+	if *format != "markdown" && *format != "json" {
+		return fmt.Errorf("formato não suportado: %s", *format)
+	}
+	if *conn != "" && *dbName == "" {
+		return fmt.Errorf("informe o nome do banco de dados com -db")
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	// Apenas as instruções SQL são necessárias, mesmo que desabilitadas na documentação
+	goCfg, err := cfg.Golang.Only(config.FeatureSQL)
+	if err != nil {
+		return err
+	}
+
+	doc, err := godoc.NewAnalyzer(goCfg).Analyze()
+	if err != nil {
+		return fmt.Errorf("erro ao analisar código Go: %w", err)
+	}
+	var queries []sqlquery.Query
+	if doc.SQL != nil {
+		queries = doc.SQL.Queries
+	}
+
+	var schema []sqlquery.SchemaTable
+	if *conn != "" {
+		if schema, err = loadSQLSchema(*driver, *conn, *dbName); err != nil {
+			return err
+		}
+	}
+
+	report := sqlquery.Check(queries, schema)
+	report.Database = *dbName
+
+	var data []byte
+	if *format == "json" {
+		if data, err = json.MarshalIndent(report, "", "  "); err != nil {
+			return fmt.Errorf("erro ao gerar JSON: %w", err)
+		}
+	} else {
+		data = []byte(report.Markdown())
+	}
+	if *outputFile == "" {
+		fmt.Println(string(data))
+	} else if err := os.WriteFile(*outputFile, data, 0644); err != nil {
+		return fmt.Errorf("erro ao escrever relatório: %w", err)
+	}
+
+	if *failOnIssues && len(report.Issues) > 0 {
+		return fmt.Errorf("%d referência(s) a tabelas ou colunas inexistentes", len(report.Issues))
+	}
+	if *outputFile != "" {
+		slog.Info("Relatório de consultas SQL gerado",
+			"output", *outputFile,
+			"queries", len(queries),
+			"issues", len(report.Issues))
+	}
+	return nil
+}
+
+// loadSQLSchema lê as tabelas e views do banco e as converte para o formato do godoc
+func loadSQLSchema(driver, conn, dbName string) ([]sqlquery.SchemaTable, error) {
+	var schema []sqlquery.SchemaTable
+	switch driver {
+	case "postgres":
+		analyzer, err := postgres.NewAnalyzer(conn)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar analisador PostgreSQL: %w", err)
+		}
+		defer analyzer.Close()
+		database, err := analyzer.Analyze(dbName)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao analisar banco de dados: %w", err)
+		}
+		for _, s := range database.Schemas {
+			for _, t := range s.Tables {
+				schema = append(schema, schemaTable(s.Name, t.Name, postgresColumns(t.Columns)))
+			}
+			for _, v := range s.Views {
+				schema = append(schema, schemaTable(s.Name, v.Name, postgresColumns(v.Columns)))
+			}
+			for _, v := range s.MatViews {
+				schema = append(schema, schemaTable(s.Name, v.Name, postgresColumns(v.Columns)))
+			}
+		}
+
+	case "mysql":
+		analyzer, err := mysql.NewAnalyzer(conn)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar analisador MySQL: %w", err)
+		}
+		defer analyzer.Close()
+		database, err := analyzer.Analyze(dbName)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao analisar banco de dados: %w", err)
+		}
+		for _, t := range database.Tables {
+			schema = append(schema, schemaTable("", t.Name, mysqlColumns(t.Columns)))
+		}
+		for _, v := range database.Views {
+			schema = append(schema, schemaTable("", v.Name, mysqlColumns(v.Columns)))
+		}
+
+	default:
+		return nil, fmt.Errorf("banco não suportado: %s", driver)
+	}
+	return schema, nil
+}
+
+func schemaTable(schema, name string, columns []string) sqlquery.SchemaTable {
+	return sqlquery.SchemaTable{Schema: schema, Name: name, Columns: columns}
+}
+
+func postgresColumns(columns []postgres.Column) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func mysqlColumns(columns []mysql.Column) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	return names
+}
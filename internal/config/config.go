@@ -8,6 +8,10 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// DefaultGolangIgnores são os ignores de golang.ignores no config.yml gerado pelo init,
+// usados pelos subcomandos que analisam código sem arquivo de configuração
+var DefaultGolangIgnores = []string{`.*_test\.go$`, "vendor/.*", "node_modules/.*"}
+
 // Load carrega a configuração do arquivo especificado
 func Load(configPath string) (*Config, error) {
     data, err := os.ReadFile(configPath)
@@ -63,6 +67,10 @@ func validateConfig(cfg *Config) error {
         if err := validateReportLevel(cfg.Golang.ReportLevel); err != nil {
             return err
         }
+        applyMetricsDefaults(&cfg.Golang.Metrics.Thresholds)
+        if cfg.Golang.DocCoverage.Min < 0 || cfg.Golang.DocCoverage.Min > 100 {
+            return errors.New("golang.doc_coverage.min deve estar entre 0 e 100")
+        }
     }
 
     if cfg.Kubernetes.Enabled {
@@ -71,6 +79,15 @@ func validateConfig(cfg *Config) error {
         }
     }
 
+    for _, rule := range cfg.Architecture.Rules {
+        if rule.Package == "" {
+            return errors.New("regra de arquitetura sem pacote (package) especificado")
+        }
+        if len(rule.Allow) == 0 && len(rule.Deny) == 0 {
+            return errors.New("regra de arquitetura sem allow nem deny para o pacote " + rule.Package)
+        }
+    }
+
     if cfg.Databases.Enabled {
         if len(cfg.Databases.Connections) == 0 {
             return errors.New("nenhuma conexão de banco de dados configurada")
@@ -93,6 +110,29 @@ func validateReportLevel(level string) error {
         return errors.New("nível de relatório inválido (use: short, standard ou complete)")
     }
 }
+// applyMetricsDefaults preenche os limites de métricas não configurados. Um limite
+// negativo é mantido e desativa a verificação
+func applyMetricsDefaults(t *MetricsThresholds) {
+    if t.Cyclomatic == 0 {
+        t.Cyclomatic = 10 // valor padrão
+    }
+    if t.Statements == 0 {
+        t.Statements = 50 // valor padrão
+    }
+    if t.Params == 0 {
+        t.Params = 5 // valor padrão
+    }
+    if t.Nesting == 0 {
+        t.Nesting = 4 // valor padrão
+    }
+    if t.PackageLOC == 0 {
+        t.PackageLOC = 3000 // valor padrão
+    }
+    if t.Efferent == 0 {
+        t.Efferent = 10 // valor padrão
+    }
+}
+
 // validateDatabaseConfig valida uma configuração específica de banco de dados
 func validateDatabaseConfig(cfg DatabaseConfig) error {
     if cfg.Name == "" {
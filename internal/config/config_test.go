@@ -0,0 +1,48 @@
+package config
+
+import "testing"
+
+func TestGolangConfigOnly(t *testing.T) {
+    cfg := GolangConfig{
+        Enabled:     true,
+        Paths:       []string{"./internal"},
+        Ignores:     []string{`.*_test\.go$`},
+        Routes:      RoutesConfig{HTTPFiles: true},
+        Metrics:     MetricsConfig{Enabled: true},
+        SQL:         SQLConfig{Enabled: true},
+        DocCoverage: DocCoverageConfig{Enabled: true, Min: 80},
+        PProf:       "cpu.pprof",
+    }
+
+    only, err := cfg.Only(FeatureRoutes)
+    if err != nil {
+        t.Fatalf("Only: %v", err)
+    }
+    if !only.Routes.Enabled || !only.Routes.HTTPFiles {
+        t.Errorf("Routes = %+v; want habilitada com as opções do arquivo", only.Routes)
+    }
+    if only.Metrics.Enabled || only.SQL.Enabled || only.DocCoverage.Enabled || only.DocCoverage.Min != 0 || only.PProf != "" {
+        t.Errorf("análises não pedidas continuam habilitadas: %+v", only)
+    }
+    if len(only.Paths) != 1 || len(only.Ignores) != 1 || !only.Enabled {
+        t.Errorf("caminhos e ignores não preservados: %+v", only)
+    }
+
+    if none, err := cfg.Only(); err != nil || none.Metrics.Enabled || none.Routes.Enabled {
+        t.Errorf("Only() = %+v, %v; want nenhuma análise", none, err)
+    }
+
+    if _, err := cfg.Only("inexistente"); err == nil {
+        t.Error("Only(\"inexistente\") sem erro; want análise desconhecida")
+    }
+}
+
+func TestApplyMetricsDefaults(t *testing.T) {
+    thresholds := MetricsThresholds{Cyclomatic: -1, Params: 8}
+    applyMetricsDefaults(&thresholds)
+
+    want := MetricsThresholds{Cyclomatic: -1, Statements: 50, Params: 8, Nesting: 4, PackageLOC: 3000, Efferent: 10}
+    if thresholds != want {
+        t.Errorf("thresholds = %+v; want %+v", thresholds, want)
+    }
+}
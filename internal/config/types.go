@@ -1,15 +1,19 @@
 package config
 
+import "fmt"
+
 type Config struct {
     Output      OutputConfig      `yaml:"output"`
     Golang      GolangConfig      `yaml:"golang"`
     Kubernetes  KubernetesConfig  `yaml:"kubernetes"`
     Databases   DatabasesConfig   `yaml:"databases"`
+    Architecture ArchitectureConfig `yaml:"architecture"`
 }
 
 type OutputConfig struct {
-    Format string `yaml:"format"` // html, markdown, json, yaml
-    Path   string `yaml:"path"`
+    Format            string `yaml:"format"` // html, markdown, json, yaml
+    Path              string `yaml:"path"`
+    SourceURLTemplate string `yaml:"source_url_template"` // ex.: https://git.example.com/repo/blob/{rev}/{path}#L{line}; vazio gera links relativos
 }
 
 
@@ -19,6 +23,221 @@ type GolangConfig struct {
     ReportOptions ReportOptions  `yaml:"report_options"`
     Paths         []string       `yaml:"paths"`
     Ignores       []string       `yaml:"ignores"`
+    CoverageProfile string       `yaml:"coverage_profile"` // arquivo gerado por go test -coverprofile
+    PProf         string          `yaml:"pprof"`            // perfil pprof (CPU, heap, ...) a sobrepor
+    CallGraph     CallGraphConfig `yaml:"call_graph"`
+    Metrics       MetricsConfig   `yaml:"metrics"`
+    DocCoverage   DocCoverageConfig `yaml:"doc_coverage"`
+    Routes        RoutesConfig    `yaml:"routes"`
+    Build         BuildConfig     `yaml:"build"`
+    Concurrency   ConcurrencyConfig `yaml:"concurrency"`
+    Errors        ErrorsConfig      `yaml:"errors"`
+    ConfigReference ConfigReferenceConfig `yaml:"config_reference"`
+    SQL           SQLConfig         `yaml:"sql"`
+    Logging       LoggingConfig     `yaml:"logging"`
+    ClassDiagram  ClassDiagramConfig `yaml:"class_diagram"`
+    CLI           CLIConfig          `yaml:"cli"`
+    Sequence      SequenceConfig     `yaml:"sequence"`
+    DeadCode      DeadCodeConfig     `yaml:"dead_code"`
+    Messaging     MessagingConfig    `yaml:"messaging"`
+    Boot          BootConfig         `yaml:"boot"`
+    API           bool               `yaml:"-"` // coleta a API exportada; usada apenas pelo apidiff
+}
+
+// Análises do GolangConfig, com os nomes usados no config.yml
+const (
+    FeatureCallGraph       = "call_graph"
+    FeatureMetrics         = "metrics"
+    FeatureDocCoverage     = "doc_coverage"
+    FeatureRoutes          = "routes"
+    FeatureConcurrency     = "concurrency"
+    FeatureErrors          = "errors"
+    FeatureConfigReference = "config_reference"
+    FeatureSQL             = "sql"
+    FeatureLogging         = "logging"
+    FeatureClassDiagram    = "class_diagram"
+    FeatureCLI             = "cli"
+    FeatureSequence        = "sequence"
+    FeatureDeadCode        = "dead_code"
+    FeatureMessaging       = "messaging"
+    FeatureBoot            = "boot"
+    FeatureCoverageProfile = "coverage_profile"
+    FeaturePProf           = "pprof"
+    FeatureAPI             = "api" // sem entrada no config.yml; pedida pelo apidiff
+)
+
+// Only retorna uma cópia da configuração em que apenas as análises informadas estão
+// habilitadas, com as opções do arquivo preservadas. Caminhos, ignores, nível do
+// relatório e alvo de build são mantidos. Subcomandos que precisam de uma única
+// análise a usam para que novas análises fiquem desligadas sem ajustes. Retorna erro
+// para uma análise desconhecida
+func (c GolangConfig) Only(features ...string) (GolangConfig, error) {
+    only := GolangConfig{
+        Enabled:       c.Enabled,
+        ReportLevel:   c.ReportLevel,
+        ReportOptions: c.ReportOptions,
+        Paths:         c.Paths,
+        Ignores:       c.Ignores,
+        Build:         c.Build,
+    }
+    for _, feature := range features {
+        switch feature {
+        case FeatureCallGraph:
+            only.CallGraph = c.CallGraph
+            only.CallGraph.Enabled = true
+        case FeatureMetrics:
+            only.Metrics = c.Metrics
+            only.Metrics.Enabled = true
+        case FeatureDocCoverage:
+            only.DocCoverage = c.DocCoverage
+            only.DocCoverage.Enabled = true
+        case FeatureRoutes:
+            only.Routes = c.Routes
+            only.Routes.Enabled = true
+        case FeatureConcurrency:
+            only.Concurrency.Enabled = true
+        case FeatureErrors:
+            only.Errors.Enabled = true
+        case FeatureConfigReference:
+            only.ConfigReference.Enabled = true
+        case FeatureSQL:
+            only.SQL.Enabled = true
+        case FeatureLogging:
+            only.Logging.Enabled = true
+        case FeatureClassDiagram:
+            only.ClassDiagram = c.ClassDiagram
+            only.ClassDiagram.Enabled = true
+        case FeatureCLI:
+            only.CLI.Enabled = true
+        case FeatureSequence:
+            only.Sequence = c.Sequence
+            only.Sequence.Enabled = true
+        case FeatureDeadCode:
+            only.DeadCode.Enabled = true
+        case FeatureMessaging:
+            only.Messaging.Enabled = true
+        case FeatureBoot:
+            only.Boot.Enabled = true
+        case FeatureCoverageProfile:
+            only.CoverageProfile = c.CoverageProfile
+        case FeaturePProf:
+            only.PProf = c.PProf
+        case FeatureAPI:
+            only.API = true
+        default:
+            return GolangConfig{}, fmt.Errorf("análise desconhecida: %s", feature)
+        }
+    }
+    return only, nil
+}
+
+// BootConfig habilita o mapa de inicialização (pacotes main, init() e variáveis de pacote)
+type BootConfig struct {
+    Enabled bool `yaml:"enabled"`
+}
+
+// MessagingConfig habilita a topologia de mensageria (Kafka, NATS e RabbitMQ)
+type MessagingConfig struct {
+    Enabled bool `yaml:"enabled"`
+}
+
+// DeadCodeConfig habilita a detecção de código não utilizado (suprimida com //aimap:ignore dead-code)
+type DeadCodeConfig struct {
+    Enabled bool `yaml:"enabled"`
+}
+
+// SequenceConfig controla os diagramas de sequência gerados a partir do grafo de chamadas
+type SequenceConfig struct {
+    Enabled     bool     `yaml:"enabled"`
+    EntryPoints []string `yaml:"entry_points"` // pkg.Func, pkg.Tipo.Metodo ou import path completo
+    Routes      bool     `yaml:"routes"`       // um diagrama por rota HTTP com handler resolvido; ativa a extração de rotas
+    MaxDepth    int      `yaml:"max_depth"`    // 0 = ilimitado
+    GroupBy     string   `yaml:"group_by"`     // package (padrão) ou receiver
+}
+
+// CLIConfig habilita a referência de comandos e flags dos binários (flag, cobra e urfave/cli)
+type CLIConfig struct {
+    Enabled bool `yaml:"enabled"`
+}
+
+// ClassDiagramConfig controla os diagramas de classes por pacote e por tipo em foco
+type ClassDiagramConfig struct {
+    Enabled      bool     `yaml:"enabled"`
+    Focus        []string `yaml:"focus"`         // pkg.Tipo ou import path completo; gera um diagrama centrado em cada tipo
+    Depth        int      `yaml:"depth"`         // vizinhos incluídos a partir do pacote ou do tipo em foco (0 = ilimitado)
+    MaxMethods   int      `yaml:"max_methods"`   // 0 = todos
+    ExportedOnly bool     `yaml:"exported_only"` // omite tipos, campos e métodos não exportados
+}
+
+// LoggingConfig habilita o catálogo de chamadas de log (slog e log) por pacote
+type LoggingConfig struct {
+    Enabled bool `yaml:"enabled"`
+}
+
+// SQLConfig habilita a extração das instruções SQL do código Go e dos arquivos do sqlc
+type SQLConfig struct {
+    Enabled bool `yaml:"enabled"`
+}
+
+// ConfigReferenceConfig habilita a referência de variáveis de ambiente e flags lidas pelo código
+type ConfigReferenceConfig struct {
+    Enabled bool `yaml:"enabled"`
+}
+
+// ErrorsConfig habilita o catálogo de erros (sentinelas, tipos, wraps e verificações)
+type ErrorsConfig struct {
+    Enabled bool `yaml:"enabled"`
+}
+
+// ConcurrencyConfig habilita o mapa de goroutines, canais e travas por pacote
+type ConcurrencyConfig struct {
+    Enabled bool `yaml:"enabled"`
+}
+
+// BuildConfig define o alvo usado para avaliar //go:build e os sufixos _GOOS/_GOARCH
+type BuildConfig struct {
+    Enabled bool          `yaml:"enabled"`
+    GOOS    string        `yaml:"goos"`   // padrão: plataforma atual
+    GOARCH  string        `yaml:"goarch"` // padrão: plataforma atual
+    Tags    []string      `yaml:"tags"`
+    Matrix  []BuildTarget `yaml:"matrix"` // se definido, documenta todos os arquivos e em quais alvos cada símbolo existe
+}
+
+type BuildTarget struct {
+    GOOS   string   `yaml:"goos"`
+    GOARCH string   `yaml:"goarch"`
+    Tags   []string `yaml:"tags"`
+}
+
+type RoutesConfig struct {
+    Enabled   bool `yaml:"enabled"`
+    HTTPFiles bool `yaml:"http_files"` // gera uma coleção .http por pacote em <output>/http
+}
+
+type DocCoverageConfig struct {
+    Enabled bool    `yaml:"enabled"`
+    Min     float64 `yaml:"min"` // porcentagem mínima (0-100); abaixo dela a geração falha
+}
+
+type MetricsConfig struct {
+    Enabled    bool              `yaml:"enabled"`
+    Thresholds MetricsThresholds `yaml:"thresholds"`
+}
+
+// MetricsThresholds define os limites que marcam hotspots (0 usa o valor padrão, negativo desativa)
+type MetricsThresholds struct {
+    Cyclomatic int `yaml:"cyclomatic"`
+    Statements int `yaml:"statements"`
+    Params     int `yaml:"params"`
+    Nesting    int `yaml:"nesting"`
+    PackageLOC int `yaml:"package_loc"`
+    Efferent   int `yaml:"efferent"`
+}
+
+type CallGraphConfig struct {
+    Enabled     bool     `yaml:"enabled"`
+    EntryPoints []string `yaml:"entry_points"` // pkg.Func ou import path completo; main.main é sempre incluída
+    MaxDepth    int      `yaml:"max_depth"`    // 0 = ilimitado
 }
 
 type ReportOptions struct {
@@ -28,6 +247,18 @@ type ReportOptions struct {
     ShowExamples      bool `yaml:"show_examples"`
 }
 
+type ArchitectureConfig struct {
+    Rules []ArchitectureRule `yaml:"rules"`
+}
+
+// ArchitectureRule restringe os imports internos de um pacote. Os padrões são
+// relativos ao módulo (ex.: internal/output) e aceitam o sufixo "/..."
+type ArchitectureRule struct {
+    Package string   `yaml:"package"`
+    Allow   []string `yaml:"allow"` // se definido, apenas estes pacotes internos podem ser importados
+    Deny    []string `yaml:"deny"`
+}
+
 type KubernetesConfig struct {
     Enabled bool     `yaml:"enabled"`
     Paths   []string `yaml:"paths"`
@@ -112,7 +112,9 @@ func (a *Analyzer) analyzeDockerfile(path string) (*Dockerfile, error) {
 	cmdRe := regexp.MustCompile(`^CMD\s+(.+)`)
 	entrypointRe := regexp.MustCompile(`^ENTRYPOINT\s+(.+)`)
 
+	lineNum := 0
 	for scanner.Scan() {
+		lineNum++
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -127,11 +129,12 @@ func (a *Analyzer) analyzeDockerfile(path string) (*Dockerfile, error) {
 
 			// Se tem nome de estágio, cria um novo
 			if len(matches) > 2 && matches[2] != "" {
-				currentStage = &Stage{
+				dockerfile.Stages = append(dockerfile.Stages, Stage{
 					Name: matches[2],
 					Base: matches[1],
-				}
-				dockerfile.Stages = append(dockerfile.Stages, *currentStage)
+				})
+				// Aponta para o elemento do slice para que os steps seguintes fiquem no estágio
+				currentStage = &dockerfile.Stages[len(dockerfile.Stages)-1]
 			} else {
 				currentStage = nil
 			}
@@ -145,6 +148,7 @@ func (a *Analyzer) analyzeDockerfile(path string) (*Dockerfile, error) {
 			step := Step{
 				Type:    "RUN",
 				Command: matches[1],
+				Line:    lineNum,
 			}
 			if currentStage != nil {
 				currentStage.Steps = append(currentStage.Steps, step)
@@ -157,6 +161,7 @@ func (a *Analyzer) analyzeDockerfile(path string) (*Dockerfile, error) {
 			step := Step{
 				Type:    "COPY",
 				Command: matches[1],
+				Line:    lineNum,
 			}
 			if currentStage != nil {
 				currentStage.Steps = append(currentStage.Steps, step)
@@ -169,6 +174,7 @@ func (a *Analyzer) analyzeDockerfile(path string) (*Dockerfile, error) {
 			step := Step{
 				Type:    "ADD",
 				Command: matches[1],
+				Line:    lineNum,
 			}
 			if currentStage != nil {
 				currentStage.Steps = append(currentStage.Steps, step)
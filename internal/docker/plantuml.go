@@ -8,12 +8,18 @@ import (
 
 type PlantUMLGenerator struct {
 	project *Project
+	source  func(file string, line int) string
 }
 
 func NewPlantUMLGenerator(project *Project) *PlantUMLGenerator {
 	return &PlantUMLGenerator{project: project}
 }
 
+// SetSourceLinker define a função que gera o link de cada step para o Dockerfile
+func (g *PlantUMLGenerator) SetSourceLinker(link func(file string, line int) string) {
+	g.source = link
+}
+
 func (g *PlantUMLGenerator) Generate() string {
 	var sb strings.Builder
 
@@ -67,7 +73,7 @@ func (g *PlantUMLGenerator) generateDockerfile(sb *strings.Builder, dockerfile D
 			if len(stage.Steps) > 0 {
 				sb.WriteString("    note \"Steps\\n")
 				for _, step := range stage.Steps {
-					sb.WriteString(fmt.Sprintf("%s %s\\n", step.Type, escapeString(step.Command)))
+					sb.WriteString(g.stepLine(dockerfile.Path, step))
 				}
 				sb.WriteString("\" as NS" + fmt.Sprintf("%d\n", i+1))
 			}
@@ -75,6 +81,15 @@ func (g *PlantUMLGenerator) generateDockerfile(sb *strings.Builder, dockerfile D
 		}
 	}
 
+	// Steps fora de estágios nomeados
+	if len(dockerfile.Steps) > 0 {
+		sb.WriteString("  note \"Steps\\n")
+		for _, step := range dockerfile.Steps {
+			sb.WriteString(g.stepLine(dockerfile.Path, step))
+		}
+		sb.WriteString("\" as NSTEPS\n")
+	}
+
 	// Environment variables
 	if len(dockerfile.Env) > 0 {
 		sb.WriteString("  note \"Environment\\n")
@@ -114,6 +129,17 @@ func (g *PlantUMLGenerator) generateDockerfile(sb *strings.Builder, dockerfile D
 	sb.WriteString("}\n\n")
 }
 
+// stepLine formata um step da nota, com link para a linha do Dockerfile quando disponível
+func (g *PlantUMLGenerator) stepLine(path string, step Step) string {
+	text := fmt.Sprintf("%s %s", step.Type, escapeString(step.Command))
+	if g.source != nil && step.Line > 0 {
+		if url := g.source(path, step.Line); url != "" {
+			return fmt.Sprintf("[[%s %s]]\\n", url, strings.ReplaceAll(text, "]", ")"))
+		}
+	}
+	return text + "\\n"
+}
+
 func (g *PlantUMLGenerator) generateCompose(sb *strings.Builder) {
 	sb.WriteString(fmt.Sprintf("package \"Docker Compose (v%s)\" {\n", g.project.Compose.Version))
 
@@ -33,6 +33,7 @@ type Step struct {
     Type    string   `json:"type"` // RUN, COPY, ADD, etc
     Command string   `json:"command"`
     Args    []string `json:"args,omitempty"`
+    Line    int      `json:"line,omitempty"` // linha da instrução no Dockerfile
 }
 
 type EnvVar struct {
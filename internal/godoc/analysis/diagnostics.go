@@ -0,0 +1,40 @@
+package analysis
+
+import "fmt"
+
+// Severidades de diagnóstico
+const (
+    SeverityError   = "error"
+    SeverityWarning = "warning"
+)
+
+// Diagnostic representa um problema encontrado durante a análise
+type Diagnostic struct {
+    Severity string `json:"severity" yaml:"severity"`
+    Rule     string `json:"rule"     yaml:"rule"`
+    Message  string `json:"message"  yaml:"message"`
+    File     string `json:"file"     yaml:"file"`
+    Line     int    `json:"line"     yaml:"line"`
+}
+
+// String formata o diagnóstico no estilo file:line: [rule] mensagem
+func (d Diagnostic) String() string {
+    location := d.File
+    if d.Line > 0 {
+        location = fmt.Sprintf("%s:%d", d.File, d.Line)
+    }
+    if location == "" {
+        return fmt.Sprintf("%s [%s] %s", d.Severity, d.Rule, d.Message)
+    }
+    return fmt.Sprintf("%s: %s [%s] %s", location, d.Severity, d.Rule, d.Message)
+}
+
+// HasErrors verifica se algum diagnóstico tem severidade de erro
+func HasErrors(diagnostics []Diagnostic) bool {
+    for _, d := range diagnostics {
+        if d.Severity == SeverityError {
+            return true
+        }
+    }
+    return false
+}
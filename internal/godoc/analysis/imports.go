@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+)
+
+// Imports associa o nome usado no código ao caminho de cada import de um pacote
+type Imports map[string]string
+
+// PackageImports lê os imports de todos os arquivos do pacote
+func PackageImports(pkg *Package) Imports {
+    imports := make(Imports)
+    for _, file := range pkg.Files {
+        for _, spec := range file.Imports {
+            path, err := strconv.Unquote(spec.Path.Value)
+            if err != nil {
+                continue
+            }
+            name := ImportName(path)
+            if spec.Name != nil {
+                name = spec.Name.Name
+            }
+            imports[name] = path
+        }
+    }
+    return imports
+}
+
+// ImportedFrom informa se a expressão de tipo é <pacote>.<name> com o pacote
+// importado de um dos caminhos aceitos por match
+func (imports Imports) ImportedFrom(info *types.Info, expr ast.Expr, match func(path string) bool, name string) bool {
+    if star, ok := expr.(*ast.StarExpr); ok {
+        expr = star.X
+    }
+    sel, ok := expr.(*ast.SelectorExpr)
+    if !ok || sel.Sel.Name != name {
+        return false
+    }
+    ident, ok := sel.X.(*ast.Ident)
+    if !ok {
+        return false
+    }
+    if pkgName, ok := IdentObject(info, ident).(*types.PkgName); ok {
+        return match(pkgName.Imported().Path())
+    }
+    // Dependência sem export data: o verificador de tipos não resolve o
+    // identificador quando o nome do pacote difere do caminho (ex.: cli/v2)
+    return match(imports[ident.Name])
+}
+
+// LiteralFields indexa os campos nomeados de um literal composto
+func LiteralFields(lit *ast.CompositeLit) map[string]ast.Expr {
+    fields := make(map[string]ast.Expr)
+    for _, elt := range lit.Elts {
+        if kv, ok := elt.(*ast.KeyValueExpr); ok {
+            if key, ok := kv.Key.(*ast.Ident); ok {
+                fields[key.Name] = kv.Value
+            }
+        }
+    }
+    return fields
+}
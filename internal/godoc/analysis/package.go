@@ -0,0 +1,58 @@
+// Package analysis reúne o que as análises do godoc compartilham: os pacotes
+// com informações de tipos, os diagnósticos e funções auxiliares sobre go/types
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Package representa um pacote analisado com informações de tipos
+type Package struct {
+    Dir        string
+    ImportPath string
+    Name       string
+    Files      []*ast.File
+    Types      *types.Package
+    Info       *types.Info
+    // Arquivos _test.go do diretório e seus tipos, verificados apenas quando alguma
+    // análise os usa. Os testes do próprio pacote são verificados junto com uma cópia
+    // do pacote, cujos objetos ficam nas mesmas posições dos de Types
+    TestFiles  []*ast.File
+    TestInfo   *types.Info
+}
+
+// Dir representa um diretório analisado com as ASTs de todos os seus arquivos,
+// inclusive de pacotes divergentes e de testes, na ordem em que foram lidos
+type Dir struct {
+    Path       string
+    ImportPath string
+    Module     string
+    Files      []*ast.File
+}
+
+// Pass reúne o que cada análise recebe: o FileSet, os diretórios lidos e os
+// pacotes com tipos verificados (vazio nas análises que rodam antes do go/types)
+type Pass struct {
+    Fset     *token.FileSet
+    Dirs     []*Dir
+    Packages []*Package
+}
+
+// FuncDecls percorre as declarações de funções de um pacote em ordem estável
+func (p *Package) FuncDecls(fn func(decl *ast.FuncDecl, obj *types.Func)) {
+    for _, file := range p.Files {
+        for _, decl := range file.Decls {
+            funcDecl, ok := decl.(*ast.FuncDecl)
+            if !ok {
+                continue
+            }
+            obj, _ := p.Info.Defs[funcDecl.Name].(*types.Func)
+            if obj == nil {
+                continue
+            }
+            fn(funcDecl, obj)
+        }
+    }
+}
@@ -0,0 +1,84 @@
+package analysis
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// SortedKeys retorna as chaves de um mapa em ordem alfabética
+func SortedKeys[V any](m map[string]V) []string {
+    keys := make([]string, 0, len(m))
+    for k := range m {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+// ContainsString verifica se a lista contém o valor
+func ContainsString(list []string, value string) bool {
+    for _, item := range list {
+        if item == value {
+            return true
+        }
+    }
+    return false
+}
+
+// AppendUnique acrescenta o valor se ele ainda não estiver na lista
+func AppendUnique(list []string, value string) []string {
+    if ContainsString(list, value) {
+        return list
+    }
+    return append(list, value)
+}
+
+// ImportName retorna o nome padrão de um pacote importado, ignorando sufixos /vN
+func ImportName(importPath string) string {
+    base := path.Base(importPath)
+    if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+        base = path.Base(path.Dir(importPath))
+    }
+    base = strings.TrimPrefix(base, "go-")
+    // Como o goimports: o nome termina no primeiro caractere inválido (kafka-go, nats.go)
+    if i := strings.IndexFunc(base, func(r rune) bool { return r == '-' || r == '.' }); i > 0 {
+        base = base[:i]
+    }
+    return base
+}
+
+// IsInternalPath informa se o import path contém um elemento internal
+func IsInternalPath(importPath string) bool {
+    for _, part := range strings.Split(importPath, "/") {
+        if part == "internal" {
+            return true
+        }
+    }
+    return false
+}
+
+// MatchingBracket retorna o índice do "]" que fecha o "[" na posição open
+func MatchingBracket(s string, open int) int {
+    depth := 0
+    for i := open; i < len(s); i++ {
+        switch s[i] {
+        case '[':
+            depth++
+        case ']':
+            depth--
+            if depth == 0 {
+                return i
+            }
+        }
+    }
+    return -1
+}
+
+// CodeOrEmpty formata o texto como código inline Markdown, se não vazio
+func CodeOrEmpty(s string) string {
+    if s == "" {
+        return ""
+    }
+    return "`" + strings.ReplaceAll(s, "|", "\\|") + "`"
+}
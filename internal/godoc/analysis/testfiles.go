@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ParseTestFiles lê os arquivos _test.go de um diretório. Os arquivos são lidos mesmo
+// quando um padrão de ignore os exclui da documentação, já que show_examples/show_tests
+// e a detecção de código morto pedem explicitamente esse conteúdo
+func ParseTestFiles(fset *token.FileSet, dirPath string) ([]*ast.File, error) {
+    entries, err := os.ReadDir(dirPath)
+    if err != nil {
+        return nil, err
+    }
+
+    var files []*ast.File
+    for _, entry := range entries {
+        if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), "_test.go") {
+            continue
+        }
+        fullPath := filepath.Join(dirPath, entry.Name())
+        file, err := parser.ParseFile(fset, fullPath, nil, parser.ParseComments)
+        if err != nil {
+            slog.Error("Erro ao analisar arquivo de teste", "path", fullPath, "error", err)
+            continue
+        }
+        files = append(files, file)
+    }
+    return files, nil
+}
@@ -0,0 +1,154 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+// IsValidType informa se o tipo foi resolvido (não inválido nem constante sem tipo)
+func IsValidType(t types.Type) bool {
+    if basic, ok := t.(*types.Basic); ok {
+        return basic.Kind() != types.Invalid && basic.Info()&types.IsUntyped == 0
+    }
+    return t != nil
+}
+
+// ConstString avalia uma expressão string constante (literal ou const)
+func ConstString(info *types.Info, expr ast.Expr) (string, bool) {
+    if tv, ok := info.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+        return constant.StringVal(tv.Value), true
+    }
+    if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+        if value, err := strconv.Unquote(lit.Value); err == nil {
+            return value, true
+        }
+    }
+    return "", false
+}
+
+// IdentObject retorna o objeto de um identificador (definição ou uso)
+func IdentObject(info *types.Info, expr ast.Expr) types.Object {
+    id, ok := ast.Unparen(expr).(*ast.Ident)
+    if !ok {
+        return nil
+    }
+    if obj := info.Defs[id]; obj != nil {
+        return obj
+    }
+    return info.Uses[id]
+}
+
+// TypeOrNil retorna o tipo de uma expressão, ou nil se desconhecido
+func TypeOrNil(info *types.Info, expr ast.Expr) types.Type {
+    if t := info.TypeOf(expr); t != nil {
+        return t
+    }
+    return types.Typ[types.Invalid]
+}
+
+// IdentsToExprs converte identificadores em expressões
+func IdentsToExprs(idents []*ast.Ident) []ast.Expr {
+    exprs := make([]ast.Expr, len(idents))
+    for i, id := range idents {
+        exprs[i] = id
+    }
+    return exprs
+}
+
+// IsPackageCall informa se a chamada é pkg.Name, com pkg identificado pelo import path
+func IsPackageCall(info *types.Info, call *ast.CallExpr, path, name string) bool {
+    sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+    if !ok || sel.Sel.Name != name {
+        return false
+    }
+    pkgName, ok := IdentObject(info, sel.X).(*types.PkgName)
+    return ok && pkgName.Imported().Path() == path
+}
+
+// ResolveCallee retorna a função chamada e se a chamada é dinâmica (via interface)
+func ResolveCallee(info *types.Info, call *ast.CallExpr) (*types.Func, bool) {
+    switch fun := ast.Unparen(call.Fun).(type) {
+    case *ast.Ident:
+        if fn, ok := info.Uses[fun].(*types.Func); ok {
+            return fn.Origin(), false
+        }
+    case *ast.SelectorExpr:
+        if sel, ok := info.Selections[fun]; ok {
+            fn, ok := sel.Obj().(*types.Func)
+            if !ok {
+                return nil, false
+            }
+            if sel.Kind() == types.MethodVal && types.IsInterface(sel.Recv()) {
+                return fn, true
+            }
+            return fn.Origin(), false
+        }
+        // Função qualificada por pacote (pkg.Func)
+        if fn, ok := info.Uses[fun.Sel].(*types.Func); ok {
+            return fn.Origin(), false
+        }
+    case *ast.IndexExpr:
+        // Função genérica instanciada explicitamente (Func[T])
+        if id, ok := fun.X.(*ast.Ident); ok {
+            if fn, ok := info.Uses[id].(*types.Func); ok {
+                return fn.Origin(), false
+            }
+        }
+    }
+    return nil, false
+}
+
+// ShortFuncName retorna o nome curto da função (pkg.Func ou pkg.Tipo.Metodo)
+func ShortFuncName(fn *types.Func) string {
+    prefix := ""
+    if fn.Pkg() != nil {
+        prefix = fn.Pkg().Name() + "."
+    }
+    if recv := ReceiverName(fn); recv != "" {
+        return prefix + recv + "." + fn.Name()
+    }
+    return prefix + fn.Name()
+}
+
+// LocalFuncName retorna o nome da função sem o pacote quando ela pertence a pkg
+func LocalFuncName(fn *types.Func, pkg *types.Package) string {
+    if fn.Pkg() != pkg {
+        return ShortFuncName(fn)
+    }
+    if recv := ReceiverName(fn); recv != "" {
+        return recv + "." + fn.Name()
+    }
+    return fn.Name()
+}
+
+// ReceiverName retorna o nome do tipo receptor de um método, sem ponteiro
+func ReceiverName(fn *types.Func) string {
+    sig, ok := fn.Type().(*types.Signature)
+    if !ok || sig.Recv() == nil {
+        return ""
+    }
+    t := sig.Recv().Type()
+    if ptr, ok := t.(*types.Pointer); ok {
+        t = ptr.Elem()
+    }
+    if n, ok := t.(*types.Named); ok {
+        return n.Obj().Name()
+    }
+    return ""
+}
+
+// ReceiverTypeName extrai o nome do tipo receptor da expressão de um método (T ou *T)
+func ReceiverTypeName(expr ast.Expr) string {
+    switch t := expr.(type) {
+    case *ast.Ident:
+        return t.Name
+    case *ast.StarExpr:
+        if id, ok := t.X.(*ast.Ident); ok {
+            return id.Name
+        }
+    }
+    return ""
+}
@@ -1,10 +1,12 @@
 package godoc
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"io/fs"
 	"log/slog"
 	"os"
@@ -13,12 +15,37 @@ import (
 	"strings"
 
 	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/apidiff"
+	"github.com/edgardnogueira/aimap/internal/godoc/boot"
+	"github.com/edgardnogueira/aimap/internal/godoc/callgraph"
+	"github.com/edgardnogueira/aimap/internal/godoc/classdiagram"
+	"github.com/edgardnogueira/aimap/internal/godoc/cli"
+	"github.com/edgardnogueira/aimap/internal/godoc/concurrency"
+	"github.com/edgardnogueira/aimap/internal/godoc/configref"
+	"github.com/edgardnogueira/aimap/internal/godoc/deadcode"
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+	"github.com/edgardnogueira/aimap/internal/godoc/errcatalog"
+	"github.com/edgardnogueira/aimap/internal/godoc/logcatalog"
+	"github.com/edgardnogueira/aimap/internal/godoc/messaging"
+	"github.com/edgardnogueira/aimap/internal/godoc/metrics"
+	"github.com/edgardnogueira/aimap/internal/godoc/pprof"
+	"github.com/edgardnogueira/aimap/internal/godoc/routes"
+	"github.com/edgardnogueira/aimap/internal/godoc/sqlquery"
+	"github.com/edgardnogueira/aimap/internal/godoc/structtag"
+	"github.com/edgardnogueira/aimap/internal/godoc/testcover"
 )
 
 // Analyzer é responsável por analisar o código Go
 type Analyzer struct {
     config config.GolangConfig
     ignoreRegex []*regexp.Regexp
+    fset *token.FileSet
+    astFiles map[string][]*ast.File
+    dirOrder []string
+    buildTarget buildTarget
+    buildMatrix []buildTarget
+    modules map[string]moduleRoot // go.mod mais próximo por diretório absoluto
 }
 
 // NewAnalyzer cria um novo analisador de código Go
@@ -32,16 +59,128 @@ func NewAnalyzer(cfg config.GolangConfig) *Analyzer {
         }
     }
 
+    primary, matrix := buildTargets(cfg.Build)
+
     return &Analyzer{
         config: cfg,
         ignoreRegex: regexps,
+        fset: token.NewFileSet(),
+        astFiles: make(map[string][]*ast.File),
+        buildTarget: primary,
+        buildMatrix: matrix,
+        modules: make(map[string]moduleRoot),
     }
 }
 
 // Analyze analisa todos os diretórios configurados
 func (a *Analyzer) Analyze() (*ProjectDoc, error) {
     projectDoc := &ProjectDoc{}
+    if err := a.readDirectories(projectDoc); err != nil {
+        return nil, err
+    }
 
+    if len(a.buildMatrix) > 0 {
+        projectDoc.BuildMatrix = projectDoc.buildBuildMatrix(a.buildMatrix)
+    }
+    a.collectModules(projectDoc)
+    pass := a.newPass()
+    projectDoc.Dependencies = depgraph.Build(pass)
+    if a.config.Metrics.Enabled {
+        projectDoc.Metrics = metrics.Packages(pass, projectDoc.Dependencies, a.config.Metrics.Thresholds)
+        projectDoc.Diagnostics = append(projectDoc.Diagnostics, projectDoc.metricsDiagnostics()...)
+    }
+    if a.config.DocCoverage.Enabled || a.config.DocCoverage.Min > 0 {
+        projectDoc.DocCoverage = projectDoc.computeDocCoverage()
+    }
+    if a.config.CoverageProfile != "" {
+        cov, err := testcover.Load(pass, a.config.CoverageProfile)
+        if err != nil {
+            return nil, err
+        }
+        projectDoc.annotateCoverage(cov)
+    }
+    if a.config.PProf != "" {
+        info, err := pprof.Load(pass, a.config.PProf)
+        if err != nil {
+            return nil, err
+        }
+        projectDoc.annotateProfile(info)
+    }
+
+    // Verificação de tipos e análises que dependem dela; só executada quando alguma
+    // análise habilitada a usa
+    if !a.needsPackages() {
+        return projectDoc, nil
+    }
+    pass.Packages = a.loadPackages(pass.Dirs, a.config.ReportOptions.ShowTests || a.config.DeadCode.Enabled)
+    packages := pass.Packages
+    if a.config.API {
+        projectDoc.API = apidiff.Collect(pass)
+    }
+    if a.config.CallGraph.Enabled || a.config.Sequence.Enabled {
+        // Os diagramas de sequência percorrem o mesmo grafo de chamadas
+        projectDoc.CallGraph = callgraph.Build(pass)
+        projectDoc.annotateCalls()
+    }
+    // Os diagramas de sequência por rota dependem da extração de rotas
+    if a.config.Routes.Enabled || (a.config.Sequence.Enabled && a.config.Sequence.Routes) {
+        projectDoc.Routes = routes.Build(pass)
+    }
+    projectDoc.inferConstVarTypes(packages)
+    if a.config.ReportOptions.ShowTests {
+        for _, pkg := range packages {
+            if dir := projectDoc.directoryFor(pkg.Dir); dir != nil {
+                a.collectTests(dir, pkg)
+            }
+        }
+    }
+    if a.config.Concurrency.Enabled {
+        attachByDir(projectDoc, concurrency.Build(pass), func(dir *DirectoryDoc, info *concurrency.Info) {
+            dir.Concurrency = info
+        })
+    }
+    if a.config.Errors.Enabled {
+        attachByDir(projectDoc, errcatalog.Build(pass), func(dir *DirectoryDoc, catalog *errcatalog.Catalog) {
+            dir.Errors = catalog
+        })
+    }
+    if a.config.ConfigReference.Enabled {
+        projectDoc.Configuration = configref.Build(pass)
+    }
+    if a.config.SQL.Enabled {
+        projectDoc.SQL = sqlquery.Build(pass, a.config.Paths, a.shouldIgnore)
+    }
+    if a.config.Logging.Enabled {
+        catalogs, diagnostics := logcatalog.Build(pass)
+        attachByDir(projectDoc, catalogs, func(dir *DirectoryDoc, catalog *logcatalog.Catalog) {
+            dir.Logging = catalog
+        })
+        projectDoc.Diagnostics = append(projectDoc.Diagnostics, diagnostics...)
+    }
+    if a.config.ClassDiagram.Enabled {
+        projectDoc.Classes = classdiagram.Build(pass, a.config.ClassDiagram)
+    }
+    if a.config.CLI.Enabled {
+        projectDoc.CLI = cli.Build(pass)
+    }
+    if a.config.Messaging.Enabled {
+        projectDoc.Messaging = messaging.Build(pass, projectDoc.Dependencies)
+    }
+    if a.config.Boot.Enabled {
+        projectDoc.Boot = boot.Build(pass, projectDoc.Dependencies)
+    }
+    if a.config.DeadCode.Enabled {
+        dead, diagnostics := deadcode.Build(pass)
+        projectDoc.DeadCode = dead
+        projectDoc.Diagnostics = append(projectDoc.Diagnostics, diagnostics...)
+    }
+
+    return projectDoc, nil
+}
+
+// readDirectories percorre os caminhos configurados e adiciona ao projeto os diretórios
+// com arquivos Go, guardando as ASTs para as análises
+func (a *Analyzer) readDirectories(projectDoc *ProjectDoc) error {
     for _, path := range a.config.Paths {
         if err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
             if err != nil {
@@ -62,19 +201,57 @@ func (a *Analyzer) Analyze() (*ProjectDoc, error) {
                     return nil
                 }
                 if len(files) > 0 {
-                    projectDoc.Directories = append(projectDoc.Directories, DirectoryDoc{
-                        Path:  path,
-                        Files: files,
-                    })
+                    dirDoc := DirectoryDoc{
+                        Path:       path,
+                        ImportPath: a.importPathForDir(path),
+                        Module:     a.moduleForDir(path),
+                        Files:      files,
+                    }
+                    a.analyzeExamples(&dirDoc)
+                    projectDoc.Directories = append(projectDoc.Directories, dirDoc)
                 }
             }
             return nil
         }); err != nil {
-            return nil, err
+            return err
         }
     }
+    return nil
+}
 
-    return projectDoc, nil
+// LoadPass lê os caminhos configurados e retorna o Pass com os pacotes e os testes
+// verificados, sem executar nenhuma análise. Permite chamar as análises diretamente
+func (a *Analyzer) LoadPass() (*analysis.Pass, error) {
+    if err := a.readDirectories(&ProjectDoc{}); err != nil {
+        return nil, err
+    }
+    pass := a.newPass()
+    pass.Packages = a.loadPackages(pass.Dirs, true)
+    return pass, nil
+}
+
+// needsPackages informa se alguma análise habilitada depende da verificação de tipos
+func (a *Analyzer) needsPackages() bool {
+    c := a.config
+    return c.API || c.ReportOptions.ShowTests || c.CallGraph.Enabled || c.Sequence.Enabled || c.Routes.Enabled ||
+        c.Concurrency.Enabled || c.Errors.Enabled || c.ConfigReference.Enabled ||
+        c.SQL.Enabled || c.Logging.Enabled || c.ClassDiagram.Enabled || c.CLI.Enabled ||
+        c.Messaging.Enabled || c.Boot.Enabled || c.DeadCode.Enabled
+}
+
+// analyzeExamples extrai os exemplos dos arquivos de teste quando show_examples está ativo.
+// As funções de teste são listadas depois da verificação de tipos (collectTests)
+func (a *Analyzer) analyzeExamples(dir *DirectoryDoc) {
+    if !a.config.ReportOptions.ShowExamples {
+        return
+    }
+
+    testFiles, err := analysis.ParseTestFiles(a.fset, dir.Path)
+    if err != nil {
+        slog.Error("Erro ao ler arquivos de teste", "path", dir.Path, "error", err)
+        return
+    }
+    a.attachExamples(dir, testFiles)
 }
 
 // shouldIgnore verifica se um caminho deve ser ignorado
@@ -106,6 +283,10 @@ func (a *Analyzer) analyzeDirectory(dirPath string) ([]FileDoc, error) {
         }
 
         fileDoc, err := a.analyzeFile(fullPath)
+        if errors.Is(err, errBuildExcluded) {
+            slog.Debug("Arquivo ignorado pelas restrições de build", "path", fullPath, "target", a.buildTarget.String())
+            continue
+        }
         if err != nil {
             slog.Error("Erro ao analisar arquivo", "path", fullPath, "error", err)
             continue
@@ -117,16 +298,38 @@ func (a *Analyzer) analyzeDirectory(dirPath string) ([]FileDoc, error) {
 }
 // analyzeFile analisa um arquivo Go específico
 func (a *Analyzer) analyzeFile(filePath string) (FileDoc, error) {
-    fset := token.NewFileSet()
+    fset := a.fset
     node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
     if err != nil {
         return FileDoc{}, err
     }
 
+    constraint := fileConstraint(node, filePath)
+    inTarget := a.buildTarget.matches(constraint)
+    if a.config.Build.Enabled && len(a.buildMatrix) == 0 && !inTarget {
+        return FileDoc{}, errBuildExcluded
+    }
+
+    // Guarda a AST para a verificação de tipos do pacote. Com restrições de build
+    // ativas, só entram os arquivos do alvo principal, evitando declarações duplicadas
+    dir := filepath.Dir(filePath)
+    if !a.config.Build.Enabled || inTarget {
+        if _, ok := a.astFiles[dir]; !ok {
+            a.dirOrder = append(a.dirOrder, dir)
+        }
+        a.astFiles[dir] = append(a.astFiles[dir], node)
+    }
+
     fileDoc := FileDoc{
-        FileName: filePath,
-        Package:  node.Name.Name,
-        Imports:  a.collectImports(node),
+        FileName:   filePath,
+        Package:    node.Name.Name,
+        Constraint: constraint,
+        Imports:    a.collectImports(node),
+    }
+    for _, target := range a.buildMatrix {
+        if target.matches(constraint) {
+            fileDoc.Platforms = append(fileDoc.Platforms, target.String())
+        }
     }
 
     // Analisa as declarações do arquivo
@@ -174,11 +377,19 @@ func (a *Analyzer) collectConstVar(decl *ast.GenDecl, fset *token.FileSet, fileN
             continue
         }
         
-        for _, name := range vs.Names {
+        // Comentário do próprio spec tem precedência sobre o do bloco
+        doc := decl.Doc.Text()
+        if vs.Doc != nil {
+            doc = vs.Doc.Text()
+        } else if vs.Comment != nil && decl.Lparen.IsValid() {
+            doc = vs.Comment.Text()
+        }
+
+        for i, name := range vs.Names {
             pos := fset.Position(name.Pos())
             cv := ConstVar{
                 Name: name.Name,
-                Doc:  strings.TrimSpace(decl.Doc.Text()),
+                Doc:  strings.TrimSpace(doc),
                 File: pos.Filename,
                 Line: pos.Line,
             }
@@ -186,12 +397,48 @@ func (a *Analyzer) collectConstVar(decl *ast.GenDecl, fset *token.FileSet, fileN
             if vs.Type != nil {
                 cv.Type = a.exprToString(vs.Type)
             }
+            if len(vs.Values) == len(vs.Names) {
+                cv.Value = types.ExprString(vs.Values[i])
+            }
             result = append(result, cv)
         }
     }
     return result
 }
 
+// inferConstVarTypes preenche o tipo de constantes e variáveis declaradas sem tipo
+// explícito (ex.: var ErrX = errors.New(...)) e o valor de constantes com iota a
+// partir da verificação de tipos
+func (p *ProjectDoc) inferConstVarTypes(packages []*analysis.Package) {
+    for _, pkg := range packages {
+        dir := p.directoryFor(pkg.Dir)
+        if pkg.Types == nil || dir == nil {
+            continue
+        }
+        scope := pkg.Types.Scope()
+        qualifier := types.RelativeTo(pkg.Types)
+        infer := func(list []ConstVar) {
+            for i := range list {
+                obj := scope.Lookup(list[i].Name)
+                if obj == nil {
+                    continue
+                }
+                if list[i].Type == "" && analysis.IsValidType(obj.Type()) {
+                    list[i].Type = types.TypeString(obj.Type(), qualifier)
+                }
+                // Constantes em blocos com iota não têm expressão própria
+                if c, ok := obj.(*types.Const); ok && list[i].Value == "" {
+                    list[i].Value = c.Val().ExactString()
+                }
+            }
+        }
+        for j := range dir.Files {
+            infer(dir.Files[j].Constants)
+            infer(dir.Files[j].Variables)
+        }
+    }
+}
+
 // collectTypes coleta tipos (interfaces e structs)
 func (a *Analyzer) collectTypes(decl *ast.GenDecl, fset *token.FileSet, fileDoc *FileDoc) {
     for _, spec := range decl.Specs {
@@ -260,6 +507,11 @@ func (a *Analyzer) collectTypes(decl *ast.GenDecl, fset *token.FileSet, fileDoc
                         tagStr = strings.Trim(field.Tag.Value, "`")
                     }
                     fieldType := a.exprToString(field.Type)
+                    if fieldDoc == "" && field.Comment != nil {
+                        // Comentário na mesma linha do campo
+                        fieldDoc = field.Comment.Text()
+                    }
+                    fieldPos := fset.Position(field.Pos())
                     
                     if len(field.Names) == 0 {
                         // Campo anônimo
@@ -267,7 +519,10 @@ func (a *Analyzer) collectTypes(decl *ast.GenDecl, fset *token.FileSet, fileDoc
                             Name: fieldType,
                             Type: fieldType,
                             Tag:  tagStr,
+                            Tags: structtag.Parse(tagStr),
                             Doc:  strings.TrimSpace(fieldDoc),
+                            File: fieldPos.Filename,
+                            Line: fieldPos.Line,
                         })
                     } else {
                         for _, name := range field.Names {
@@ -275,7 +530,10 @@ func (a *Analyzer) collectTypes(decl *ast.GenDecl, fset *token.FileSet, fileDoc
                                 Name: name.Name,
                                 Type: fieldType,
                                 Tag:  tagStr,
+                                Tags: structtag.Parse(tagStr),
                                 Doc:  strings.TrimSpace(fieldDoc),
+                                File: fieldPos.Filename,
+                                Line: fieldPos.Line,
                             })
                         }
                     }
@@ -295,6 +553,11 @@ func (a *Analyzer) collectFunction(decl *ast.FuncDecl, fset *token.FileSet, file
     }
     
     sig := a.buildFuncSig(decl.Type)
+
+    var funcMetrics *metrics.Func
+    if a.config.Metrics.Enabled {
+        funcMetrics = metrics.ForFunc(decl, a.config.Metrics.Thresholds)
+    }
     
     if decl.Recv == nil {
         // Função normal (não é método)
@@ -304,10 +567,11 @@ func (a *Analyzer) collectFunction(decl *ast.FuncDecl, fset *token.FileSet, file
             File: pos.Filename,
             Line: pos.Line,
             Sig:  sig,
+            Metrics: funcMetrics,
         })
     } else {
         // É um método, adiciona ao struct correspondente
-        receiverName := a.extractReceiverTypeName(decl.Recv.List[0].Type)
+        receiverName := analysis.ReceiverTypeName(decl.Recv.List[0].Type)
         if receiverName == "" {
             return
         }
@@ -318,6 +582,7 @@ func (a *Analyzer) collectFunction(decl *ast.FuncDecl, fset *token.FileSet, file
             File: pos.Filename,
             Line: pos.Line,
             Sig:  sig,
+            Metrics: funcMetrics,
         }
         
         // Procura o struct correspondente
@@ -364,19 +629,6 @@ func (a *Analyzer) extractFieldList(fieldList *ast.FieldList) string {
     return strings.Join(parts, ", ")
 }
 
-// extractReceiverTypeName extrai o nome do tipo receptor de um método
-func (a *Analyzer) extractReceiverTypeName(expr ast.Expr) string {
-    switch t := expr.(type) {
-    case *ast.Ident:
-        return t.Name
-    case *ast.StarExpr:
-        if id, ok := t.X.(*ast.Ident); ok {
-            return id.Name
-        }
-    }
-    return ""
-}
-
 // exprToString converte uma expressão AST em string
 func (a *Analyzer) exprToString(expr ast.Expr) string {
     switch t := expr.(type) {
@@ -406,5 +658,5 @@ func (a *Analyzer) exprToString(expr ast.Expr) string {
             return "chan " + a.exprToString(t.Value)
         }
     }
-    return fmt.Sprintf("%T", expr)
+    return types.ExprString(expr)
 }
\ No newline at end of file
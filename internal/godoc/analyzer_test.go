@@ -0,0 +1,147 @@
+package godoc_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestSequenceRouteDiagrams(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "service/service.go": `package service
+
+import "net/http"
+
+type Handler struct{}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) { h.find() }
+
+func (h *Handler) find() { audit() }
+
+func audit() {}
+
+func Register(mux *http.ServeMux, h *Handler) {
+    mux.HandleFunc("GET /items", h.Get)
+}
+`,
+    })
+
+    cfg := config.GolangConfig{
+        Paths: []string{root},
+        // Sem routes.enabled: os diagramas por rota ativam a extração de rotas
+        Sequence: config.SequenceConfig{
+            Enabled:     true,
+            Routes:      true,
+            EntryPoints: []string{"service.Handler.find"},
+        },
+    }
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    diagrams := godoc.NewGenerator(doc, "", cfg).GenerateSequenceDiagrams()
+    if len(diagrams) != 2 {
+        t.Fatalf("diagramas = %d; want 2 (rota e ponto de entrada)", len(diagrams))
+    }
+    if route := diagrams[0]; route.Kind != "route" || route.Name != "GET /items" {
+        t.Errorf("primeiro diagrama = %s %q; want route \"GET /items\"", route.Kind, route.Name)
+    }
+}
+
+func TestAPICollectedOnRequest(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "api/api.go": `package api
+
+func Find(id string) string { return id }
+`,
+    })
+
+    cfg := config.GolangConfig{Paths: []string{root}}
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    if doc.API != nil {
+        t.Errorf("API coletada sem ser pedida: %+v", doc.API)
+    }
+
+    cfg, err = cfg.Only(config.FeatureAPI)
+    if err != nil {
+        t.Fatalf("Only: %v", err)
+    }
+    doc, err = godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    if doc.API["example.com/app/api"] == nil {
+        t.Errorf("API = %+v; want pacote example.com/app/api", doc.API)
+    }
+}
+
+func TestErrorCatalogAttached(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "store/store.go": `package store
+
+import "errors"
+
+var ErrNotFound = errors.New("registro não encontrado")
+
+func Get(id string) error { return ErrNotFound }
+`,
+    })
+
+    cfg := config.GolangConfig{
+        Paths:  []string{root},
+        Errors: config.ErrorsConfig{Enabled: true},
+    }
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    dir := doc.Directories[0]
+    if dir.Errors == nil || len(dir.Errors.Sentinels) != 1 {
+        t.Errorf("catálogo de erros = %+v; want ErrNotFound", dir.Errors)
+    }
+    v := dir.Files[0].Variables[0]
+    if v.Type != "error" || v.Value != `errors.New("registro não encontrado")` {
+        t.Errorf("ErrNotFound documentada como %q = %q", v.Type, v.Value)
+    }
+}
+
+func TestTestCoverageAnnotated(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "calc/calc.go": `package calc
+
+func Add(a, b int) int {
+    return a + b
+}
+
+func Sub(a, b int) int {
+    return a - b
+}
+`,
+    })
+    profile := filepath.Join(t.TempDir(), "coverage.out")
+    lines := "mode: set\n" +
+        "example.com/app/calc/calc.go:3.24,5.2 1 0\n" +
+        "example.com/app/calc/calc.go:7.24,9.2 1 1\n"
+    if err := os.WriteFile(profile, []byte(lines), 0644); err != nil {
+        t.Fatal(err)
+    }
+
+    cfg := config.GolangConfig{Paths: []string{root}, CoverageProfile: profile}
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    if sub := godoctest.FindFunc(doc, "Sub"); sub == nil || sub.Coverage == nil || sub.Coverage.Covered != 1 {
+        t.Errorf("cobertura de Sub = %+v; want 1/1", sub)
+    }
+    if cov := doc.TestCoverage; cov == nil || len(cov.Untested) != 1 || cov.Untested[0].Name != "Add" {
+        t.Errorf("cobertura = %+v; want apenas Add sem testes", cov)
+    }
+}
@@ -0,0 +1,109 @@
+package godoc
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/pprof"
+	"github.com/edgardnogueira/aimap/internal/godoc/testcover"
+)
+
+// annotateCalls preenche as listas Calls/CalledBy de funções e métodos
+func (p *ProjectDoc) annotateCalls() {
+    if p.CallGraph == nil {
+        return
+    }
+    idByPos := make(map[string]string)
+    for _, n := range p.CallGraph.Nodes {
+        idByPos[fmt.Sprintf("%s:%d", n.File, n.Line)] = n.ID
+    }
+    calls, calledBy := p.CallGraph.Index()
+
+    for d := range p.Directories {
+        for f := range p.Directories[d].Files {
+            file := &p.Directories[d].Files[f]
+            for i := range file.Functions {
+                fn := &file.Functions[i]
+                id := idByPos[fmt.Sprintf("%s:%d", fn.File, fn.Line)]
+                fn.Calls, fn.CalledBy = calls[id], calledBy[id]
+            }
+            for s := range file.Structs {
+                for i := range file.Structs[s].Methods {
+                    m := &file.Structs[s].Methods[i]
+                    id := idByPos[fmt.Sprintf("%s:%d", m.File, m.Line)]
+                    m.Calls, m.CalledBy = calls[id], calledBy[id]
+                }
+            }
+        }
+    }
+}
+
+// annotateCoverage sobrepõe o coverprofile às funções e métodos documentados
+func (p *ProjectDoc) annotateCoverage(cov *testcover.Info) {
+    for d := range p.Directories {
+        dir := &p.Directories[d]
+        untested := func(name, file string, line int) {
+            cov.Untested = append(cov.Untested, testcover.UntestedFunc{Package: dir.ImportPath, Name: name, File: file, Line: line})
+        }
+        for f := range dir.Files {
+            file := &dir.Files[f]
+            for i := range file.Functions {
+                fn := &file.Functions[i]
+                fn.Coverage = cov.Func(dir.ImportPath, fn.File, fn.Line)
+                if fn.Coverage != nil && fn.Coverage.Covered == 0 {
+                    untested(fn.Name, fn.File, fn.Line)
+                }
+            }
+            for s := range file.Structs {
+                for i := range file.Structs[s].Methods {
+                    m := &file.Structs[s].Methods[i]
+                    m.Coverage = cov.Func(dir.ImportPath, m.File, m.Line)
+                    if m.Coverage != nil && m.Coverage.Covered == 0 {
+                        untested(file.Structs[s].Name+"."+m.Name, m.File, m.Line)
+                    }
+                }
+            }
+        }
+    }
+    p.TestCoverage = cov
+}
+
+// annotateProfile sobrepõe o perfil pprof às funções e métodos documentados
+func (p *ProjectDoc) annotateProfile(info *pprof.Info) {
+    for d := range p.Directories {
+        dir := &p.Directories[d]
+        for f := range dir.Files {
+            file := &dir.Files[f]
+            for i := range file.Functions {
+                file.Functions[i].Profile = info.Func(dir.ImportPath, file.Functions[i].Name)
+            }
+            for s := range file.Structs {
+                for i := range file.Structs[s].Methods {
+                    m := &file.Structs[s].Methods[i]
+                    m.Profile = info.Func(dir.ImportPath, file.Structs[s].Name+"."+m.Name)
+                }
+            }
+        }
+    }
+    p.Profile = info
+}
+
+// directoryFor retorna o DirectoryDoc de um diretório
+func (p *ProjectDoc) directoryFor(path string) *DirectoryDoc {
+    path = filepath.Clean(path)
+    for i := range p.Directories {
+        if filepath.Clean(p.Directories[i].Path) == path {
+            return &p.Directories[i]
+        }
+    }
+    return nil
+}
+
+// attachByDir associa a cada DirectoryDoc o resultado da análise do seu pacote
+func attachByDir[T any](p *ProjectDoc, byDir map[string]T, set func(dir *DirectoryDoc, result T)) {
+    for path, result := range byDir {
+        if dir := p.directoryFor(path); dir != nil {
+            set(dir, result)
+        }
+    }
+}
@@ -0,0 +1,319 @@
+// Package apidiff compara a API exportada de duas revisões do projeto
+package apidiff
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Diff descreve as mudanças na API pública entre duas revisões
+type Diff struct {
+    Old     string      `json:"old"     yaml:"old"`
+    New     string      `json:"new"     yaml:"new"`
+    Changes []Change `json:"changes" yaml:"changes"`
+}
+
+// Change representa a mudança de um símbolo exportado
+type Change struct {
+    Package  string `json:"package"       yaml:"package"`
+    Symbol   string `json:"symbol"        yaml:"symbol"`
+    Kind     string `json:"kind"          yaml:"kind"`   // function, method, type, field, interface_method, const, var
+    Change   string `json:"change"        yaml:"change"` // added, removed, changed
+    Breaking bool   `json:"breaking"      yaml:"breaking"`
+    Old      string `json:"old,omitempty" yaml:"old,omitempty"` // assinatura ou tipo anterior
+    New      string `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// Package reúne os símbolos exportados de um pacote verificado pelo go/types
+type Package struct {
+    Name    string
+    Symbols map[string]Symbol // por nome: Func, Tipo, Tipo.Campo ou Tipo.Metodo
+}
+
+// Symbol é a representação comparável de um símbolo exportado
+type Symbol struct {
+    Kind   string
+    Detail string // assinatura normalizada, tipo ou tipo = valor
+    Shown  string // texto exibido no relatório
+}
+
+// Options controla quais pacotes entram na comparação
+type Options struct {
+    IncludeInternal bool // inclui pacotes internal/ e main, que não fazem parte da API importável
+}
+
+// Compare compara a API exportada de duas análises. Remoções e mudanças de
+// assinatura ou tipo são incompatíveis; adições são compatíveis, exceto métodos
+// novos em interfaces, que quebram implementações existentes
+func Compare(oldPackages, newPackages map[string]*Package, opts Options) *Diff {
+    oldAPI := exportedAPI(oldPackages, opts)
+    newAPI := exportedAPI(newPackages, opts)
+    diff := &Diff{}
+
+    for _, pkg := range analysis.SortedKeys(oldAPI) {
+        newSymbols := newAPI[pkg]
+        for _, name := range analysis.SortedKeys(oldAPI[pkg]) {
+            old := oldAPI[pkg][name]
+            current, ok := newSymbols[name]
+            switch {
+            case !ok && isMemberOf(name, oldAPI[pkg], newSymbols):
+                // A remoção do próprio tipo já é reportada
+            case !ok:
+                diff.add(pkg, name, old.Kind, "removed", true, old.Shown, "")
+            case current.Kind != old.Kind || current.Detail != old.Detail:
+                diff.add(pkg, name, current.Kind, "changed", true, old.Shown, current.Shown)
+            }
+        }
+    }
+    for _, pkg := range analysis.SortedKeys(newAPI) {
+        for _, name := range analysis.SortedKeys(newAPI[pkg]) {
+            if _, ok := oldAPI[pkg][name]; ok {
+                continue
+            }
+            if isMemberOf(name, newAPI[pkg], oldAPI[pkg]) {
+                continue
+            }
+            current := newAPI[pkg][name]
+            // Método novo em interface já existente quebra as implementações
+            iface, _, _ := strings.Cut(name, ".")
+            breaking := current.Kind == "interface_method" && oldAPI[pkg][iface].Detail == "interface"
+            diff.add(pkg, name, current.Kind, "added", breaking, "", current.Shown)
+        }
+    }
+    return diff
+}
+
+// isMemberOf informa se o símbolo é campo ou método de um tipo presente em
+// from e ausente em other (tipo adicionado ou removido)
+func isMemberOf(name string, from, other map[string]Symbol) bool {
+    parent, _, found := strings.Cut(name, ".")
+    if !found {
+        return false
+    }
+    _, inFrom := from[parent]
+    _, inOther := other[parent]
+    return inFrom && !inOther
+}
+
+// add registra uma mudança
+func (d *Diff) add(pkg, symbol, kind, change string, breaking bool, old, current string) {
+    d.Changes = append(d.Changes, Change{
+        Package:  pkg,
+        Symbol:   symbol,
+        Kind:     kind,
+        Change:   change,
+        Breaking: breaking,
+        Old:      old,
+        New:      current,
+    })
+}
+
+// Breaking retorna apenas as mudanças incompatíveis
+func (d *Diff) Breaking() []Change {
+    var result []Change
+    for _, c := range d.Changes {
+        if c.Breaking {
+            result = append(result, c)
+        }
+    }
+    return result
+}
+
+// exportedAPI seleciona os pacotes comparados conforme as opções
+func exportedAPI(packages map[string]*Package, opts Options) map[string]map[string]Symbol {
+    api := make(map[string]map[string]Symbol)
+    for path, pkg := range packages {
+        if !opts.IncludeInternal && (pkg.Name == "main" || analysis.IsInternalPath(path)) {
+            continue
+        }
+        api[path] = pkg.Symbols
+    }
+    return api
+}
+
+// Collect indexa os símbolos exportados de cada pacote a partir do escopo verificado
+// pelo go/types, incluindo métodos declarados em outros arquivos e tipos nomeados que
+// não são structs nem interfaces
+func Collect(pass *analysis.Pass) map[string]*Package {
+    api := make(map[string]*Package)
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil {
+            continue
+        }
+        // Tipos de outros pacotes são comparados pelo import path e exibidos pelo nome
+        detailQualifier := types.RelativeTo(pkg.Types)
+        shownQualifier := func(p *types.Package) string {
+            if p == pkg.Types {
+                return ""
+            }
+            return p.Name()
+        }
+        symbols := make(map[string]Symbol)
+        set := func(name, kind, detail, shown string) {
+            symbols[name] = Symbol{Kind: kind, Detail: detail, Shown: shown}
+        }
+
+        scope := pkg.Types.Scope()
+        for _, name := range scope.Names() {
+            obj := scope.Lookup(name)
+            if !obj.Exported() {
+                continue
+            }
+            switch obj := obj.(type) {
+            case *types.Func:
+                sig := obj.Type().(*types.Signature)
+                set(name, "function", signatureKey(sig, detailQualifier), "func "+name+strings.TrimPrefix(types.TypeString(sig, shownQualifier), "func"))
+            case *types.Const:
+                typ := ""
+                if basic, ok := obj.Type().(*types.Basic); !ok || basic.Info()&types.IsUntyped == 0 {
+                    typ = types.TypeString(obj.Type(), detailQualifier)
+                }
+                detail := strings.TrimSpace(typ + " = " + obj.Val().ExactString())
+                set(name, "const", detail, "const "+name+" "+detail)
+            case *types.Var:
+                set(name, "var", types.TypeString(obj.Type(), detailQualifier), "var "+name+" "+types.TypeString(obj.Type(), shownQualifier))
+            case *types.TypeName:
+                collectTypeAPI(obj, set, detailQualifier, shownQualifier)
+            }
+        }
+        if len(symbols) > 0 {
+            api[pkg.ImportPath] = &Package{Name: pkg.Name, Symbols: symbols}
+        }
+    }
+    return api
+}
+
+// collectTypeAPI indexa um tipo exportado com seus campos e métodos exportados
+func collectTypeAPI(obj *types.TypeName, set func(name, kind, detail, shown string), detailQualifier, shownQualifier types.Qualifier) {
+    name := obj.Name()
+    if obj.IsAlias() {
+        rhs := types.Unalias(obj.Type())
+        set(name, "type", "= "+types.TypeString(rhs, detailQualifier), "type "+name+" = "+types.TypeString(rhs, shownQualifier))
+        return
+    }
+    named, ok := obj.Type().(*types.Named)
+    if !ok {
+        return
+    }
+    tparams := typeParamsKey(named.TypeParams(), detailQualifier)
+
+    switch underlying := named.Underlying().(type) {
+    case *types.Interface:
+        set(name, "type", "interface"+tparams, "type "+name+" interface")
+        for i := 0; i < underlying.NumMethods(); i++ {
+            m := underlying.Method(i)
+            if m.Exported() {
+                sig := m.Type().(*types.Signature)
+                set(name+"."+m.Name(), "interface_method", signatureKey(sig, detailQualifier), m.Name()+strings.TrimPrefix(types.TypeString(sig, shownQualifier), "func"))
+            }
+        }
+        return
+    case *types.Struct:
+        set(name, "type", "struct"+tparams, "type "+name+" struct")
+        for i := 0; i < underlying.NumFields(); i++ {
+            f := underlying.Field(i)
+            if f.Exported() {
+                set(name+"."+f.Name(), "field", types.TypeString(f.Type(), detailQualifier), f.Name()+" "+types.TypeString(f.Type(), shownQualifier))
+            }
+        }
+    default:
+        shown := types.TypeString(underlying, shownQualifier)
+        set(name, "type", types.TypeString(underlying, detailQualifier)+tparams, "type "+name+" "+shown)
+    }
+
+    for i := 0; i < named.NumMethods(); i++ {
+        m := named.Method(i)
+        if !m.Exported() {
+            continue
+        }
+        sig := m.Type().(*types.Signature)
+        recv := name
+        if _, ok := sig.Recv().Type().(*types.Pointer); ok {
+            recv = "*" + name
+        }
+        // O tipo do receptor faz parte da API: trocar valor por ponteiro altera o conjunto de métodos
+        set(name+"."+m.Name(), "method", "("+recv+") "+signatureKey(sig, detailQualifier),
+            "func ("+recv+") "+m.Name()+strings.TrimPrefix(types.TypeString(sig, shownQualifier), "func"))
+    }
+}
+
+// signatureKey descreve uma assinatura sem os nomes dos parâmetros e resultados,
+// para que renomeações não sejam tratadas como mudanças de API
+func signatureKey(sig *types.Signature, qualifier types.Qualifier) string {
+    list := func(tuple *types.Tuple, variadic bool) string {
+        parts := make([]string, tuple.Len())
+        for i := range parts {
+            t := tuple.At(i).Type()
+            if variadic && i == tuple.Len()-1 {
+                if slice, ok := t.(*types.Slice); ok {
+                    parts[i] = "..." + types.TypeString(slice.Elem(), qualifier)
+                    continue
+                }
+            }
+            parts[i] = types.TypeString(t, qualifier)
+        }
+        return strings.Join(parts, ", ")
+    }
+    return typeParamsKey(sig.TypeParams(), qualifier) + "(" + list(sig.Params(), sig.Variadic()) + ") (" + list(sig.Results(), false) + ")"
+}
+
+// typeParamsKey descreve as restrições dos parâmetros de tipo, sem os nomes
+func typeParamsKey(list *types.TypeParamList, qualifier types.Qualifier) string {
+    if list.Len() == 0 {
+        return ""
+    }
+    parts := make([]string, list.Len())
+    for i := range parts {
+        parts[i] = types.TypeString(list.At(i).Constraint(), qualifier)
+    }
+    return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// Markdown gera o relatório de mudanças da API
+func (d *Diff) Markdown() string {
+    var sb strings.Builder
+    sb.WriteString(fmt.Sprintf("# Mudanças na API: %s → %s\n\n", d.Old, d.New))
+
+    breaking := d.Breaking()
+    sb.WriteString(fmt.Sprintf("**Total:** %d mudanças, %d incompatíveis\n\n", len(d.Changes), len(breaking)))
+    if len(d.Changes) == 0 {
+        sb.WriteString("Nenhuma mudança na API exportada.\n")
+        return sb.String()
+    }
+
+    changes := append([]Change(nil), d.Changes...)
+    sort.SliceStable(changes, func(i, j int) bool {
+        if changes[i].Breaking != changes[j].Breaking {
+            return changes[i].Breaking
+        }
+        return changes[i].Package < changes[j].Package
+    })
+
+    section := func(title string, breaking bool) {
+        var rows []Change
+        for _, c := range changes {
+            if c.Breaking == breaking {
+                rows = append(rows, c)
+            }
+        }
+        if len(rows) == 0 {
+            return
+        }
+        sb.WriteString("## " + title + "\n\n")
+        sb.WriteString("| Pacote | Símbolo | Tipo | Mudança | Antes | Depois |\n")
+        sb.WriteString("|--------|---------|------|---------|-------|--------|\n")
+        for _, c := range rows {
+            sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %s | %s | %s | %s |\n",
+                c.Package, c.Symbol, c.Kind, c.Change, analysis.CodeOrEmpty(c.Old), analysis.CodeOrEmpty(c.New)))
+        }
+        sb.WriteString("\n")
+    }
+    section("Mudanças incompatíveis", true)
+    section("Mudanças compatíveis", false)
+    return sb.String()
+}
+
@@ -0,0 +1,139 @@
+package apidiff_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/apidiff"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestDiffAPI(t *testing.T) {
+    oldPass := godoctest.LoadPass(t, map[string]string{
+        "api/api.go": `package api
+
+const Version = "1"
+
+type Store interface {
+	Get(id string) (string, error)
+}
+
+type User struct {
+	ID   string
+	Name string
+}
+
+func Find(id string) *User { return nil }
+
+func Count(kind string) int { return 0 }
+`,
+    })
+    newPass := godoctest.LoadPass(t, map[string]string{
+        "api/api.go": `package api
+
+const Version = "2"
+
+type Store interface {
+	Get(id string) (string, error)
+	Delete(id string) error
+}
+
+type User struct {
+	ID string
+}
+
+func Find(key string) *User { return nil }
+
+func Count(kind int) int { return 0 }
+
+func New() *User { return nil }
+`,
+    })
+
+    diff := apidiff.Compare(apidiff.Collect(oldPass), apidiff.Collect(newPass), apidiff.Options{})
+
+    got := make(map[string]string)
+    for _, c := range diff.Changes {
+        got[c.Symbol] = c.Change + ":" + strconv.FormatBool(c.Breaking)
+    }
+    want := map[string]string{
+        "Version":      "changed:true",
+        "Store.Delete": "added:true",
+        "User.Name":    "removed:true",
+        "Count":        "changed:true",
+        "New":          "added:false",
+    }
+    for symbol, change := range want {
+        if got[symbol] != change {
+            t.Errorf("%s = %q; want %q", symbol, got[symbol], change)
+        }
+    }
+    if _, ok := got["Find"]; ok {
+        t.Error("renomear parâmetros não deveria alterar a API")
+    }
+    if len(diff.Changes) != len(want) {
+        t.Errorf("mudanças = %+v", diff.Changes)
+    }
+}
+
+func TestDiffAPITypeChecked(t *testing.T) {
+    // Métodos declarados fora do arquivo do tipo e tipos nomeados que não são structs
+    oldPass := godoctest.LoadPass(t, map[string]string{
+        "client/a.go": `package client
+
+type Client struct{}
+`,
+        "client/b.go": `package client
+
+import "context"
+
+type Mode int
+
+func (m Mode) String() string { return "" }
+
+func (c *Client) Do(ctx context.Context, req string) error { return nil }
+
+func (c *Client) Close() error { return nil }
+
+type Handler func(req string) error
+`,
+    })
+    newPass := godoctest.LoadPass(t, map[string]string{
+        "client/a.go": `package client
+
+type Client struct{}
+`,
+        "client/b.go": `package client
+
+func (c Client) Close() error { return nil }
+
+type Handler func(req string, retry bool) error
+`,
+    })
+
+    diff := apidiff.Compare(apidiff.Collect(oldPass), apidiff.Collect(newPass), apidiff.Options{})
+
+    got := make(map[string]string)
+    for _, c := range diff.Changes {
+        got[c.Symbol] = c.Change + ":" + strconv.FormatBool(c.Breaking)
+    }
+    want := map[string]string{
+        "Mode":         "removed:true",
+        "Client.Do":    "removed:true",
+        "Client.Close": "changed:true",
+        "Handler":      "changed:true",
+    }
+    for symbol, change := range want {
+        if got[symbol] != change {
+            t.Errorf("%s = %q; want %q", symbol, got[symbol], change)
+        }
+    }
+    if len(diff.Changes) != len(want) {
+        t.Errorf("mudanças = %+v", diff.Changes)
+    }
+    for _, c := range diff.Changes {
+        if c.Symbol == "Client.Do" && c.Old != "func (*Client) Do(ctx context.Context, req string) error" {
+            t.Errorf("Client.Do exibido como %q", c.Old)
+        }
+    }
+}
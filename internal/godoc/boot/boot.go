@@ -0,0 +1,315 @@
+// Package boot descreve a inicialização dos binários: variáveis e funções init com
+// efeitos colaterais, a ordem de inicialização dos pacotes e o que main() executa
+package boot
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/configref"
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+)
+
+// Map descreve como os binários do projeto inicializam: ordem dos pacotes,
+// variáveis de pacote com inicialização não trivial, funções init() e main()
+type Map struct {
+    Binaries []Binary   `json:"binaries,omitempty" yaml:"binaries,omitempty"`
+    Packages []PackageInit  `json:"packages,omitempty" yaml:"packages,omitempty"` // pacotes com variáveis não triviais ou init()
+}
+
+// Binary representa um pacote main e a sequência de inicialização do binário
+type Binary struct {
+    Name    string       `json:"name"           yaml:"name"`
+    Package string       `json:"package"        yaml:"package"`
+    Order   []string     `json:"order"          yaml:"order"` // pacotes do projeto na ordem de inicialização (o main por último)
+    Main    []InitEffect `json:"main,omitempty" yaml:"main,omitempty"` // efeitos de main()
+    File    string       `json:"file"           yaml:"file"`
+    Line    int          `json:"line"           yaml:"line"`
+}
+
+// PackageInit reúne o que um pacote executa ao ser inicializado
+type PackageInit struct {
+    Package string     `json:"package"         yaml:"package"`
+    Vars    []VarInit  `json:"vars,omitempty"  yaml:"vars,omitempty"`  // na ordem de inicialização do go/types
+    Inits   []InitFunc `json:"inits,omitempty" yaml:"inits,omitempty"` // na ordem dos arquivos
+}
+
+// VarInit representa uma variável de pacote inicializada com chamadas de função
+type VarInit struct {
+    Names   []string     `json:"names"             yaml:"names"`
+    Init    string       `json:"init"              yaml:"init"`
+    Effects []InitEffect `json:"effects,omitempty" yaml:"effects,omitempty"`
+    File    string       `json:"file"              yaml:"file"`
+    Line    int          `json:"line"              yaml:"line"`
+}
+
+// InitFunc representa uma função init() e seus efeitos colaterais
+type InitFunc struct {
+    Effects []InitEffect `json:"effects,omitempty" yaml:"effects,omitempty"`
+    File    string       `json:"file"              yaml:"file"`
+    Line    int          `json:"line"              yaml:"line"`
+}
+
+// InitEffect representa um efeito colateral da inicialização
+type InitEffect struct {
+    Kind   string `json:"kind"   yaml:"kind"` // register, scheme, flag, goroutine, global
+    Detail string `json:"detail" yaml:"detail"`
+    File   string `json:"file"   yaml:"file"`
+    Line   int    `json:"line"   yaml:"line"`
+}
+
+// maxBootExpr limita o tamanho das expressões exibidas
+const maxBootExpr = 80
+
+// bootScanner procura efeitos colaterais de inicialização em um pacote
+type bootScanner struct {
+    fset   *token.FileSet
+    pkg    *analysis.Package
+    flags  *configref.Flags
+}
+
+// Build lista as inicializações de cada pacote (variáveis com chamadas, na ordem
+// calculada pelo go/types, e funções init() na ordem dos arquivos) e, para cada pacote
+// main, a ordem em que os pacotes do projeto são inicializados e os efeitos de main()
+func Build(pass *analysis.Pass, deps *depgraph.Graph) *Map {
+    boot := &Map{}
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil {
+            continue
+        }
+        s := &bootScanner{fset: pass.Fset, pkg: pkg, flags: configref.NewFlags(pkg)}
+        pi := PackageInit{Package: pkg.ImportPath}
+        for _, initializer := range pkg.Info.InitOrder {
+            if !s.nonTrivial(initializer.Rhs) {
+                continue
+            }
+            v := VarInit{Init: shortExpr(initializer.Rhs), Effects: s.effects(initializer.Rhs)}
+            for _, lhs := range initializer.Lhs {
+                v.Names = append(v.Names, lhs.Name())
+            }
+            v.File, v.Line = s.position(initializer.Lhs[0].Pos())
+            pi.Vars = append(pi.Vars, v)
+        }
+
+        var mainDecl *ast.FuncDecl
+        pkg.FuncDecls(func(decl *ast.FuncDecl, obj *types.Func) {
+            if decl.Recv != nil || decl.Body == nil {
+                return
+            }
+            switch {
+            case decl.Name.Name == "init":
+                fn := InitFunc{Effects: s.effects(decl.Body)}
+                fn.File, fn.Line = s.position(decl.Pos())
+                pi.Inits = append(pi.Inits, fn)
+            case decl.Name.Name == "main" && pkg.Name == "main":
+                mainDecl = decl
+            }
+        })
+        if len(pi.Vars) > 0 || len(pi.Inits) > 0 {
+            boot.Packages = append(boot.Packages, pi)
+        }
+
+        if mainDecl != nil {
+            bin := Binary{
+                Name:    filepath.Base(pkg.Dir),
+                Package: pkg.ImportPath,
+                Order:   initOrder(pkg.ImportPath, deps),
+                Main:    s.effects(mainDecl.Body),
+            }
+            bin.File, bin.Line = s.position(mainDecl.Pos())
+            boot.Binaries = append(boot.Binaries, bin)
+        }
+    }
+    if len(boot.Binaries) == 0 && len(boot.Packages) == 0 {
+        return nil
+    }
+    return boot
+}
+
+// initOrder calcula a ordem de inicialização dos pacotes do projeto importados pelo
+// main, seguindo a especificação (Go 1.21+): entre os pacotes ordenados pelo import
+// path, inicializa o primeiro cujos imports já foram todos inicializados
+func initOrder(main string, graph *depgraph.Graph) []string {
+    imports := make(map[string][]string)
+    if graph != nil {
+        for _, e := range graph.Edges {
+            imports[e.From] = append(imports[e.From], e.To)
+        }
+    }
+    reachable := map[string]bool{main: true}
+    queue := []string{main}
+    for len(queue) > 0 {
+        current := queue[0]
+        queue = queue[1:]
+        for _, next := range imports[current] {
+            if !reachable[next] {
+                reachable[next] = true
+                queue = append(queue, next)
+            }
+        }
+    }
+
+    pending := analysis.SortedKeys(reachable)
+    done := make(map[string]bool)
+    var order []string
+    for len(pending) > 0 {
+        pick := 0 // ciclo de imports (código inválido): segue a ordem alfabética
+        for i, pkg := range pending {
+            ready := true
+            for _, dep := range imports[pkg] {
+                if !done[dep] {
+                    ready = false
+                    break
+                }
+            }
+            if ready {
+                pick = i
+                break
+            }
+        }
+        done[pending[pick]] = true
+        order = append(order, pending[pick])
+        pending = append(pending[:pick], pending[pick+1:]...)
+    }
+    return order
+}
+
+// nonTrivial informa se a inicialização chama alguma função (conversões, funções
+// embutidas e construtores de erros sentinela não contam)
+func (s *bootScanner) nonTrivial(expr ast.Expr) bool {
+    found := false
+    ast.Inspect(expr, func(n ast.Node) bool {
+        switch n := n.(type) {
+        case *ast.FuncLit:
+            return false // o corpo só executa quando a função é chamada
+        case *ast.CallExpr:
+            tv := s.pkg.Info.Types[n.Fun]
+            if !tv.IsType() && !tv.IsBuiltin() && !analysis.IsPackageCall(s.pkg.Info, n, "errors", "New") && !analysis.IsPackageCall(s.pkg.Info, n, "fmt", "Errorf") {
+                found = true
+            }
+        }
+        return !found
+    })
+    return found
+}
+
+// effects procura efeitos colaterais: registros em mapas globais e funções Register*,
+// AddToScheme, definições de flags, goroutines e atribuições a variáveis de pacote
+func (s *bootScanner) effects(node ast.Node) []InitEffect {
+    var effects []InitEffect
+    add := func(kind, detail string, n ast.Node) {
+        e := InitEffect{Kind: kind, Detail: detail}
+        e.File, e.Line = s.position(n.Pos())
+        effects = append(effects, e)
+    }
+    info := s.pkg.Info
+    ast.Inspect(node, func(n ast.Node) bool {
+        switch n := n.(type) {
+        case *ast.FuncLit:
+            return false // closures (handlers, callbacks) não executam na inicialização
+        case *ast.GoStmt:
+            add("goroutine", "go "+shortExpr(n.Call), n)
+            return false
+        case *ast.AssignStmt:
+            for _, lhs := range n.Lhs {
+                if index, ok := ast.Unparen(lhs).(*ast.IndexExpr); ok && packageVar(info, index.X) != nil {
+                    add("register", shortExpr(lhs), n)
+                } else if packageVar(info, lhs) != nil {
+                    add("global", shortExpr(lhs), n)
+                }
+            }
+        case *ast.CallExpr:
+            if setting, ok := s.flags.Setting(n); ok {
+                detail := "-" + setting.Name
+                if setting.Type != "" {
+                    detail += " (" + setting.Type + ")"
+                }
+                add("flag", detail, n)
+                return true
+            }
+            name := ""
+            switch fun := ast.Unparen(n.Fun).(type) {
+            case *ast.SelectorExpr:
+                name = fun.Sel.Name
+            case *ast.Ident:
+                name = fun.Name
+            }
+            switch {
+            case name == "AddToScheme":
+                add("scheme", shortExpr(n), n)
+            case strings.HasPrefix(name, "Register") || strings.HasPrefix(name, "MustRegister") ||
+                analysis.IsPackageCall(info, n, "net/http", "Handle") || analysis.IsPackageCall(info, n, "net/http", "HandleFunc"):
+                add("register", shortExpr(n), n)
+            }
+        }
+        return true
+    })
+    return effects
+}
+
+// position retorna arquivo e linha de uma posição
+func (s *bootScanner) position(pos token.Pos) (string, int) {
+    p := s.fset.Position(pos)
+    return p.Filename, p.Line
+}
+
+// packageVar retorna a variável de pacote referenciada por um identificador ou pkg.Var
+func packageVar(info *types.Info, expr ast.Expr) *types.Var {
+    var obj types.Object
+    switch e := ast.Unparen(expr).(type) {
+    case *ast.Ident:
+        obj = info.Uses[e]
+    case *ast.SelectorExpr:
+        if _, ok := analysis.IdentObject(info, e.X).(*types.PkgName); ok {
+            obj = info.Uses[e.Sel]
+        }
+    }
+    v, ok := obj.(*types.Var)
+    if !ok || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() {
+        return nil
+    }
+    return v
+}
+
+// shortExpr formata uma expressão limitando seu tamanho
+func shortExpr(expr ast.Expr) string {
+    text := types.ExprString(expr)
+    if len([]rune(text)) > maxBootExpr {
+        text = string([]rune(text)[:maxBootExpr-1]) + "…"
+    }
+    return text
+}
+
+// BinaryPackages retorna as inicializações dos pacotes do binário na ordem de execução
+func (b *Map) BinaryPackages(bin Binary) []PackageInit {
+    byPath := make(map[string]PackageInit)
+    for _, p := range b.Packages {
+        byPath[p.Package] = p
+    }
+    var result []PackageInit
+    for _, pkg := range bin.Order {
+        if p, ok := byPath[pkg]; ok {
+            result = append(result, p)
+        }
+    }
+    return result
+}
+
+// String descreve o efeito em uma linha
+func (e InitEffect) String() string {
+    return fmt.Sprintf("%s: %s", initEffectLabels[e.Kind], e.Detail)
+}
+
+// initEffectLabels nomeia os tipos de efeito na documentação
+var initEffectLabels = map[string]string{
+    "register":  "registro",
+    "scheme":    "scheme",
+    "flag":      "flag",
+    "goroutine": "goroutine",
+    "global":    "variável global",
+}
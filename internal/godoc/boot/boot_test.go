@@ -0,0 +1,91 @@
+package boot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/boot"
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestBootMap(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "store/store.go": `package store
+
+import (
+    "errors"
+    "regexp"
+)
+
+var ErrNotFound = errors.New("not found")
+
+var pattern = regexp.MustCompile("^[a-z]+$")
+
+var drivers = map[string]string{}
+
+func Register(name string) { drivers[name] = name }
+
+func init() {
+    drivers["memory"] = "memory"
+    go func() {}()
+}
+`,
+        "cmd/api/main.go": `package main
+
+import (
+    "flag"
+
+    "example.com/app/store"
+)
+
+var port = flag.Int("port", 8080, "porta HTTP")
+
+func init() {
+    store.Register("postgres")
+}
+
+func main() {
+    flag.Parse()
+}
+`,
+    })
+
+    m := boot.Build(pass, depgraph.Build(pass))
+    if m == nil || len(m.Binaries) != 1 {
+        t.Fatalf("mapa de inicialização = %+v; want 1 binário", m)
+    }
+    bin := m.Binaries[0]
+    if bin.Name != "api" || strings.Join(bin.Order, " ") != "example.com/app/store example.com/app/cmd/api" {
+        t.Errorf("binário = %s, ordem = %v", bin.Name, bin.Order)
+    }
+
+    var got []string
+    for _, p := range m.BinaryPackages(bin) {
+        for _, v := range p.Vars {
+            got = append(got, "var "+strings.Join(v.Names, ","))
+            for _, e := range v.Effects {
+                got = append(got, "  "+e.String())
+            }
+        }
+        for _, fn := range p.Inits {
+            got = append(got, "init")
+            for _, e := range fn.Effects {
+                got = append(got, "  "+e.String())
+            }
+        }
+    }
+    want := []string{
+        "var pattern",
+        "init",
+        "  registro: drivers[\"memory\"]",
+        "  goroutine: go (func() literal)()",
+        "var port",
+        "  flag: -port (int)",
+        "init",
+        "  registro: store.Register(\"postgres\")",
+    }
+    if strings.Join(got, "\n") != strings.Join(want, "\n") {
+        t.Errorf("inicialização =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+    }
+}
@@ -0,0 +1,266 @@
+package godoc
+
+import (
+	"errors"
+	"go/ast"
+	"go/build/constraint"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// errBuildExcluded indica um arquivo excluído pelas restrições de build configuradas
+var errBuildExcluded = errors.New("arquivo excluído pelas restrições de build")
+
+// knownOS e knownArch seguem as listas do go/build para os sufixos _GOOS/_GOARCH
+var (
+    knownOS = map[string]bool{
+        "aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+        "hurd": true, "illumos": true, "ios": true, "js": true, "linux": true, "nacl": true,
+        "netbsd": true, "openbsd": true, "plan9": true, "solaris": true, "wasip1": true,
+        "windows": true, "zos": true,
+    }
+    knownArch = map[string]bool{
+        "386": true, "amd64": true, "arm": true, "arm64": true, "loong64": true,
+        "mips": true, "mipsle": true, "mips64": true, "mips64le": true, "ppc64": true,
+        "ppc64le": true, "riscv64": true, "s390x": true, "wasm": true,
+    }
+    unixOS = map[string]bool{
+        "aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+        "hurd": true, "illumos": true, "ios": true, "linux": true, "netbsd": true,
+        "openbsd": true, "solaris": true,
+    }
+)
+
+// BuildMatrix documenta em quais plataformas cada símbolo específico existe
+type BuildMatrix struct {
+    Targets []string         `json:"targets" yaml:"targets"`
+    Symbols []PlatformSymbol `json:"symbols" yaml:"symbols"` // apenas símbolos ausentes em algum alvo
+}
+
+// PlatformSymbol representa um símbolo e os alvos de build em que ele existe
+type PlatformSymbol struct {
+    Package string   `json:"package" yaml:"package"`
+    Name    string   `json:"name"    yaml:"name"`
+    Kind    string   `json:"kind"    yaml:"kind"` // function, type, const, var
+    Targets []string `json:"targets" yaml:"targets"`
+}
+
+// Has informa se o símbolo existe no alvo
+func (s PlatformSymbol) Has(target string) bool {
+    return analysis.ContainsString(s.Targets, target)
+}
+
+// buildTarget representa uma combinação GOOS/GOARCH/tags
+type buildTarget struct {
+    goos   string
+    goarch string
+    tags   map[string]bool
+}
+
+// newBuildTarget cria um alvo, usando a plataforma atual quando GOOS/GOARCH não são informados
+func newBuildTarget(goos, goarch string, tags []string) buildTarget {
+    if goos == "" {
+        goos = runtime.GOOS
+    }
+    if goarch == "" {
+        goarch = runtime.GOARCH
+    }
+    t := buildTarget{goos: goos, goarch: goarch, tags: make(map[string]bool)}
+    for _, tag := range tags {
+        t.tags[tag] = true
+    }
+    return t
+}
+
+// String retorna o nome do alvo (ex.: linux/amd64 ou linux/amd64+integration)
+func (t buildTarget) String() string {
+    name := t.goos + "/" + t.goarch
+    if len(t.tags) > 0 {
+        name += "+" + strings.Join(analysis.SortedKeys(t.tags), ",")
+    }
+    return name
+}
+
+// matches avalia uma expressão de restrição (sintaxe do //go:build) para o alvo
+func (t buildTarget) matches(expr string) bool {
+    if expr == "" {
+        return true
+    }
+    parsed, err := constraint.Parse("//go:build " + expr)
+    if err != nil {
+        return true
+    }
+    return parsed.Eval(func(tag string) bool {
+        switch {
+        case tag == t.goos || tag == t.goarch || t.tags[tag]:
+            return true
+        case tag == "unix":
+            return unixOS[t.goos]
+        case tag == "gc":
+            return true
+        case strings.HasPrefix(tag, "go1."):
+            // Tags de versão do Go: assume uma toolchain recente
+            return true
+        }
+        return false
+    })
+}
+
+// buildTargets retorna o alvo principal e, no modo matriz, todos os alvos configurados
+func buildTargets(cfg config.BuildConfig) (buildTarget, []buildTarget) {
+    primary := newBuildTarget(cfg.GOOS, cfg.GOARCH, cfg.Tags)
+    if !cfg.Enabled {
+        return primary, nil
+    }
+    var matrix []buildTarget
+    for _, m := range cfg.Matrix {
+        matrix = append(matrix, newBuildTarget(m.GOOS, m.GOARCH, m.Tags))
+    }
+    if len(matrix) > 0 {
+        primary = matrix[0]
+    }
+    return primary, matrix
+}
+
+// fileConstraint combina a linha //go:build (ou // +build) do arquivo com a
+// restrição implícita nos sufixos _GOOS/_GOARCH do nome
+func fileConstraint(file *ast.File, fileName string) string {
+    var exprs []string
+    if expr := headerConstraint(file); expr != "" {
+        exprs = append(exprs, expr)
+    }
+    if expr := fileNameConstraint(fileName); expr != "" {
+        exprs = append(exprs, expr)
+    }
+    switch len(exprs) {
+    case 0:
+        return ""
+    case 1:
+        return exprs[0]
+    }
+    return "(" + exprs[0] + ") && " + exprs[1]
+}
+
+// headerConstraint lê a restrição dos comentários anteriores à cláusula package
+func headerConstraint(file *ast.File) string {
+    var plusBuild []constraint.Expr
+    for _, group := range file.Comments {
+        if group.Pos() >= file.Package {
+            break
+        }
+        for _, c := range group.List {
+            if !constraint.IsGoBuild(c.Text) && !constraint.IsPlusBuild(c.Text) {
+                continue
+            }
+            expr, err := constraint.Parse(c.Text)
+            if err != nil {
+                continue
+            }
+            if constraint.IsGoBuild(c.Text) {
+                // //go:build tem precedência sobre as linhas // +build
+                return expr.String()
+            }
+            plusBuild = append(plusBuild, expr)
+        }
+    }
+    if len(plusBuild) == 0 {
+        return ""
+    }
+    combined := plusBuild[0]
+    for _, expr := range plusBuild[1:] {
+        combined = &constraint.AndExpr{X: combined, Y: expr}
+    }
+    return combined.String()
+}
+
+// fileNameConstraint segue a regra do go/build para nomes como x_linux.go,
+// x_amd64.go e x_linux_amd64.go (incluindo a variante _test)
+func fileNameConstraint(fileName string) string {
+    name := strings.TrimSuffix(filepath.Base(fileName), ".go")
+    name = strings.TrimSuffix(name, "_test")
+    idx := strings.Index(name, "_")
+    if idx < 0 {
+        return ""
+    }
+    parts := strings.Split(name[idx:], "_")
+    n := len(parts)
+    if n >= 2 && knownOS[parts[n-2]] && knownArch[parts[n-1]] {
+        return parts[n-2] + " && " + parts[n-1]
+    }
+    if knownOS[parts[n-1]] || knownArch[parts[n-1]] {
+        return parts[n-1]
+    }
+    return ""
+}
+
+// buildBuildMatrix lista os símbolos que não existem em todos os alvos da matriz
+func (p *ProjectDoc) buildBuildMatrix(targets []buildTarget) *BuildMatrix {
+    matrix := &BuildMatrix{}
+    for _, t := range targets {
+        matrix.Targets = append(matrix.Targets, t.String())
+    }
+
+    type symbolKey struct{ pkg, kind, name string }
+    available := make(map[symbolKey]map[string]bool)
+    var order []symbolKey
+    add := func(pkg, kind, name string, platforms []string) {
+        key := symbolKey{pkg, kind, name}
+        if _, ok := available[key]; !ok {
+            available[key] = make(map[string]bool)
+            order = append(order, key)
+        }
+        for _, platform := range platforms {
+            available[key][platform] = true
+        }
+    }
+
+    for _, dir := range p.Directories {
+        for _, file := range dir.Files {
+            for _, fn := range file.Functions {
+                add(dir.ImportPath, "function", fn.Name, file.Platforms)
+            }
+            for _, str := range file.Structs {
+                add(dir.ImportPath, "type", str.Name, file.Platforms)
+                for _, m := range str.Methods {
+                    add(dir.ImportPath, "method", str.Name+"."+m.Name, file.Platforms)
+                }
+            }
+            for _, iface := range file.Interfaces {
+                add(dir.ImportPath, "type", iface.Name, file.Platforms)
+            }
+            for _, c := range file.Constants {
+                add(dir.ImportPath, "const", c.Name, file.Platforms)
+            }
+            for _, v := range file.Variables {
+                add(dir.ImportPath, "var", v.Name, file.Platforms)
+            }
+        }
+    }
+
+    for _, key := range order {
+        if len(available[key]) == len(targets) {
+            continue
+        }
+        var platforms []string
+        for _, t := range matrix.Targets {
+            if available[key][t] {
+                platforms = append(platforms, t)
+            }
+        }
+        matrix.Symbols = append(matrix.Symbols, PlatformSymbol{
+            Package: key.pkg,
+            Name:    key.name,
+            Kind:    key.kind,
+            Targets: platforms,
+        })
+    }
+    sort.SliceStable(matrix.Symbols, func(i, j int) bool {
+        return matrix.Symbols[i].Package < matrix.Symbols[j].Package
+    })
+    return matrix
+}
@@ -0,0 +1,52 @@
+package godoc_test
+
+import (
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestBuildConstraints(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "sys/sys.go":         "package sys\n\nfunc Common() {}\n",
+        "sys/sys_linux.go":   "package sys\n\nfunc Open() {}\n\nfunc Epoll() {}\n",
+        "sys/sys_windows.go": "package sys\n\nfunc Open() {}\n",
+        "sys/debug.go":       "//go:build debug && !windows\n\npackage sys\n\nfunc Trace() {}\n",
+    })
+
+    linux := config.BuildTarget{GOOS: "linux", GOARCH: "amd64"}
+    windows := config.BuildTarget{GOOS: "windows", GOARCH: "amd64"}
+
+    cfg := config.GolangConfig{
+        Paths: []string{root},
+        Build: config.BuildConfig{Enabled: true, GOOS: "windows", GOARCH: "amd64"},
+    }
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    if len(doc.Directories[0].Files) != 2 || godoctest.FindFunc(doc, "Epoll") != nil || godoctest.FindFunc(doc, "Trace") != nil {
+        t.Errorf("alvo windows deveria documentar apenas sys.go e sys_windows.go: %+v", doc.Directories[0].Files)
+    }
+
+    cfg.Build = config.BuildConfig{Enabled: true, Matrix: []config.BuildTarget{linux, windows}}
+    doc, err = godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    got := make(map[string][]string)
+    for _, sym := range doc.BuildMatrix.Symbols {
+        got[sym.Name] = sym.Targets
+    }
+    if _, ok := got["Open"]; ok {
+        t.Error("Open existe nos dois alvos e não deveria aparecer na matriz")
+    }
+    if targets := got["Epoll"]; len(targets) != 1 || targets[0] != "linux/amd64" {
+        t.Errorf("Epoll = %v; want [linux/amd64]", targets)
+    }
+    if targets, ok := got["Trace"]; !ok || len(targets) != 0 {
+        t.Errorf("Trace = %v (existe: %v); want nenhum alvo sem a tag debug", targets, ok)
+    }
+}
@@ -0,0 +1,318 @@
+// Package callgraph monta o grafo estático de chamadas entre as funções do projeto
+package callgraph
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Tipos de aresta do grafo de chamadas
+const (
+    CallStatic    = "static"
+    CallInterface = "interface"
+)
+
+// Graph representa o grafo estático de chamadas entre funções do projeto
+type Graph struct {
+    Nodes []Node `json:"nodes" yaml:"nodes"`
+    Edges []Edge `json:"edges" yaml:"edges"`
+}
+
+// Node representa uma função ou método declarado no projeto
+type Node struct {
+    ID       string `json:"id"       yaml:"id"`
+    Name     string `json:"name"     yaml:"name"`
+    Package  string `json:"package"  yaml:"package"`
+    Receiver string `json:"receiver" yaml:"receiver"`
+    File     string `json:"file"     yaml:"file"`
+    Line     int    `json:"line"     yaml:"line"`
+}
+
+// Edge representa uma chamada de Caller para Callee
+type Edge struct {
+    Caller string `json:"caller" yaml:"caller"`
+    Callee string `json:"callee" yaml:"callee"`
+    Kind   string `json:"kind"   yaml:"kind"` // static, interface
+    File   string `json:"file"   yaml:"file"`
+    Line   int    `json:"line"   yaml:"line"`
+}
+
+// Build resolve as chamadas estáticas e de interface de todos os pacotes
+func Build(pass *analysis.Pass) *Graph {
+    graph := &Graph{}
+    declared := make(map[*types.Func]bool)
+
+    for _, pkg := range pass.Packages {
+        pkg.FuncDecls(func(decl *ast.FuncDecl, obj *types.Func) {
+            declared[obj] = true
+            pos := pass.Fset.Position(decl.Pos())
+            graph.Nodes = append(graph.Nodes, Node{
+                ID:       obj.FullName(),
+                Name:     analysis.ShortFuncName(obj),
+                Package:  pkg.ImportPath,
+                Receiver: analysis.ReceiverName(obj),
+                File:     pos.Filename,
+                Line:     pos.Line,
+            })
+        })
+    }
+
+    implementations := collectNamedTypes(pass.Packages)
+    seen := make(map[string]bool)
+    addEdge := func(caller, callee *types.Func, kind string, call *ast.CallExpr) {
+        key := caller.FullName() + "->" + callee.FullName()
+        if seen[key] {
+            return
+        }
+        seen[key] = true
+        pos := pass.Fset.Position(call.Pos())
+        graph.Edges = append(graph.Edges, Edge{
+            Caller: caller.FullName(),
+            Callee: callee.FullName(),
+            Kind:   kind,
+            File:   pos.Filename,
+            Line:   pos.Line,
+        })
+    }
+
+    for _, pkg := range pass.Packages {
+        pkg.FuncDecls(func(decl *ast.FuncDecl, caller *types.Func) {
+            if decl.Body == nil {
+                return
+            }
+            ast.Inspect(decl.Body, func(n ast.Node) bool {
+                call, ok := n.(*ast.CallExpr)
+                if !ok {
+                    return true
+                }
+                callee, dynamic := analysis.ResolveCallee(pkg.Info, call)
+                if callee == nil {
+                    return true
+                }
+                if !dynamic {
+                    if declared[callee] {
+                        addEdge(caller, callee, CallStatic, call)
+                    }
+                    return true
+                }
+                for _, impl := range implementationsOf(callee, implementations) {
+                    if declared[impl] {
+                        addEdge(caller, impl, CallInterface, call)
+                    }
+                }
+                return true
+            })
+        })
+    }
+
+    return graph
+}
+
+// collectNamedTypes lista os tipos concretos nomeados declarados nos pacotes
+func collectNamedTypes(packages []*analysis.Package) []*types.Named {
+    var named []*types.Named
+    for _, pkg := range packages {
+        if pkg.Types == nil {
+            continue
+        }
+        scope := pkg.Types.Scope()
+        for _, name := range scope.Names() {
+            tn, ok := scope.Lookup(name).(*types.TypeName)
+            if !ok || tn.IsAlias() {
+                continue
+            }
+            if n, ok := tn.Type().(*types.Named); ok && !types.IsInterface(n) && n.TypeParams() == nil {
+                named = append(named, n)
+            }
+        }
+    }
+    return named
+}
+
+// implementationsOf resolve um método de interface para os métodos concretos conhecidos
+func implementationsOf(method *types.Func, named []*types.Named) []*types.Func {
+    sig, ok := method.Type().(*types.Signature)
+    if !ok || sig.Recv() == nil {
+        return nil
+    }
+    iface, ok := sig.Recv().Type().Underlying().(*types.Interface)
+    if !ok {
+        return nil
+    }
+
+    var result []*types.Func
+    for _, n := range named {
+        var recv types.Type = n
+        if !types.Implements(recv, iface) {
+            recv = types.NewPointer(n)
+            if !types.Implements(recv, iface) {
+                continue
+            }
+        }
+        obj, _, _ := types.LookupFieldOrMethod(recv, true, method.Pkg(), method.Name())
+        if fn, ok := obj.(*types.Func); ok {
+            result = append(result, fn)
+        }
+    }
+    return result
+}
+
+// Packages retorna os import paths que possuem funções no grafo
+func (g *Graph) Packages() []string {
+    set := make(map[string]bool)
+    for _, n := range g.Nodes {
+        set[n.Package] = true
+    }
+    return analysis.SortedKeys(set)
+}
+
+// EntryPoints retorna as funções main e as entradas configuradas presentes no grafo
+func (g *Graph) EntryPoints(configured []string) []Node {
+    var result []Node
+    for _, n := range g.Nodes {
+        isMain := n.Name == "main.main"
+        if isMain || MatchesEntryPoint(n, configured) {
+            result = append(result, n)
+        }
+    }
+    return result
+}
+
+// MatchesEntryPoint verifica se um nó corresponde a uma entrada configurada
+// (aceita o ID completo, o nome curto pkg.Func ou o caminho importpath.Func)
+func MatchesEntryPoint(n Node, configured []string) bool {
+    for _, entry := range configured {
+        if entry == n.ID || entry == n.Name {
+            return true
+        }
+        if strings.HasSuffix(n.ID, "/"+entry) {
+            return true
+        }
+    }
+    return false
+}
+
+// Subgraph retorna o grafo restrito às funções de um pacote e às funções que elas chamam
+func (g *Graph) Subgraph(pkg string) *Graph {
+    sub := &Graph{}
+    packageOf := make(map[string]string)
+    for _, n := range g.Nodes {
+        packageOf[n.ID] = n.Package
+    }
+    include := make(map[string]bool)
+    for _, e := range g.Edges {
+        if packageOf[e.Caller] == pkg {
+            sub.Edges = append(sub.Edges, e)
+            include[e.Caller] = true
+            include[e.Callee] = true
+        }
+    }
+    for _, n := range g.Nodes {
+        if n.Package == pkg || include[n.ID] {
+            sub.Nodes = append(sub.Nodes, n)
+        }
+    }
+    return sub
+}
+
+// Reachable retorna o grafo alcançável a partir de uma função até a profundidade informada
+// (profundidade <= 0 significa ilimitada)
+func (g *Graph) Reachable(root string, maxDepth int) *Graph {
+    sub := &Graph{}
+    adjacency := make(map[string][]Edge)
+    for _, e := range g.Edges {
+        adjacency[e.Caller] = append(adjacency[e.Caller], e)
+    }
+
+    depth := map[string]int{root: 0}
+    queue := []string{root}
+    for len(queue) > 0 {
+        current := queue[0]
+        queue = queue[1:]
+        if maxDepth > 0 && depth[current] >= maxDepth {
+            continue
+        }
+        for _, e := range adjacency[current] {
+            sub.Edges = append(sub.Edges, e)
+            if _, ok := depth[e.Callee]; !ok {
+                depth[e.Callee] = depth[current] + 1
+                queue = append(queue, e.Callee)
+            }
+        }
+    }
+
+    for _, n := range g.Nodes {
+        if _, ok := depth[n.ID]; ok {
+            sub.Nodes = append(sub.Nodes, n)
+        }
+    }
+    return sub
+}
+
+// Index agrupa as arestas por chamador e por chamado
+func (g *Graph) Index() (map[string][]string, map[string][]string) {
+    calls := make(map[string][]string)
+    calledBy := make(map[string][]string)
+    for _, e := range g.Edges {
+        calls[e.Caller] = append(calls[e.Caller], e.Callee)
+        calledBy[e.Callee] = append(calledBy[e.Callee], e.Caller)
+    }
+    for _, m := range []map[string][]string{calls, calledBy} {
+        for k := range m {
+            sort.Strings(m[k])
+        }
+    }
+    return calls, calledBy
+}
+
+// Mermaid renderiza o grafo como um flowchart Mermaid
+// (chamadas via interface aparecem tracejadas)
+func (g *Graph) Mermaid() string {
+    var sb strings.Builder
+    sb.WriteString("flowchart LR\n")
+
+    ids := make(map[string]string)
+    for i, n := range g.Nodes {
+        ids[n.ID] = fmt.Sprintf("n%d", i)
+        sb.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", ids[n.ID], n.Name))
+    }
+    for _, e := range g.Edges {
+        from, okFrom := ids[e.Caller]
+        to, okTo := ids[e.Callee]
+        if !okFrom || !okTo {
+            continue
+        }
+        arrow := "-->"
+        if e.Kind == CallInterface {
+            arrow = "-.->"
+        }
+        sb.WriteString(fmt.Sprintf("    %s %s %s\n", from, arrow, to))
+    }
+    return sb.String()
+}
+
+// DOT renderiza o grafo no formato Graphviz DOT
+func (g *Graph) DOT(name string) string {
+    var sb strings.Builder
+    sb.WriteString(fmt.Sprintf("digraph %q {\n", name))
+    sb.WriteString("    rankdir=LR;\n")
+    sb.WriteString("    node [shape=box, fontname=\"Helvetica\"];\n")
+
+    for _, n := range g.Nodes {
+        sb.WriteString(fmt.Sprintf("    %q [label=%q];\n", n.ID, n.Name))
+    }
+    for _, e := range g.Edges {
+        if e.Kind == CallInterface {
+            sb.WriteString(fmt.Sprintf("    %q -> %q [style=dashed];\n", e.Caller, e.Callee))
+        } else {
+            sb.WriteString(fmt.Sprintf("    %q -> %q;\n", e.Caller, e.Callee))
+        }
+    }
+    sb.WriteString("}\n")
+    return sb.String()
+}
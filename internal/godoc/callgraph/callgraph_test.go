@@ -0,0 +1,66 @@
+package callgraph_test
+
+import (
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/callgraph"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestCallGraph(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "store/store.go": `package store
+
+type Store interface {
+    Get(id string) string
+}
+
+type Memory struct{}
+
+func (m *Memory) Get(id string) string { return lookup(id) }
+
+func lookup(id string) string { return id }
+`,
+        "service/service.go": `package service
+
+import "example.com/app/store"
+
+func Find(s store.Store, id string) string {
+    return s.Get(id)
+}
+
+func Default() string {
+    return Find(&store.Memory{}, "1")
+}
+`,
+    })
+
+    graph := callgraph.Build(pass)
+    edges := make(map[string]string)
+    for _, e := range graph.Edges {
+        edges[e.Caller+" -> "+e.Callee] = e.Kind
+    }
+
+    cases := []struct {
+        edge string
+        kind string
+    }{
+        {"example.com/app/service.Default -> example.com/app/service.Find", callgraph.CallStatic},
+        {"example.com/app/service.Find -> (*example.com/app/store.Memory).Get", callgraph.CallInterface},
+        {"(*example.com/app/store.Memory).Get -> example.com/app/store.lookup", callgraph.CallStatic},
+    }
+    for _, tc := range cases {
+        if got, ok := edges[tc.edge]; !ok || got != tc.kind {
+            t.Errorf("aresta %q = %q (existe: %v); want %q", tc.edge, got, ok, tc.kind)
+        }
+    }
+
+    calls, calledBy := graph.Index()
+    const find = "example.com/app/service.Find"
+    if got := calledBy[find]; len(got) != 1 || got[0] != "example.com/app/service.Default" {
+        t.Errorf("Find chamada por %v; want [example.com/app/service.Default]", got)
+    }
+    if got := calls[find]; len(got) != 1 || got[0] != "(*example.com/app/store.Memory).Get" {
+        t.Errorf("Find chama %v; want [(*example.com/app/store.Memory).Get]", got)
+    }
+}
@@ -0,0 +1,197 @@
+package callgraph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sequenceParticipant representa um participante (pacote ou tipo receptor)
+type sequenceParticipant struct {
+    ID      string
+    Name    string
+    Package string // nome do pacote, usado para agrupar tipos receptores
+}
+
+// sequenceStep representa uma chamada, o início ou o fim de uma ativação
+type sequenceStep struct {
+    Kind     string // call, activate, deactivate
+    From, To string
+    Label    string
+}
+
+// Sequence acumula os participantes e passos de um diagrama de sequência
+type Sequence struct {
+    participants []sequenceParticipant
+    known        map[string]string // pacote ou tipo receptor -> ID do participante
+    steps        []sequenceStep
+    grouped      bool
+}
+
+// Sequence percorre as chamadas a partir de root em ordem de código até a profundidade
+// informada (<= 0 significa ilimitada). Cada função é expandida uma única vez; chamadas
+// seguintes a ela, e as recursivas, aparecem como uma seta sem as chamadas internas.
+// groupBy define os participantes: package ou receiver
+func (g *Graph) Sequence(root string, maxDepth int, groupBy string) *Sequence {
+    nodes := make(map[string]Node)
+    for _, n := range g.Nodes {
+        nodes[n.ID] = n
+    }
+    calls := make(map[string][]Edge)
+    for _, e := range g.Edges {
+        calls[e.Caller] = append(calls[e.Caller], e)
+    }
+    for caller := range calls {
+        edges := calls[caller]
+        sort.SliceStable(edges, func(i, j int) bool {
+            if edges[i].File != edges[j].File {
+                return edges[i].File < edges[j].File
+            }
+            return edges[i].Line < edges[j].Line
+        })
+    }
+
+    seq := &Sequence{known: make(map[string]string), grouped: groupBy == "receiver"}
+    participant := func(n Node) string {
+        pkgName := n.Name
+        if i := strings.Index(pkgName, "."); i >= 0 {
+            pkgName = pkgName[:i]
+        }
+        key, name := n.Package, pkgName
+        if seq.grouped && n.Receiver != "" {
+            key, name = n.Package+"."+n.Receiver, n.Receiver
+        }
+        if id, ok := seq.known[key]; ok {
+            return id
+        }
+        id := fmt.Sprintf("p%d", len(seq.participants))
+        seq.known[key] = id
+        seq.participants = append(seq.participants, sequenceParticipant{ID: id, Name: name, Package: pkgName})
+        return id
+    }
+    label := func(n Node) string {
+        name := strings.TrimPrefix(n.Name, strings.SplitN(n.Name, ".", 2)[0]+".")
+        if seq.grouped && n.Receiver != "" {
+            name = strings.TrimPrefix(name, n.Receiver+".")
+        }
+        return name + "()"
+    }
+
+    rootNode, ok := nodes[root]
+    if !ok {
+        return seq
+    }
+    expanded := make(map[string]bool)
+    var visit func(id string, depth int)
+    visit = func(id string, depth int) {
+        if maxDepth > 0 && depth >= maxDepth {
+            return
+        }
+        expanded[id] = true
+        from := participant(nodes[id])
+        for _, e := range calls[id] {
+            callee, ok := nodes[e.Callee]
+            if !ok {
+                continue
+            }
+            to := participant(callee)
+            text := label(callee)
+            if e.Kind == CallInterface {
+                text += " (interface)"
+            }
+            seq.steps = append(seq.steps, sequenceStep{Kind: "call", From: from, To: to, Label: text})
+            if expanded[e.Callee] {
+                continue // já expandida ou recursão: a chamada aparece sem ser expandida
+            }
+            seq.steps = append(seq.steps, sequenceStep{Kind: "activate", To: to})
+            visit(e.Callee, depth+1)
+            seq.steps = append(seq.steps, sequenceStep{Kind: "deactivate", To: to})
+        }
+    }
+    participant(rootNode)
+    visit(root, 0)
+    return seq
+}
+
+// groups agrupa os participantes por pacote, na ordem de aparição
+func (s *Sequence) groups() ([]string, map[string][]sequenceParticipant) {
+    var order []string
+    groups := make(map[string][]sequenceParticipant)
+    for _, p := range s.participants {
+        if _, ok := groups[p.Package]; !ok {
+            order = append(order, p.Package)
+        }
+        groups[p.Package] = append(groups[p.Package], p)
+    }
+    return order, groups
+}
+
+// Mermaid renderiza a sequência como um sequenceDiagram Mermaid; com participantes
+// por tipo receptor, cada pacote vira um box
+func (s *Sequence) Mermaid() string {
+    var sb strings.Builder
+    sb.WriteString("sequenceDiagram\n")
+    if s.grouped {
+        order, groups := s.groups()
+        for _, pkg := range order {
+            sb.WriteString(fmt.Sprintf("    box %s\n", pkg))
+            for _, p := range groups[pkg] {
+                sb.WriteString(fmt.Sprintf("        participant %s as %s\n", p.ID, p.Name))
+            }
+            sb.WriteString("    end\n")
+        }
+    } else {
+        for _, p := range s.participants {
+            sb.WriteString(fmt.Sprintf("    participant %s as %s\n", p.ID, p.Name))
+        }
+    }
+    for _, step := range s.steps {
+        switch step.Kind {
+        case "call":
+            sb.WriteString(fmt.Sprintf("    %s->>%s: %s\n", step.From, step.To, step.Label))
+        case "activate":
+            sb.WriteString(fmt.Sprintf("    activate %s\n", step.To))
+        case "deactivate":
+            sb.WriteString(fmt.Sprintf("    deactivate %s\n", step.To))
+        }
+    }
+    return sb.String()
+}
+
+// PlantUML renderiza a sequência como um diagrama de sequência PlantUML
+func (s *Sequence) PlantUML() string {
+    var sb strings.Builder
+    sb.WriteString("@startuml\n\n")
+    if s.grouped {
+        order, groups := s.groups()
+        for _, pkg := range order {
+            sb.WriteString(fmt.Sprintf("box %q\n", pkg))
+            for _, p := range groups[pkg] {
+                sb.WriteString(fmt.Sprintf("participant %q as %s\n", p.Name, p.ID))
+            }
+            sb.WriteString("end box\n")
+        }
+    } else {
+        for _, p := range s.participants {
+            sb.WriteString(fmt.Sprintf("participant %q as %s\n", p.Name, p.ID))
+        }
+    }
+    sb.WriteString("\n")
+    for _, step := range s.steps {
+        switch step.Kind {
+        case "call":
+            sb.WriteString(fmt.Sprintf("%s -> %s : %s\n", step.From, step.To, step.Label))
+        case "activate":
+            sb.WriteString(fmt.Sprintf("activate %s\n", step.To))
+        case "deactivate":
+            sb.WriteString(fmt.Sprintf("deactivate %s\n", step.To))
+        }
+    }
+    sb.WriteString("\n@enduml\n")
+    return sb.String()
+}
+
+// Empty informa se a sequência não possui chamadas
+func (s *Sequence) Empty() bool {
+    return len(s.steps) == 0
+}
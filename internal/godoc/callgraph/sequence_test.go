@@ -0,0 +1,126 @@
+package callgraph_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/callgraph"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestSequenceDiagrams(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "store/store.go": `package store
+
+type Store interface {
+    Get(id string) string
+}
+
+type Memory struct{}
+
+func (m *Memory) Get(id string) string { return normalize(id) }
+
+func normalize(id string) string { return id }
+`,
+        "service/service.go": `package service
+
+import (
+    "net/http"
+
+    "example.com/app/store"
+)
+
+type Service struct {
+    store store.Store
+}
+
+func (s *Service) Find(id string) string {
+    s.audit(id)
+    return s.store.Get(id)
+}
+
+func (s *Service) audit(id string) {}
+
+type Handler struct {
+    svc *Service
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+    h.svc.Find(r.URL.Query().Get("id"))
+}
+
+func Register(mux *http.ServeMux, h *Handler) {
+    mux.HandleFunc("GET /items", h.Get)
+}
+`,
+    })
+
+    graph := callgraph.Build(pass)
+
+    // Profundidade 2: Handler.Get -> Service.Find -> (audit, Store.Get), sem normalize
+    want := `sequenceDiagram
+    box service
+        participant p0 as Handler
+        participant p1 as Service
+    end
+    box store
+        participant p2 as Memory
+    end
+    p0->>p1: Find()
+    activate p1
+    p1->>p1: audit()
+    activate p1
+    deactivate p1
+    p1->>p2: Get() (interface)
+    activate p2
+    deactivate p2
+    deactivate p1
+`
+    if got := graph.Sequence("(*example.com/app/service.Handler).Get", 2, "receiver").Mermaid(); got != want {
+        t.Errorf("Mermaid =\n%s\nwant\n%s", got, want)
+    }
+
+    plantUML := graph.Sequence("(*example.com/app/service.Service).Find", 2, "receiver").PlantUML()
+    for _, s := range []string{`participant "Service" as p0`, "p1 -> p2 : normalize()", "end box"} {
+        if !strings.Contains(plantUML, s) {
+            t.Errorf("PlantUML de Service.Find sem %q:\n%s", s, plantUML)
+        }
+    }
+}
+
+func TestSequenceExpandsOnce(t *testing.T) {
+    // Cadeia de losangos: f0 chama a0 e b0, que chamam f1, e assim por diante. Sem limite de
+    // profundidade, expandir f1 a partir de a0 e de b0 dobraria o diagrama a cada nível
+    const levels = 40
+    graph := &callgraph.Graph{}
+    node := func(name string) {
+        graph.Nodes = append(graph.Nodes, callgraph.Node{ID: "app." + name, Name: "app." + name, Package: "app"})
+    }
+    call := func(caller, callee string, line int) {
+        graph.Edges = append(graph.Edges, callgraph.Edge{
+            Caller: "app." + caller, Callee: "app." + callee, Kind: callgraph.CallStatic, File: "app.go", Line: line,
+        })
+    }
+    for i := 0; i < levels; i++ {
+        f, a, b, next := fmt.Sprintf("f%d", i), fmt.Sprintf("a%d", i), fmt.Sprintf("b%d", i), fmt.Sprintf("f%d", i+1)
+        node(f)
+        node(a)
+        node(b)
+        call(f, a, 1)
+        call(f, b, 2)
+        call(a, next, 3)
+        call(b, next, 4)
+    }
+    node(fmt.Sprintf("f%d", levels))
+
+    seq := graph.Sequence("app.f0", 0, "package")
+    mermaid := seq.Mermaid()
+    // Cada nível tem 4 chamadas; só a primeira chamada a f(i+1) é expandida
+    if calls := strings.Count(mermaid, "->>"); calls != 4*levels {
+        t.Errorf("chamadas = %d; want %d", calls, 4*levels)
+    }
+    if activations := strings.Count(mermaid, "activate p0\n") - strings.Count(mermaid, "deactivate p0\n"); activations != 3*levels {
+        t.Errorf("ativações = %d; want %d (f, a e b de cada nível, uma vez)", activations, 3*levels)
+    }
+}
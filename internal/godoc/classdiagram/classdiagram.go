@@ -0,0 +1,455 @@
+// Package classdiagram extrai os tipos nomeados do projeto e as relações entre eles
+package classdiagram
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Tipos de relação do diagrama de classes
+const (
+    RelationEmbeds     = "embeds"
+    RelationField      = "field"
+    RelationImplements = "implements"
+)
+
+// Graph representa os tipos nomeados do projeto e as relações entre eles
+type Graph struct {
+    Types     []Node     `json:"types"     yaml:"types"`
+    Relations []Relation `json:"relations" yaml:"relations"`
+}
+
+// Node representa uma struct, interface ou outro tipo nomeado com métodos
+type Node struct {
+    ID          string        `json:"id"                yaml:"id"` // importpath.Tipo
+    Name        string        `json:"name"              yaml:"name"`
+    Package     string        `json:"package"           yaml:"package"`
+    PackageName string        `json:"package_name"      yaml:"package_name"`
+    Kind        string        `json:"kind"              yaml:"kind"` // struct, interface, type
+    Fields      []Member `json:"fields,omitempty"  yaml:"fields,omitempty"`
+    Methods     []Member `json:"methods,omitempty" yaml:"methods,omitempty"`
+    File        string        `json:"file"              yaml:"file"`
+    Line        int           `json:"line"              yaml:"line"`
+}
+
+// Member representa um campo ou método de um tipo
+type Member struct {
+    Name     string `json:"name"     yaml:"name"`
+    Type     string `json:"type"     yaml:"type"` // tipo do campo ou assinatura do método
+    Exported bool   `json:"exported" yaml:"exported"`
+}
+
+// Relation representa uma relação entre dois tipos do projeto
+type Relation struct {
+    From  string `json:"from"            yaml:"from"`
+    To    string `json:"to"              yaml:"to"`
+    Kind  string `json:"kind"            yaml:"kind"` // embeds, field, implements
+    Label string `json:"label,omitempty" yaml:"label,omitempty"` // nome do campo
+}
+
+// Build extrai structs, interfaces e tipos com métodos, com as relações de
+// composição, campo e implementação entre eles
+func Build(pass *analysis.Pass, cfg config.ClassDiagramConfig) *Graph {
+    graph := &Graph{}
+    exportedOnly := cfg.ExportedOnly
+    nodes := make(map[*types.TypeName]string)
+    var named []*types.Named
+
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil {
+            continue
+        }
+        qualifier := nameQualifier(pkg.Types)
+        scope := pkg.Types.Scope()
+        for _, name := range scope.Names() {
+            tn, ok := scope.Lookup(name).(*types.TypeName)
+            if !ok || tn.IsAlias() || (exportedOnly && !tn.Exported()) {
+                continue
+            }
+            n, ok := tn.Type().(*types.Named)
+            if !ok {
+                continue
+            }
+            node := Node{
+                ID:          pkg.ImportPath + "." + tn.Name(),
+                Name:        tn.Name(),
+                Package:     pkg.ImportPath,
+                PackageName: pkg.Name,
+            }
+            switch u := n.Underlying().(type) {
+            case *types.Struct:
+                node.Kind = "struct"
+                for i := 0; i < u.NumFields(); i++ {
+                    f := u.Field(i)
+                    if exportedOnly && !f.Exported() {
+                        continue
+                    }
+                    node.Fields = append(node.Fields, Member{
+                        Name:     f.Name(),
+                        Type:     types.TypeString(f.Type(), qualifier),
+                        Exported: f.Exported(),
+                    })
+                }
+            case *types.Interface:
+                node.Kind = "interface"
+                for i := 0; i < u.NumExplicitMethods(); i++ {
+                    node.Methods = append(node.Methods, classMethod(u.ExplicitMethod(i), qualifier))
+                }
+            default:
+                if n.NumMethods() == 0 {
+                    continue
+                }
+                node.Kind = "type"
+            }
+            for i := 0; i < n.NumMethods(); i++ {
+                m := n.Method(i)
+                if exportedOnly && !m.Exported() {
+                    continue
+                }
+                node.Methods = append(node.Methods, classMethod(m, qualifier))
+            }
+            pos := pass.Fset.Position(tn.Pos())
+            node.File, node.Line = pos.Filename, pos.Line
+            nodes[tn] = node.ID
+            named = append(named, n)
+            graph.Types = append(graph.Types, node)
+        }
+    }
+
+    seen := make(map[string]bool)
+    addRelation := func(from, to, kind, label string) {
+        key := from + "|" + to + "|" + kind + "|" + label
+        if from == to || seen[key] {
+            return
+        }
+        seen[key] = true
+        graph.Relations = append(graph.Relations, Relation{From: from, To: to, Kind: kind, Label: label})
+    }
+
+    for _, n := range named {
+        from := nodes[n.Obj()]
+        switch u := n.Underlying().(type) {
+        case *types.Struct:
+            for i := 0; i < u.NumFields(); i++ {
+                f := u.Field(i)
+                for _, target := range referencedTypes(f.Type()) {
+                    to, ok := nodes[target]
+                    if !ok {
+                        continue
+                    }
+                    if f.Embedded() {
+                        addRelation(from, to, RelationEmbeds, "")
+                    } else if !exportedOnly || f.Exported() {
+                        addRelation(from, to, RelationField, f.Name())
+                    }
+                }
+            }
+        case *types.Interface:
+            for i := 0; i < u.NumEmbeddeds(); i++ {
+                if e, ok := u.EmbeddedType(i).(*types.Named); ok {
+                    if to, ok := nodes[e.Obj()]; ok {
+                        addRelation(from, to, RelationEmbeds, "")
+                    }
+                }
+            }
+        }
+    }
+
+    // Implementações: tipos concretos (T ou *T) que satisfazem interfaces não vazias do projeto
+    for _, iface := range named {
+        it, ok := iface.Underlying().(*types.Interface)
+        if !ok || it.NumMethods() == 0 || iface.TypeParams() != nil {
+            continue
+        }
+        for _, n := range named {
+            if types.IsInterface(n) || n.TypeParams() != nil {
+                continue
+            }
+            if types.Implements(n, it) || types.Implements(types.NewPointer(n), it) {
+                addRelation(nodes[n.Obj()], nodes[iface.Obj()], RelationImplements, "")
+            }
+        }
+    }
+
+    sort.SliceStable(graph.Relations, func(i, j int) bool {
+        ri, rj := graph.Relations[i], graph.Relations[j]
+        if ri.From != rj.From {
+            return ri.From < rj.From
+        }
+        return ri.To < rj.To
+    })
+    return graph
+}
+
+// nameQualifier qualifica tipos de outros pacotes apenas pelo nome do pacote
+func nameQualifier(pkg *types.Package) types.Qualifier {
+    return func(other *types.Package) string {
+        if other == pkg {
+            return ""
+        }
+        return other.Name()
+    }
+}
+
+// classMethod descreve um método com a assinatura sem o receptor
+func classMethod(m *types.Func, qualifier types.Qualifier) Member {
+    sig := m.Type().(*types.Signature)
+    params := strings.TrimPrefix(types.TypeString(types.NewSignatureType(nil, nil, nil, sig.Params(), sig.Results(), sig.Variadic()), qualifier), "func")
+    return Member{Name: m.Name(), Type: params, Exported: m.Exported()}
+}
+
+// referencedTypes retorna os tipos nomeados referenciados por um tipo de campo
+// (através de ponteiros, slices, arrays, maps e canais)
+func referencedTypes(t types.Type) []*types.TypeName {
+    switch u := t.(type) {
+    case *types.Named:
+        return []*types.TypeName{u.Origin().Obj()}
+    case *types.Pointer:
+        return referencedTypes(u.Elem())
+    case *types.Slice:
+        return referencedTypes(u.Elem())
+    case *types.Array:
+        return referencedTypes(u.Elem())
+    case *types.Chan:
+        return referencedTypes(u.Elem())
+    case *types.Map:
+        return append(referencedTypes(u.Key()), referencedTypes(u.Elem())...)
+    }
+    return nil
+}
+
+// Packages retorna os import paths que possuem tipos no grafo
+func (g *Graph) Packages() []string {
+    set := make(map[string]bool)
+    for _, n := range g.Types {
+        set[n.Package] = true
+    }
+    return analysis.SortedKeys(set)
+}
+
+// Focus retorna os tipos que correspondem às entradas configuradas
+// (aceita o ID completo, pkg.Tipo ou o sufixo do caminho, como em entry_points)
+func (g *Graph) Focus(configured []string) []Node {
+    var result []Node
+    for _, n := range g.Types {
+        for _, entry := range configured {
+            if entry == n.ID || entry == n.PackageName+"."+n.Name || strings.HasSuffix(n.ID, "/"+entry) {
+                result = append(result, n)
+                break
+            }
+        }
+    }
+    return result
+}
+
+// Neighborhood retorna o grafo com os tipos iniciais e os vizinhos até a profundidade
+// informada, seguindo as relações nos dois sentidos (profundidade <= 0 significa ilimitada)
+func (g *Graph) Neighborhood(roots []string, maxDepth int) *Graph {
+    adjacency := make(map[string][]string)
+    for _, r := range g.Relations {
+        adjacency[r.From] = append(adjacency[r.From], r.To)
+        adjacency[r.To] = append(adjacency[r.To], r.From)
+    }
+
+    depth := make(map[string]int)
+    var queue []string
+    for _, root := range roots {
+        if _, ok := depth[root]; !ok {
+            depth[root] = 0
+            queue = append(queue, root)
+        }
+    }
+    for len(queue) > 0 {
+        current := queue[0]
+        queue = queue[1:]
+        if maxDepth > 0 && depth[current] >= maxDepth {
+            continue
+        }
+        for _, next := range adjacency[current] {
+            if _, ok := depth[next]; !ok {
+                depth[next] = depth[current] + 1
+                queue = append(queue, next)
+            }
+        }
+    }
+
+    sub := &Graph{}
+    for _, n := range g.Types {
+        if _, ok := depth[n.ID]; ok {
+            sub.Types = append(sub.Types, n)
+        }
+    }
+    for _, r := range g.Relations {
+        _, okFrom := depth[r.From]
+        _, okTo := depth[r.To]
+        if okFrom && okTo {
+            sub.Relations = append(sub.Relations, r)
+        }
+    }
+    return sub
+}
+
+// PackageGraph retorna os tipos de um pacote e os vizinhos até a profundidade informada
+func (g *Graph) PackageGraph(pkg string, maxDepth int) *Graph {
+    var roots []string
+    for _, n := range g.Types {
+        if n.Package == pkg {
+            roots = append(roots, n.ID)
+        }
+    }
+    return g.Neighborhood(roots, maxDepth)
+}
+
+// namespaces escolhe o nome de cada pacote no diagrama: o nome do pacote,
+// ou o import path quando dois pacotes do diagrama têm o mesmo nome
+func (g *Graph) namespaces() map[string]string {
+    byName := make(map[string]map[string]bool)
+    for _, n := range g.Types {
+        if byName[n.PackageName] == nil {
+            byName[n.PackageName] = make(map[string]bool)
+        }
+        byName[n.PackageName][n.Package] = true
+    }
+    names := make(map[string]string)
+    for _, n := range g.Types {
+        if len(byName[n.PackageName]) > 1 {
+            names[n.Package] = n.Package
+        } else {
+            names[n.Package] = n.PackageName
+        }
+    }
+    return names
+}
+
+// groupByPackage agrupa os tipos por pacote, na ordem dos import paths
+func (g *Graph) groupByPackage() ([]string, map[string][]Node) {
+    groups := make(map[string][]Node)
+    for _, n := range g.Types {
+        groups[n.Package] = append(groups[n.Package], n)
+    }
+    return analysis.SortedKeys(groups), groups
+}
+
+// classID gera um identificador válido em Mermaid e PlantUML
+func classID(id string) string {
+    var sb strings.Builder
+    for _, r := range id {
+        if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+            sb.WriteRune(r)
+        } else {
+            sb.WriteRune('_')
+        }
+    }
+    return sb.String()
+}
+
+// memberLines formata campos e métodos, limitando os métodos a maxMethods (0 = todos)
+func memberLines(n Node, maxMethods int) []string {
+    visibility := func(m Member) string {
+        if m.Exported {
+            return "+"
+        }
+        return "-"
+    }
+    var lines []string
+    for _, f := range n.Fields {
+        lines = append(lines, fmt.Sprintf("%s%s %s", visibility(f), f.Name, f.Type))
+    }
+    methods := n.Methods
+    if maxMethods > 0 && len(methods) > maxMethods {
+        methods = methods[:maxMethods]
+    }
+    for _, m := range methods {
+        lines = append(lines, fmt.Sprintf("%s%s%s", visibility(m), m.Name, m.Type))
+    }
+    if hidden := len(n.Methods) - len(methods); hidden > 0 {
+        lines = append(lines, fmt.Sprintf("+%d métodos", hidden))
+    }
+    return lines
+}
+
+// mermaidMember remove caracteres que o Mermaid interpreta dentro de uma classe
+func mermaidMember(s string) string {
+    return strings.NewReplacer("{", "", "}", "", "~", "", "\"", "'").Replace(s)
+}
+
+// Mermaid renderiza o grafo como um classDiagram Mermaid, com um namespace por pacote
+func (g *Graph) Mermaid(maxMethods int) string {
+    var sb strings.Builder
+    sb.WriteString("classDiagram\n")
+
+    namespaces := g.namespaces()
+    pkgs, groups := g.groupByPackage()
+    for _, pkg := range pkgs {
+        sb.WriteString(fmt.Sprintf("    namespace %s {\n", classID(namespaces[pkg])))
+        for _, n := range groups[pkg] {
+            sb.WriteString(fmt.Sprintf("        class %s[\"%s\"] {\n", classID(n.ID), n.Name))
+            if n.Kind == "interface" {
+                sb.WriteString("            <<interface>>\n")
+            }
+            for _, line := range memberLines(n, maxMethods) {
+                sb.WriteString("            " + mermaidMember(line) + "\n")
+            }
+            sb.WriteString("        }\n")
+        }
+        sb.WriteString("    }\n")
+    }
+
+    for _, r := range g.Relations {
+        from, to := classID(r.From), classID(r.To)
+        switch r.Kind {
+        case RelationImplements:
+            sb.WriteString(fmt.Sprintf("    %s ..|> %s\n", from, to))
+        case RelationEmbeds:
+            sb.WriteString(fmt.Sprintf("    %s *-- %s : embeds\n", from, to))
+        default:
+            sb.WriteString(fmt.Sprintf("    %s --> %s : %s\n", from, to, r.Label))
+        }
+    }
+    return sb.String()
+}
+
+// PlantUML renderiza o grafo como um diagrama de classes PlantUML, com um package por pacote
+func (g *Graph) PlantUML(maxMethods int) string {
+    var sb strings.Builder
+    sb.WriteString("@startuml\n\n")
+    sb.WriteString("set namespaceSeparator none\n")
+    sb.WriteString("hide empty members\n\n")
+
+    namespaces := g.namespaces()
+    pkgs, groups := g.groupByPackage()
+    for _, pkg := range pkgs {
+        sb.WriteString(fmt.Sprintf("package %q {\n", namespaces[pkg]))
+        for _, n := range groups[pkg] {
+            keyword := "class"
+            if n.Kind == "interface" {
+                keyword = "interface"
+            }
+            sb.WriteString(fmt.Sprintf("    %s %q as %s {\n", keyword, n.Name, classID(n.ID)))
+            for _, line := range memberLines(n, maxMethods) {
+                sb.WriteString("        " + line + "\n")
+            }
+            sb.WriteString("    }\n")
+        }
+        sb.WriteString("}\n\n")
+    }
+
+    for _, r := range g.Relations {
+        from, to := classID(r.From), classID(r.To)
+        switch r.Kind {
+        case RelationImplements:
+            sb.WriteString(fmt.Sprintf("%s ..|> %s\n", from, to))
+        case RelationEmbeds:
+            sb.WriteString(fmt.Sprintf("%s *-- %s : embeds\n", from, to))
+        default:
+            sb.WriteString(fmt.Sprintf("%s --> %s : %s\n", from, to, r.Label))
+        }
+    }
+    sb.WriteString("\n@enduml\n")
+    return sb.String()
+}
@@ -0,0 +1,88 @@
+package classdiagram_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc/classdiagram"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestClassDiagrams(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "store/store.go": `package store
+
+type Reader interface {
+    Get(id string) string
+}
+
+type Store interface {
+    Reader
+    Put(id, v string)
+}
+
+type Base struct{ name string }
+
+type Memory struct {
+    Base
+    items map[string]string
+}
+
+func (m *Memory) Get(id string) string { return m.items[id] }
+func (m *Memory) Put(id, v string)     { m.items[id] = v }
+func (m *Memory) Len() int             { return len(m.items) }
+`,
+        "service/service.go": `package service
+
+import "example.com/app/store"
+
+type Service struct {
+    Store store.Store
+}
+`,
+    })
+
+    graph := classdiagram.Build(pass, config.ClassDiagramConfig{Enabled: true})
+
+    var relations []string
+    for _, r := range graph.Relations {
+        relations = append(relations, fmt.Sprintf("%s %s %s %s", r.From, r.Kind, r.To, r.Label))
+    }
+    want := []string{
+        "example.com/app/service.Service field example.com/app/store.Store Store",
+        "example.com/app/store.Memory embeds example.com/app/store.Base ",
+        "example.com/app/store.Memory implements example.com/app/store.Reader ",
+        "example.com/app/store.Memory implements example.com/app/store.Store ",
+        "example.com/app/store.Store embeds example.com/app/store.Reader ",
+    }
+    if strings.Join(relations, "\n") != strings.Join(want, "\n") {
+        t.Errorf("relações =\n%s\nwant\n%s", strings.Join(relations, "\n"), strings.Join(want, "\n"))
+    }
+
+    if got := strings.Join(graph.Packages(), " "); got != "example.com/app/service example.com/app/store" {
+        t.Errorf("pacotes = %s", got)
+    }
+    focus := graph.Focus([]string{"service.Service"})
+    if len(focus) != 1 {
+        t.Fatalf("foco = %+v; want service.Service", focus)
+    }
+    // Profundidade 1: Service e Store, sem as implementações de Store
+    mermaid := graph.Neighborhood([]string{focus[0].ID}, 1).Mermaid(2)
+    for _, s := range []string{"namespace service", "namespace store", `["Store"]`, "<<interface>>", "+Store store.Store"} {
+        if !strings.Contains(mermaid, s) {
+            t.Errorf("Mermaid do foco sem %q:\n%s", s, mermaid)
+        }
+    }
+    if strings.Contains(mermaid, "Memory") {
+        t.Errorf("Mermaid do foco inclui tipo além da profundidade:\n%s", mermaid)
+    }
+    plantUML := graph.PackageGraph("example.com/app/store", 1).PlantUML(2)
+    for _, s := range []string{`package "store"`, `interface "Reader" as example_com_app_store_Reader`,
+        "example_com_app_store_Memory ..|> example_com_app_store_Store", "+1 métodos"} {
+        if !strings.Contains(plantUML, s) {
+            t.Errorf("PlantUML do pacote sem %q:\n%s", s, plantUML)
+        }
+    }
+}
@@ -0,0 +1,891 @@
+// Package cli extrai a estrutura de linha de comando dos binários: subcomandos
+// com flag.NewFlagSet, árvores do cobra e aplicações do urfave/cli
+package cli
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/configref"
+)
+
+// App representa a árvore de comandos de um binário (pacote main)
+type App struct {
+    Name      string     `json:"name"      yaml:"name"` // nome do binário
+    Package   string     `json:"package"   yaml:"package"`
+    Framework string     `json:"framework" yaml:"framework"` // flag, cobra, urfave
+    Root      Command `json:"root"      yaml:"root"`
+}
+
+// Command representa um comando ou subcomando
+type Command struct {
+    Name     string       `json:"name"               yaml:"name"`
+    Path     string       `json:"path"               yaml:"path"` // caminho completo (ex.: aimap docker)
+    Usage    string       `json:"usage,omitempty"    yaml:"usage,omitempty"` // linha de uso (Use do cobra, UsageText do urfave)
+    Short    string       `json:"short,omitempty"    yaml:"short,omitempty"`
+    Long     string       `json:"long,omitempty"     yaml:"long,omitempty"`
+    Aliases  []string     `json:"aliases,omitempty"  yaml:"aliases,omitempty"`
+    Handler  string       `json:"handler,omitempty"  yaml:"handler,omitempty"`
+    Flags    []Flag    `json:"flags,omitempty"    yaml:"flags,omitempty"`
+    Commands []Command `json:"commands,omitempty" yaml:"commands,omitempty"`
+    File     string       `json:"file"               yaml:"file"`
+    Line     int          `json:"line"               yaml:"line"`
+}
+
+// Flag representa uma flag de um comando
+type Flag struct {
+    Name       string   `json:"name"                 yaml:"name"`
+    Aliases    []string `json:"aliases,omitempty"    yaml:"aliases,omitempty"` // atalhos (-n) e nomes alternativos
+    Type       string   `json:"type,omitempty"       yaml:"type,omitempty"`
+    Default    string   `json:"default,omitempty"    yaml:"default,omitempty"`
+    Usage      string   `json:"usage,omitempty"      yaml:"usage,omitempty"`
+    Env        []string `json:"env,omitempty"        yaml:"env,omitempty"`
+    Persistent bool     `json:"persistent,omitempty" yaml:"persistent,omitempty"` // herdada pelos subcomandos (cobra)
+    Required   bool     `json:"required,omitempty"   yaml:"required,omitempty"`
+}
+
+// pflagTypes traduz o nome base dos métodos do pflag (e dos tipos de flag do urfave/cli)
+var pflagTypes = map[string]string{
+    "String": "string", "Bool": "bool", "Int": "int", "Int8": "int8", "Int16": "int16",
+    "Int32": "int32", "Int64": "int64", "Uint": "uint", "Uint8": "uint8", "Uint16": "uint16",
+    "Uint32": "uint32", "Uint64": "uint64", "Float32": "float32", "Float64": "float64",
+    "Duration": "duration", "Count": "count", "Path": "string", "Timestamp": "timestamp",
+    "StringSlice": "[]string", "StringArray": "[]string", "IntSlice": "[]int", "Int64Slice": "[]int64",
+    "BoolSlice": "[]bool", "Float64Slice": "[]float64", "DurationSlice": "[]duration",
+    "StringToString": "map[string]string", "StringToInt": "map[string]int",
+    "IP": "ip", "IPMask": "ipMask", "IPNet": "ipNet", "BytesHex": "bytesHex", "BytesBase64": "bytesBase64",
+    "Generic": "", "": "",
+}
+
+// cliScanner extrai a estrutura de linha de comando de um pacote main
+type cliScanner struct {
+    fset   *token.FileSet
+    pkg    *analysis.Package
+    binary string
+    flags   *configref.Flags
+    docs    map[*types.Func]*ast.FuncDecl
+    imports analysis.Imports
+}
+
+// Build detecta os comandos dos pacotes main: subcomandos com flag.NewFlagSet
+// despachados por switch os.Args[1], árvores do cobra e aplicações do urfave/cli
+func Build(pass *analysis.Pass) []App {
+    var apps []App
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil || pkg.Name != "main" {
+            continue
+        }
+        s := &cliScanner{
+            fset:   pass.Fset,
+            pkg:    pkg,
+            binary: filepath.Base(pkg.Dir),
+            flags:  configref.NewFlags(pkg),
+            docs:   make(map[*types.Func]*ast.FuncDecl),
+        }
+        pkg.FuncDecls(func(decl *ast.FuncDecl, obj *types.Func) {
+            s.docs[obj] = decl
+        })
+        s.imports = analysis.PackageImports(pkg)
+
+        found := append(s.cobraApps(), s.urfaveApps()...)
+        if len(found) == 0 {
+            if app, ok := s.flagApp(); ok {
+                found = append(found, app)
+            }
+        }
+        apps = append(apps, found...)
+    }
+    return apps
+}
+
+// flagApp monta a árvore de um binário baseado no pacote flag
+func (s *cliScanner) flagApp() (App, bool) {
+    info := s.pkg.Info
+
+    root := Command{Name: s.binary, Path: s.binary, Short: s.packageSummary()}
+    var commands []*Command
+    byName := make(map[string]*Command)
+    command := func(name string, pos token.Pos) *Command {
+        if cmd, ok := byName[name]; ok {
+            return cmd
+        }
+        position := s.fset.Position(pos)
+        cmd := &Command{Name: name, Path: s.binary + " " + name, File: position.Filename, Line: position.Line}
+        byName[name] = cmd
+        commands = append(commands, cmd)
+        return cmd
+    }
+    setCommand := make(map[string]*Command)
+
+    // Despacho por switch os.Args[1] (ou flag.Arg(0))
+    for _, file := range s.pkg.Files {
+        ast.Inspect(file, func(n ast.Node) bool {
+            sw, ok := n.(*ast.SwitchStmt)
+            if !ok || !isArgDispatch(info, sw.Tag) {
+                return true
+            }
+            for _, stmt := range sw.Body.List {
+                clause := stmt.(*ast.CaseClause)
+                for _, value := range clause.List {
+                    name, ok := analysis.ConstString(info, value)
+                    if !ok {
+                        continue
+                    }
+                    cmd := command(name, clause.Pos())
+                    s.inspectCase(clause, cmd, setCommand)
+                }
+            }
+            return true
+        })
+    }
+
+    // Flags do flag.CommandLine e dos FlagSets
+    for _, file := range s.pkg.Files {
+        ast.Inspect(file, func(n ast.Node) bool {
+            call, ok := n.(*ast.CallExpr)
+            if !ok {
+                return true
+            }
+            setting, ok := s.flags.Setting(call)
+            if !ok {
+                return true
+            }
+            flag := Flag{Name: setting.Name, Type: setting.Type, Default: setting.Default, Usage: setting.Description}
+            switch {
+            case setting.Set == "" || strings.ContainsAny(setting.Set, "[(."):
+                // flag.CommandLine ou FlagSet com nome dinâmico (ex.: os.Args[0])
+                root.Flags = append(root.Flags, flag)
+            case setCommand[setting.Set] != nil:
+                setCommand[setting.Set].Flags = append(setCommand[setting.Set].Flags, flag)
+            default:
+                cmd := command(setting.Set, call.Pos())
+                cmd.Flags = append(cmd.Flags, flag)
+            }
+            return true
+        })
+    }
+    if len(commands) == 0 && len(root.Flags) == 0 {
+        return App{}, false
+    }
+
+    // Descrição: linha do texto de ajuda ou, na falta dela, o comentário do handler
+    usage := s.usageTexts()
+    handlers := make(map[string]*ast.FuncDecl)
+    for fn, decl := range s.docs {
+        handlers[analysis.LocalFuncName(fn, s.pkg.Types)] = decl
+    }
+    for _, cmd := range commands {
+        cmd.Short = usageDescription(usage, cmd.Name)
+        if decl := handlers[cmd.Handler]; cmd.Short == "" && decl != nil {
+            cmd.Short = strings.TrimPrefix(firstSentence(decl.Doc.Text()), decl.Name.Name+" ")
+        }
+        root.Commands = append(root.Commands, *cmd)
+    }
+    return App{Name: s.binary, Package: s.pkg.ImportPath, Framework: "flag", Root: root}, true
+}
+
+// inspectCase associa o handler e o FlagSet analisado (x.Parse) a um caso do switch
+func (s *cliScanner) inspectCase(clause *ast.CaseClause, cmd *Command, setCommand map[string]*Command) {
+    info := s.pkg.Info
+    for _, stmt := range clause.Body {
+        ast.Inspect(stmt, func(n ast.Node) bool {
+            call, ok := n.(*ast.CallExpr)
+            if !ok {
+                return true
+            }
+            if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && sel.Sel.Name == "Parse" {
+                if set, ok := s.flags.SetName(analysis.IdentObject(info, sel.X)); ok {
+                    setCommand[set] = cmd
+                }
+                return true
+            }
+            fn, _ := analysis.ResolveCallee(info, call)
+            if fn == nil || fn.Pkg() != s.pkg.Types || cmd.Handler != "" {
+                return true
+            }
+            cmd.Handler = analysis.LocalFuncName(fn, s.pkg.Types)
+            return true
+        })
+    }
+}
+
+// isArgDispatch reconhece os.Args[1] e flag.Arg(0) como expressão de um switch
+func isArgDispatch(info *types.Info, tag ast.Expr) bool {
+    switch x := ast.Unparen(tag).(type) {
+    case *ast.IndexExpr:
+        sel, ok := ast.Unparen(x.X).(*ast.SelectorExpr)
+        if !ok || sel.Sel.Name != "Args" {
+            return false
+        }
+        pkgName, ok := analysis.IdentObject(info, sel.X).(*types.PkgName)
+        tv, known := info.Types[x.Index]
+        return ok && pkgName.Imported().Path() == "os" && known && tv.Value != nil && tv.Value.ExactString() == "1"
+    case *ast.CallExpr:
+        if !analysis.IsPackageCall(info, x, "flag", "Arg") || len(x.Args) != 1 {
+            return false
+        }
+        tv, known := info.Types[x.Args[0]]
+        return known && tv.Value != nil && tv.Value.ExactString() == "0"
+    }
+    return false
+}
+
+// usageTexts reúne as strings constantes do pacote com mais de uma linha (textos de ajuda)
+func (s *cliScanner) usageTexts() []string {
+    var texts []string
+    for _, file := range s.pkg.Files {
+        ast.Inspect(file, func(n ast.Node) bool {
+            lit, ok := n.(*ast.BasicLit)
+            if !ok || lit.Kind != token.STRING {
+                return true
+            }
+            if text, ok := analysis.ConstString(s.pkg.Info, lit); ok && strings.Contains(text, "\n") {
+                texts = append(texts, text)
+            }
+            return true
+        })
+    }
+    return texts
+}
+
+// usageDescription procura a descrição de um comando em linhas indentadas
+// do texto de ajuda, como "  generate  Gera a documentação"
+func usageDescription(texts []string, name string) string {
+    for _, text := range texts {
+        for _, line := range strings.Split(text, "\n") {
+            if line == "" || (line[0] != ' ' && line[0] != '\t') {
+                continue
+            }
+            fields := strings.Fields(line)
+            if len(fields) > 1 && fields[0] == name {
+                return strings.Join(fields[1:], " ")
+            }
+        }
+    }
+    return ""
+}
+
+// packageSummary retorna a primeira frase do comentário do pacote
+// (ignora comentários que só repetem o caminho do arquivo)
+func (s *cliScanner) packageSummary() string {
+    for _, file := range s.pkg.Files {
+        if file.Doc == nil {
+            continue
+        }
+        if summary := firstSentence(file.Doc.Text()); summary != "" && !strings.HasSuffix(summary, ".go") {
+            return summary
+        }
+    }
+    return ""
+}
+
+// firstSentence retorna a primeira linha não vazia de um comentário
+func firstSentence(text string) string {
+    for _, line := range strings.Split(text, "\n") {
+        if line = strings.TrimSpace(line); line != "" {
+            return line
+        }
+    }
+    return ""
+}
+
+// literalBindings associa variáveis e funções construtoras aos literais compostos
+// aceitos por match (ex.: cmd := &cobra.Command{...}; return cmd)
+func (s *cliScanner) literalBindings(match func(*ast.CompositeLit) bool) ([]*ast.CompositeLit, map[types.Object]*ast.CompositeLit, map[*types.Func]*ast.CompositeLit) {
+    info := s.pkg.Info
+    var lits []*ast.CompositeLit
+    vars := make(map[types.Object]*ast.CompositeLit)
+    funcs := make(map[*types.Func]*ast.CompositeLit)
+    literal := func(expr ast.Expr) *ast.CompositeLit {
+        expr = ast.Unparen(expr)
+        if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+            expr = u.X
+        }
+        if lit, ok := expr.(*ast.CompositeLit); ok && match(lit) {
+            return lit
+        }
+        return nil
+    }
+    bind := func(lhs, rhs []ast.Expr) {
+        if len(lhs) != len(rhs) {
+            return
+        }
+        for i := range rhs {
+            if lit := literal(rhs[i]); lit != nil {
+                if obj := analysis.IdentObject(info, lhs[i]); obj != nil {
+                    vars[obj] = lit
+                }
+            }
+        }
+    }
+
+    for _, file := range s.pkg.Files {
+        ast.Inspect(file, func(n ast.Node) bool {
+            switch node := n.(type) {
+            case *ast.CompositeLit:
+                if match(node) {
+                    lits = append(lits, node)
+                }
+            case *ast.AssignStmt:
+                bind(node.Lhs, node.Rhs)
+            case *ast.ValueSpec:
+                bind(analysis.IdentsToExprs(node.Names), node.Values)
+            }
+            return true
+        })
+    }
+
+    // Funções que retornam um literal ou uma variável associada a um literal
+    for fn, decl := range s.docs {
+        if decl.Body == nil {
+            continue
+        }
+        ast.Inspect(decl.Body, func(n ast.Node) bool {
+            if _, ok := n.(*ast.FuncLit); ok {
+                return false
+            }
+            ret, ok := n.(*ast.ReturnStmt)
+            if !ok || len(ret.Results) == 0 {
+                return true
+            }
+            if lit := literal(ret.Results[0]); lit != nil {
+                funcs[fn] = lit
+            } else if lit, ok := vars[analysis.IdentObject(info, ret.Results[0])]; ok {
+                funcs[fn] = lit
+            }
+            return true
+        })
+    }
+    return lits, vars, funcs
+}
+
+// resolveLiteral encontra o literal associado a uma expressão (literal, variável ou construtora)
+func (s *cliScanner) resolveLiteral(expr ast.Expr, vars map[types.Object]*ast.CompositeLit, funcs map[*types.Func]*ast.CompositeLit) *ast.CompositeLit {
+    expr = ast.Unparen(expr)
+    if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+        expr = ast.Unparen(u.X)
+    }
+    switch x := expr.(type) {
+    case *ast.CompositeLit:
+        return x
+    case *ast.Ident:
+        return vars[analysis.IdentObject(s.pkg.Info, x)]
+    case *ast.CallExpr:
+        if fn, _ := analysis.ResolveCallee(s.pkg.Info, x); fn != nil {
+            return funcs[fn]
+        }
+    }
+    return nil
+}
+
+// stringField lê um campo string constante de um literal
+func (s *cliScanner) stringField(fields map[string]ast.Expr, name string) string {
+    if expr, ok := fields[name]; ok {
+        value, _ := analysis.ConstString(s.pkg.Info, expr)
+        return value
+    }
+    return ""
+}
+
+// stringsField lê um campo []string com elementos constantes (ou chamada como cli.EnvVars("A"))
+func (s *cliScanner) stringsField(fields map[string]ast.Expr, name string) []string {
+    var elts []ast.Expr
+    switch x := ast.Unparen(fields[name]).(type) {
+    case *ast.CompositeLit:
+        elts = x.Elts
+    case *ast.CallExpr:
+        elts = x.Args
+    default:
+        return nil
+    }
+    var values []string
+    for _, elt := range elts {
+        if value, ok := analysis.ConstString(s.pkg.Info, elt); ok {
+            values = append(values, value)
+        }
+    }
+    return values
+}
+
+// handlerName descreve a função associada a um comando; vazio para funções anônimas
+func handlerName(expr ast.Expr) string {
+    switch ast.Unparen(expr).(type) {
+    case *ast.Ident, *ast.SelectorExpr:
+        return types.ExprString(expr)
+    }
+    return ""
+}
+
+// cobraNode acumula um comando do cobra e as relações encontradas no código
+type cobraNode struct {
+    lit      *ast.CompositeLit
+    command  Command
+    children []*cobraNode
+    parent   *cobraNode
+    required map[string]bool
+}
+
+// cobraApps monta as árvores de comandos do cobra (cada comando sem pai é uma raiz)
+func (s *cliScanner) cobraApps() []App {
+    info := s.pkg.Info
+    isCobra := func(path string) bool { return path == "github.com/spf13/cobra" }
+    lits, vars, funcs := s.literalBindings(func(lit *ast.CompositeLit) bool {
+        return lit.Type != nil && s.imports.ImportedFrom(s.pkg.Info, lit.Type, isCobra, "Command")
+    })
+    if len(lits) == 0 {
+        return nil
+    }
+
+    nodes := make(map[*ast.CompositeLit]*cobraNode)
+    var order []*cobraNode
+    for _, lit := range lits {
+        fields := analysis.LiteralFields(lit)
+        pos := s.fset.Position(lit.Pos())
+        use := s.stringField(fields, "Use")
+        name := use
+        if f := strings.Fields(use); len(f) > 0 {
+            name = f[0]
+        }
+        node := &cobraNode{lit: lit, required: make(map[string]bool), command: Command{
+            Name:    name,
+            Usage:   use,
+            Short:   s.stringField(fields, "Short"),
+            Long:    s.stringField(fields, "Long"),
+            Aliases: s.stringsField(fields, "Aliases"),
+            File:    pos.Filename,
+            Line:    pos.Line,
+        }}
+        for _, key := range []string{"RunE", "Run"} {
+            if expr, ok := fields[key]; ok && node.command.Handler == "" {
+                node.command.Handler = handlerName(expr)
+            }
+        }
+        nodes[lit] = node
+        order = append(order, node)
+    }
+    nodeOf := func(expr ast.Expr) *cobraNode {
+        if lit := s.resolveLiteral(expr, vars, funcs); lit != nil {
+            return nodes[lit]
+        }
+        return nil
+    }
+
+    // Variáveis com o FlagSet de um comando (flags := cmd.Flags())
+    type flagSetRef struct {
+        node       *cobraNode
+        persistent bool
+    }
+    flagSetOf := func(expr ast.Expr) (flagSetRef, bool) {
+        call, ok := ast.Unparen(expr).(*ast.CallExpr)
+        if !ok {
+            return flagSetRef{}, false
+        }
+        sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+        if !ok {
+            return flagSetRef{}, false
+        }
+        switch sel.Sel.Name {
+        case "Flags", "LocalFlags", "PersistentFlags":
+            if node := nodeOf(sel.X); node != nil {
+                return flagSetRef{node, sel.Sel.Name == "PersistentFlags"}, true
+            }
+        }
+        return flagSetRef{}, false
+    }
+    flagVars := make(map[types.Object]flagSetRef)
+    for _, file := range s.pkg.Files {
+        ast.Inspect(file, func(n ast.Node) bool {
+            if assign, ok := n.(*ast.AssignStmt); ok && len(assign.Lhs) == len(assign.Rhs) {
+                for i, rhs := range assign.Rhs {
+                    if ref, ok := flagSetOf(rhs); ok {
+                        if obj := analysis.IdentObject(info, assign.Lhs[i]); obj != nil {
+                            flagVars[obj] = ref
+                        }
+                    }
+                }
+            }
+            return true
+        })
+    }
+
+    for _, file := range s.pkg.Files {
+        ast.Inspect(file, func(n ast.Node) bool {
+            call, ok := n.(*ast.CallExpr)
+            if !ok {
+                return true
+            }
+            sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+            if !ok {
+                return true
+            }
+            switch sel.Sel.Name {
+            case "AddCommand":
+                parent := nodeOf(sel.X)
+                if parent == nil {
+                    return true
+                }
+                for _, arg := range call.Args {
+                    if child := nodeOf(arg); child != nil && child.parent == nil && child != parent {
+                        child.parent = parent
+                        parent.children = append(parent.children, child)
+                    }
+                }
+                return true
+            case "MarkFlagRequired", "MarkPersistentFlagRequired":
+                if node := nodeOf(sel.X); node != nil && len(call.Args) > 0 {
+                    if name, ok := analysis.ConstString(info, call.Args[0]); ok {
+                        node.required[name] = true
+                    }
+                }
+                return true
+            }
+            ref, ok := flagSetOf(sel.X)
+            if !ok {
+                ref, ok = flagVars[analysis.IdentObject(info, sel.X)]
+            }
+            if !ok {
+                return true
+            }
+            if flag, ok := s.pflagDefinition(sel.Sel.Name, call); ok {
+                flag.Persistent = ref.persistent
+                ref.node.command.Flags = append(ref.node.command.Flags, flag)
+            }
+            return true
+        })
+    }
+
+    var apps []App
+    for _, node := range order {
+        if node.parent != nil {
+            continue
+        }
+        name := node.command.Name
+        if name == "" {
+            name = s.binary
+        }
+        apps = append(apps, App{
+            Name:      name,
+            Package:   s.pkg.ImportPath,
+            Framework: "cobra",
+            Root:      node.tree(name),
+        })
+    }
+    return apps
+}
+
+// tree converte o nó e seus filhos em comandos, preenchendo os caminhos completos
+func (n *cobraNode) tree(path string) Command {
+    cmd := n.command
+    cmd.Path = path
+    if cmd.Name == "" {
+        cmd.Name = path
+    }
+    for i := range cmd.Flags {
+        cmd.Flags[i].Required = cmd.Flags[i].Required || n.required[cmd.Flags[i].Name]
+    }
+    cmd.Commands = nil
+    for _, child := range n.children {
+        cmd.Commands = append(cmd.Commands, child.tree(path+" "+child.command.Name))
+    }
+    return cmd
+}
+
+// pflagDefinition interpreta métodos de definição do pflag: String(name, value, usage),
+// StringP(name, shorthand, value, usage), StringVar(p, name, value, usage), StringVarP(...)
+func (s *cliScanner) pflagDefinition(method string, call *ast.CallExpr) (Flag, bool) {
+    base := method
+    shorthand := strings.HasSuffix(base, "P") && base != "IP"
+    if shorthand {
+        base = strings.TrimSuffix(base, "P")
+    }
+    isVar := strings.HasSuffix(base, "Var")
+    base = strings.TrimSuffix(base, "Var")
+    typ, ok := pflagTypes[base]
+    if !ok || base == "Generic" || base == "Path" || base == "Timestamp" || (base == "" && !isVar) {
+        return Flag{}, false
+    }
+
+    args := call.Args
+    var target ast.Expr
+    if isVar {
+        if len(args) == 0 {
+            return Flag{}, false
+        }
+        target, args = args[0], args[1:]
+    }
+    hasValue := base != "Count" && base != ""
+    want := 2
+    if shorthand {
+        want++
+    }
+    if hasValue {
+        want++
+    }
+    if len(args) != want {
+        return Flag{}, false
+    }
+
+    name, ok := analysis.ConstString(s.pkg.Info, args[0])
+    if !ok {
+        return Flag{}, false
+    }
+    flag := Flag{Name: name, Type: typ}
+    args = args[1:]
+    if shorthand {
+        if short, ok := analysis.ConstString(s.pkg.Info, args[0]); ok && short != "" {
+            flag.Aliases = []string{short}
+        }
+        args = args[1:]
+    }
+    if hasValue {
+        flag.Default = s.flags.Value(args[0])
+        args = args[1:]
+    }
+    flag.Usage, _ = analysis.ConstString(s.pkg.Info, args[0])
+    if base == "" && target != nil {
+        // Var(value, name, usage): o tipo é o do flag.Value passado
+        if t := analysis.TypeOrNil(s.pkg.Info, target); analysis.IsValidType(t) {
+            flag.Type = types.TypeString(t, types.RelativeTo(s.pkg.Types))
+        }
+    }
+    return flag, true
+}
+
+// urfaveApps monta as aplicações do urfave/cli (cli.App nas versões 1 e 2, cli.Command raiz na 3)
+func (s *cliScanner) urfaveApps() []App {
+    isUrfave := func(path string) bool {
+        return path == "github.com/urfave/cli" || strings.HasPrefix(path, "github.com/urfave/cli/v")
+    }
+    lits, vars, funcs := s.literalBindings(func(lit *ast.CompositeLit) bool {
+        return lit.Type != nil && (s.imports.ImportedFrom(s.pkg.Info, lit.Type, isUrfave, "App") || s.imports.ImportedFrom(s.pkg.Info, lit.Type, isUrfave, "Command"))
+    })
+    if len(lits) == 0 {
+        return nil
+    }
+
+    // Comandos referenciados em Commands/Subcommands de outro literal não são raízes
+    nested := make(map[*ast.CompositeLit]bool)
+    for _, lit := range lits {
+        for _, child := range s.urfaveChildren(lit, vars, funcs) {
+            nested[child] = true
+        }
+    }
+
+    var apps []App
+    for _, lit := range lits {
+        if nested[lit] {
+            continue
+        }
+        root := s.urfaveCommand(lit, vars, funcs, make(map[*ast.CompositeLit]bool))
+        if root.Name == "" {
+            root.Name = s.binary
+        }
+        root = withPaths(root, root.Name)
+        apps = append(apps, App{Name: root.Name, Package: s.pkg.ImportPath, Framework: "urfave", Root: root})
+    }
+    return apps
+}
+
+// urfaveChildren retorna os literais de Commands/Subcommands de um comando do urfave/cli
+func (s *cliScanner) urfaveChildren(lit *ast.CompositeLit, vars map[types.Object]*ast.CompositeLit, funcs map[*types.Func]*ast.CompositeLit) []*ast.CompositeLit {
+    fields := analysis.LiteralFields(lit)
+    var children []*ast.CompositeLit
+    for _, key := range []string{"Commands", "Subcommands"} {
+        list, ok := ast.Unparen(fields[key]).(*ast.CompositeLit)
+        if !ok {
+            continue
+        }
+        for _, elt := range list.Elts {
+            if child := s.resolveLiteral(elt, vars, funcs); child != nil {
+                children = append(children, child)
+            }
+        }
+    }
+    return children
+}
+
+// urfaveCommand converte um literal cli.App/cli.Command e seus subcomandos
+func (s *cliScanner) urfaveCommand(lit *ast.CompositeLit, vars map[types.Object]*ast.CompositeLit, funcs map[*types.Func]*ast.CompositeLit, visiting map[*ast.CompositeLit]bool) Command {
+    fields := analysis.LiteralFields(lit)
+    pos := s.fset.Position(lit.Pos())
+    cmd := Command{
+        Name:    s.stringField(fields, "Name"),
+        Usage:   s.stringField(fields, "UsageText"),
+        Short:   s.stringField(fields, "Usage"),
+        Long:    s.stringField(fields, "Description"),
+        Aliases: s.stringsField(fields, "Aliases"),
+        File:    pos.Filename,
+        Line:    pos.Line,
+    }
+    if expr, ok := fields["Action"]; ok {
+        cmd.Handler = handlerName(expr)
+    }
+    if list, ok := ast.Unparen(fields["Flags"]).(*ast.CompositeLit); ok {
+        for _, elt := range list.Elts {
+            if flag, ok := s.urfaveFlag(elt); ok {
+                cmd.Flags = append(cmd.Flags, flag)
+            }
+        }
+    }
+
+    visiting[lit] = true
+    for _, child := range s.urfaveChildren(lit, vars, funcs) {
+        if !visiting[child] {
+            cmd.Commands = append(cmd.Commands, s.urfaveCommand(child, vars, funcs, visiting))
+        }
+    }
+    delete(visiting, lit)
+    return cmd
+}
+
+// urfaveFlag interpreta um literal &cli.StringFlag{Name: ..., Value: ..., Usage: ...}
+func (s *cliScanner) urfaveFlag(expr ast.Expr) (Flag, bool) {
+    expr = ast.Unparen(expr)
+    if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+        expr = ast.Unparen(u.X)
+    }
+    lit, ok := expr.(*ast.CompositeLit)
+    if !ok {
+        return Flag{}, false
+    }
+    typeExpr := lit.Type
+    if star, ok := typeExpr.(*ast.StarExpr); ok {
+        typeExpr = star.X
+    }
+    sel, ok := typeExpr.(*ast.SelectorExpr)
+    if !ok || !strings.HasSuffix(sel.Sel.Name, "Flag") {
+        return Flag{}, false
+    }
+    fields := analysis.LiteralFields(lit)
+    flag := Flag{
+        Name:     s.stringField(fields, "Name"),
+        Aliases:  s.stringsField(fields, "Aliases"),
+        Type:     pflagTypes[strings.TrimSuffix(sel.Sel.Name, "Flag")],
+        Usage:    s.stringField(fields, "Usage"),
+        Env:      s.stringsField(fields, "EnvVars"),
+        Required: types.ExprString(fields["Required"]) == "true",
+    }
+    if flag.Type == "" {
+        flag.Type = strings.TrimSuffix(sel.Sel.Name, "Flag")
+    }
+    if value, ok := fields["Value"]; ok {
+        flag.Default = s.flags.Value(value)
+    }
+    if sources := s.stringsField(fields, "Sources"); len(sources) > 0 {
+        flag.Env = sources // v3: Sources: cli.EnvVars("PORT")
+    }
+    if env := s.stringField(fields, "EnvVar"); env != "" {
+        // v1: EnvVar: "PORT, APP_PORT"
+        for _, name := range strings.Split(env, ",") {
+            flag.Env = append(flag.Env, strings.TrimSpace(name))
+        }
+    }
+    // v1: Name: "port, p"
+    if names := strings.Split(flag.Name, ","); len(names) > 1 {
+        flag.Name = strings.TrimSpace(names[0])
+        for _, alias := range names[1:] {
+            flag.Aliases = append(flag.Aliases, strings.TrimSpace(alias))
+        }
+    }
+    return flag, flag.Name != ""
+}
+
+// withPaths preenche o caminho completo do comando e de seus subcomandos
+func withPaths(cmd Command, path string) Command {
+    cmd.Path = path
+    for i, child := range cmd.Commands {
+        cmd.Commands[i] = withPaths(child, path+" "+child.Name)
+    }
+    return cmd
+}
+
+// AllCommands retorna o comando raiz e todos os subcomandos em pré-ordem
+func (app App) AllCommands() []Command {
+    var result []Command
+    var walk func(cmd Command)
+    walk = func(cmd Command) {
+        result = append(result, cmd)
+        for _, child := range cmd.Commands {
+            walk(child)
+        }
+    }
+    walk(app.Root)
+    return result
+}
+
+// flagSpelling formata o nome de uma flag como na linha de comando
+func (app App) flagSpelling(name string) string {
+    if app.Framework == "flag" || len(name) == 1 {
+        return "-" + name
+    }
+    return "--" + name
+}
+
+// Markdown gera a página de referência da CLI
+func (app App) Markdown() string {
+    var sb strings.Builder
+    sb.WriteString(fmt.Sprintf("# Referência da CLI: %s\n\n", app.Name))
+    sb.WriteString(fmt.Sprintf("Pacote `%s` · framework `%s`\n", app.Package, app.Framework))
+
+    for _, cmd := range app.AllCommands() {
+        sb.WriteString(fmt.Sprintf("\n## %s\n\n", cmd.Path))
+        if cmd.Short != "" {
+            sb.WriteString(cmd.Short + "\n\n")
+        }
+        if cmd.Long != "" && cmd.Long != cmd.Short {
+            sb.WriteString(cmd.Long + "\n\n")
+        }
+        if cmd.Usage != "" {
+            sb.WriteString(fmt.Sprintf("Uso: `%s`\n\n", cmd.Usage))
+        }
+        if len(cmd.Aliases) > 0 {
+            sb.WriteString(fmt.Sprintf("Aliases: `%s`\n\n", strings.Join(cmd.Aliases, "`, `")))
+        }
+        if cmd.Handler != "" {
+            sb.WriteString(fmt.Sprintf("Handler: `%s` (%s:%d)\n\n", cmd.Handler, cmd.File, cmd.Line))
+        }
+
+        if len(cmd.Flags) > 0 {
+            sb.WriteString("| Flag | Tipo | Padrão | Descrição |\n")
+            sb.WriteString("|------|------|--------|-----------|\n")
+            for _, f := range cmd.Flags {
+                names := []string{"`" + app.flagSpelling(f.Name) + "`"}
+                for _, alias := range f.Aliases {
+                    names = append(names, "`"+app.flagSpelling(alias)+"`")
+                }
+                description := f.Usage
+                if f.Required {
+                    description += " (obrigatória)"
+                }
+                if f.Persistent {
+                    description += " (herdada pelos subcomandos)"
+                }
+                if len(f.Env) > 0 {
+                    description += " · env `" + strings.Join(f.Env, "`, `") + "`"
+                }
+                def := ""
+                if f.Default != "" {
+                    def = "`" + f.Default + "`"
+                }
+                sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+                    strings.Join(names, ", "), f.Type, def, strings.ReplaceAll(description, "|", "\\|")))
+            }
+            sb.WriteString("\n")
+        }
+
+        if len(cmd.Commands) > 0 {
+            sb.WriteString("| Subcomando | Descrição |\n")
+            sb.WriteString("|------------|-----------|\n")
+            for _, child := range cmd.Commands {
+                sb.WriteString(fmt.Sprintf("| `%s` | %s |\n", child.Name, child.Short))
+            }
+            sb.WriteString("\n")
+        }
+    }
+    return sb.String()
+}
@@ -0,0 +1,136 @@
+package cli_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/cli"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestCLI(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "cmd/tool/main.go": `package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+)
+
+func main() {
+    verbose := flag.Bool("v", false, "saída detalhada")
+    _ = verbose
+    buildCmd := flag.NewFlagSet("build", flag.ExitOnError)
+    target := buildCmd.String("target", "linux", "plataforma alvo")
+    _ = target
+
+    switch os.Args[1] {
+    case "build":
+        buildCmd.Parse(os.Args[2:])
+    case "serve":
+        runServe(os.Args[2:])
+    }
+}
+
+// runServe inicia o servidor HTTP
+func runServe(args []string) {
+    fs := flag.NewFlagSet("serve", flag.ExitOnError)
+    fs.Int("port", 8080, "porta")
+    fs.Parse(args)
+}
+
+func usage() {
+    fmt.Println(` + "`" + `Comandos:
+  build  Compila o projeto` + "`" + `)
+}
+`,
+        "cmd/ctl/main.go": `package main
+
+import "github.com/spf13/cobra"
+
+var rootCmd = &cobra.Command{Use: "ctl", Short: "Controla o cluster"}
+
+func newGetCmd() *cobra.Command {
+    cmd := &cobra.Command{
+        Use:     "get NAME",
+        Short:   "Mostra um recurso",
+        Aliases: []string{"g"},
+        RunE:    runGet,
+    }
+    cmd.Flags().StringP("output", "o", "table", "formato de saída")
+    cmd.MarkFlagRequired("output")
+    return cmd
+}
+
+func runGet(cmd *cobra.Command, args []string) error { return nil }
+
+func main() {
+    rootCmd.PersistentFlags().String("kubeconfig", "", "arquivo kubeconfig")
+    rootCmd.AddCommand(newGetCmd())
+    rootCmd.Execute()
+}
+`,
+        "cmd/app/main.go": `package main
+
+import (
+    "os"
+
+    "github.com/urfave/cli/v2"
+)
+
+func main() {
+    app := &cli.App{
+        Name:  "app",
+        Usage: "Ferramenta de exemplo",
+        Flags: []cli.Flag{
+            &cli.StringFlag{Name: "config", Aliases: []string{"c"}, Value: "app.yml", Usage: "arquivo de configuração", EnvVars: []string{"APP_CONFIG"}},
+        },
+        Commands: []*cli.Command{
+            {Name: "migrate", Usage: "Executa as migrações", Subcommands: []*cli.Command{
+                {Name: "up", Usage: "Aplica", Flags: []cli.Flag{&cli.IntFlag{Name: "steps", Required: true}}},
+            }},
+        },
+    }
+    app.Run(os.Args)
+}
+`,
+    })
+
+    apps := cli.Build(pass)
+
+    var got []string
+    for _, app := range apps {
+        for _, cmd := range app.AllCommands() {
+            var flags []string
+            for _, f := range cmd.Flags {
+                flags = append(flags, fmt.Sprintf("%s:%s=%s%v", f.Name, f.Type, f.Default, f.Aliases))
+                if f.Required {
+                    flags[len(flags)-1] += "!"
+                }
+            }
+            got = append(got, fmt.Sprintf("%s %s | %s | %s | %v", app.Framework, cmd.Path, cmd.Short, cmd.Handler, flags))
+        }
+    }
+    want := []string{
+        `urfave app | Ferramenta de exemplo |  | [config:string="app.yml"[c]]`,
+        `urfave app migrate | Executa as migrações |  | []`,
+        `urfave app migrate up | Aplica |  | [steps:int=[]!]`,
+        `cobra ctl | Controla o cluster |  | [kubeconfig:string=""[]]`,
+        `cobra ctl get | Mostra um recurso | runGet | [output:string="table"[o]!]`,
+        `flag tool |  |  | [v:bool=false[]]`,
+        `flag tool build | Compila o projeto |  | [target:string="linux"[]]`,
+        `flag tool serve | inicia o servidor HTTP | runServe | [port:int=8080[]]`,
+    }
+    if strings.Join(got, "\n") != strings.Join(want, "\n") {
+        t.Errorf("comandos =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+    }
+
+    page := apps[1].Markdown()
+    for _, s := range []string{"## ctl get", "| `--output`, `-o` | string | `\"table\"` | formato de saída (obrigatória) |", "Aliases: `g`"} {
+        if !strings.Contains(page, s) {
+            t.Errorf("página da CLI sem %q:\n%s", s, page)
+        }
+    }
+}
@@ -0,0 +1,450 @@
+// Package concurrency descreve as goroutines, canais, travas e grupos de sincronização de cada pacote
+package concurrency
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Info descreve o modelo de concorrência de um pacote
+type Info struct {
+    Goroutines []GoroutineSite `json:"goroutines,omitempty"  yaml:"goroutines,omitempty"`
+    Channels   []ChannelInfo   `json:"channels,omitempty"    yaml:"channels,omitempty"`
+    Locks      []LockInfo      `json:"locks,omitempty"       yaml:"locks,omitempty"`
+    SyncGroups []SyncGroupUse  `json:"sync_groups,omitempty" yaml:"sync_groups,omitempty"`
+}
+
+// GoroutineSite representa um ponto onde uma goroutine é iniciada
+type GoroutineSite struct {
+    Function string `json:"function"      yaml:"function"` // função que inicia a goroutine
+    Target   string `json:"target"        yaml:"target"`   // função executada
+    Via      string `json:"via,omitempty" yaml:"via,omitempty"` // go, errgroup, sync.WaitGroup
+    File     string `json:"file"          yaml:"file"`
+    Line     int    `json:"line"          yaml:"line"`
+}
+
+// ChannelInfo representa um canal declarado em campo, variável ou assinatura
+type ChannelInfo struct {
+    Name      string   `json:"name"                yaml:"name"`
+    Kind      string   `json:"kind"                yaml:"kind"` // field, var, param, result
+    Type      string   `json:"type"                yaml:"type"`
+    Senders   []string `json:"senders,omitempty"   yaml:"senders,omitempty"`
+    Receivers []string `json:"receivers,omitempty" yaml:"receivers,omitempty"`
+    File      string   `json:"file"                yaml:"file"`
+    Line      int      `json:"line"                yaml:"line"`
+}
+
+// LockInfo representa um campo ou variável sync.Mutex/RWMutex ou atômico
+type LockInfo struct {
+    Name    string   `json:"name"              yaml:"name"`
+    Type    string   `json:"type"              yaml:"type"`
+    Methods []string `json:"methods,omitempty" yaml:"methods,omitempty"` // funções que bloqueiam (ou acessam, se atômico)
+    File    string   `json:"file"              yaml:"file"`
+    Line    int      `json:"line"              yaml:"line"`
+}
+
+// SyncGroupUse representa o uso de sync.WaitGroup ou errgroup em uma função
+type SyncGroupUse struct {
+    Kind     string   `json:"kind"     yaml:"kind"` // sync.WaitGroup, errgroup
+    Function string   `json:"function" yaml:"function"`
+    Calls    []string `json:"calls"    yaml:"calls"` // métodos chamados (Add, Done, Wait, Go)
+    File     string   `json:"file"     yaml:"file"`
+    Line     int      `json:"line"     yaml:"line"`
+}
+
+// lockMethods são os métodos que adquirem um sync.Mutex/RWMutex
+var lockMethods = map[string]bool{"Lock": true, "RLock": true, "TryLock": true, "TryRLock": true}
+
+// concurrencyScanner acumula as informações de concorrência de um pacote
+type concurrencyScanner struct {
+    fset      *token.FileSet
+    pkg       *analysis.Package
+    info      Info
+    channels  map[*types.Var]int // canal -> índice em info.Channels
+    locks     map[*types.Var]int // mutex/atômico -> índice em info.Locks
+    groups    map[string]int     // função + tipo -> índice em info.SyncGroups
+    errgroups map[types.Object]bool
+    users     map[int]map[string]bool // índice do lock -> funções que o usam
+}
+
+// Build gera o mapa de concorrência de cada pacote analisado, indexado pelo diretório
+func Build(pass *analysis.Pass) map[string]*Info {
+    result := make(map[string]*Info)
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil {
+            continue
+        }
+        s := &concurrencyScanner{
+            fset:      pass.Fset,
+            pkg:       pkg,
+            channels:  make(map[*types.Var]int),
+            locks:     make(map[*types.Var]int),
+            groups:    make(map[string]int),
+            errgroups: make(map[types.Object]bool),
+            users:     make(map[int]map[string]bool),
+        }
+        s.collectDeclarations()
+        pkg.FuncDecls(func(decl *ast.FuncDecl, obj *types.Func) {
+            if decl.Body != nil {
+                s.scanBody(analysis.LocalFuncName(obj, s.pkg.Types), decl.Body)
+            }
+        })
+        s.finish()
+
+        info := s.info
+        if len(info.Goroutines)+len(info.Channels)+len(info.Locks)+len(info.SyncGroups) == 0 {
+            continue
+        }
+        result[pkg.Dir] = &info
+    }
+    return result
+}
+
+// collectDeclarations registra canais e travas declarados em structs, variáveis e assinaturas
+func (s *concurrencyScanner) collectDeclarations() {
+    scope := s.pkg.Types.Scope()
+    for _, name := range scope.Names() {
+        switch obj := scope.Lookup(name).(type) {
+        case *types.TypeName:
+            st, ok := obj.Type().Underlying().(*types.Struct)
+            if !ok || obj.IsAlias() {
+                continue
+            }
+            for i := 0; i < st.NumFields(); i++ {
+                s.declare(st.Field(i), obj.Name()+"."+st.Field(i).Name(), "field")
+            }
+        case *types.Var:
+            s.declare(obj, obj.Name(), "var")
+        }
+    }
+
+    s.pkg.FuncDecls(func(decl *ast.FuncDecl, fn *types.Func) {
+        sig := fn.Type().(*types.Signature)
+        name := analysis.LocalFuncName(fn, s.pkg.Types)
+        for i := 0; i < sig.Params().Len(); i++ {
+            p := sig.Params().At(i)
+            s.declare(p, name+"("+p.Name()+")", "param")
+        }
+        for i := 0; i < sig.Results().Len(); i++ {
+            r := sig.Results().At(i)
+            label := r.Name()
+            if label == "" {
+                label = "#" + strconv.Itoa(i+1)
+            }
+            s.declare(r, name+" → "+label, "result")
+        }
+    })
+}
+
+// declare registra um canal ou trava, conforme o tipo da variável
+func (s *concurrencyScanner) declare(v *types.Var, name, kind string) {
+    pos := s.fset.Position(v.Pos())
+    qualifier := types.RelativeTo(s.pkg.Types)
+    if _, ok := v.Type().Underlying().(*types.Chan); ok {
+        s.channels[v] = len(s.info.Channels)
+        s.info.Channels = append(s.info.Channels, ChannelInfo{
+            Name: name,
+            Kind: kind,
+            Type: types.TypeString(v.Type(), qualifier),
+            File: pos.Filename,
+            Line: pos.Line,
+        })
+        return
+    }
+    if kind != "field" && kind != "var" {
+        return
+    }
+    if label := syncTypeName(v.Type()); label != "" && label != "sync.WaitGroup" {
+        s.locks[v] = len(s.info.Locks)
+        s.info.Locks = append(s.info.Locks, LockInfo{
+            Name: name,
+            Type: label,
+            File: pos.Filename,
+            Line: pos.Line,
+        })
+    }
+}
+
+// syncTypeName identifica os tipos de sincronização da biblioteca padrão
+func syncTypeName(t types.Type) string {
+    if ptr, ok := t.(*types.Pointer); ok {
+        t = ptr.Elem()
+    }
+    named, ok := t.(*types.Named)
+    if !ok || named.Obj().Pkg() == nil {
+        return ""
+    }
+    name := named.Obj().Name()
+    switch named.Obj().Pkg().Path() {
+    case "sync":
+        if name == "Mutex" || name == "RWMutex" || name == "WaitGroup" {
+            return "sync." + name
+        }
+    case "sync/atomic":
+        return "atomic." + name
+    }
+    return ""
+}
+
+// scanBody percorre o corpo de uma função procurando goroutines, operações em
+// canais, travas e grupos de sincronização
+func (s *concurrencyScanner) scanBody(function string, body *ast.BlockStmt) {
+    info := s.pkg.Info
+    ast.Inspect(body, func(n ast.Node) bool {
+        switch node := n.(type) {
+        case *ast.GoStmt:
+            s.addGoroutine(function, node.Call.Fun, "go", node.Pos())
+        case *ast.SendStmt:
+            if i, ok := s.channels[s.varOf(node.Chan)]; ok {
+                s.info.Channels[i].Senders = analysis.AppendUnique(s.info.Channels[i].Senders, function)
+            }
+        case *ast.UnaryExpr:
+            if node.Op == token.ARROW {
+                if i, ok := s.channels[s.varOf(node.X)]; ok {
+                    s.info.Channels[i].Receivers = analysis.AppendUnique(s.info.Channels[i].Receivers, function)
+                }
+            }
+        case *ast.RangeStmt:
+            if i, ok := s.channels[s.varOf(node.X)]; ok {
+                s.info.Channels[i].Receivers = analysis.AppendUnique(s.info.Channels[i].Receivers, function)
+            }
+        case *ast.AssignStmt:
+            s.trackErrgroups(node.Lhs, node.Rhs)
+        case *ast.ValueSpec:
+            if isErrgroupExpr(info, node.Type) {
+                for _, name := range node.Names {
+                    s.errgroups[info.Defs[name]] = true
+                }
+            }
+            s.trackErrgroups(analysis.IdentsToExprs(node.Names), node.Values)
+        case *ast.CallExpr:
+            s.inspectCall(function, node)
+        }
+        return true
+    })
+}
+
+// inspectCall trata chamadas a travas, atômicos, WaitGroup e errgroup
+func (s *concurrencyScanner) inspectCall(function string, call *ast.CallExpr) {
+    info := s.pkg.Info
+    sel, ok := call.Fun.(*ast.SelectorExpr)
+    if !ok {
+        return
+    }
+    method := sel.Sel.Name
+
+    // Funções do sync/atomic sobre campos: atomic.AddInt64(&s.count, 1)
+    if pkgName, ok := analysis.IdentObject(info, sel.X).(*types.PkgName); ok {
+        if pkgName.Imported().Path() == "sync/atomic" && len(call.Args) > 0 {
+            if addr, ok := call.Args[0].(*ast.UnaryExpr); ok && addr.Op == token.AND {
+                if v := s.varOf(addr.X); v != nil {
+                    s.useLock(v, "atomic."+method, function)
+                }
+            }
+        }
+        return
+    }
+
+    if v := s.lockTarget(sel); v != nil {
+        label := syncTypeName(v.Type())
+        switch {
+        case strings.HasPrefix(label, "atomic."):
+            s.useLock(v, label, function)
+        case lockMethods[method] && label != "sync.WaitGroup":
+            s.useLock(v, label, function)
+        }
+    }
+
+    switch {
+    case syncTypeName(analysis.TypeOrNil(info, sel.X)) == "sync.WaitGroup":
+        s.addGroupCall("sync.WaitGroup", function, method, call.Pos())
+        if method == "Go" && len(call.Args) == 1 {
+            s.addGoroutine(function, call.Args[0], "sync.WaitGroup", call.Pos())
+        }
+    case s.errgroups[analysis.IdentObject(info, sel.X)]:
+        s.addGroupCall("errgroup", function, method, call.Pos())
+        if method == "Go" && len(call.Args) == 1 {
+            s.addGoroutine(function, call.Args[0], "errgroup", call.Pos())
+        }
+    }
+}
+
+// lockTarget retorna o campo ou variável de sincronização acessado pelo seletor,
+// incluindo mutexes embutidos (c.Lock() com sync.Mutex embutido em c)
+func (s *concurrencyScanner) lockTarget(sel *ast.SelectorExpr) *types.Var {
+    if selection, ok := s.pkg.Info.Selections[sel]; ok && len(selection.Index()) > 1 {
+        t := selection.Recv()
+        if ptr, ok := t.Underlying().(*types.Pointer); ok {
+            t = ptr.Elem()
+        }
+        if st, ok := t.Underlying().(*types.Struct); ok {
+            field := st.Field(selection.Index()[0])
+            if _, known := s.locks[field]; known {
+                return field
+            }
+        }
+    }
+    if v := s.varOf(sel.X); v != nil {
+        if _, known := s.locks[v]; known {
+            return v
+        }
+    }
+    return nil
+}
+
+// useLock registra a função que bloqueia ou acessa uma trava. Campos acessados
+// apenas via funções do sync/atomic são registrados na primeira ocorrência
+func (s *concurrencyScanner) useLock(v *types.Var, label, function string) {
+    i, ok := s.locks[v]
+    if !ok {
+        pos := s.fset.Position(v.Pos())
+        i = len(s.info.Locks)
+        s.locks[v] = i
+        s.info.Locks = append(s.info.Locks, LockInfo{
+            Name: s.varName(v),
+            Type: types.TypeString(v.Type(), types.RelativeTo(s.pkg.Types)) + " (" + label + ")",
+            File: pos.Filename,
+            Line: pos.Line,
+        })
+    }
+    if s.users[i] == nil {
+        s.users[i] = make(map[string]bool)
+    }
+    s.users[i][function] = true
+}
+
+// addGoroutine registra o início de uma goroutine
+func (s *concurrencyScanner) addGoroutine(function string, fun ast.Expr, via string, pos token.Pos) {
+    target := "func literal"
+    if _, isLit := ast.Unparen(fun).(*ast.FuncLit); !isLit {
+        target = types.ExprString(fun)
+        if callee, _ := analysis.ResolveCallee(s.pkg.Info, &ast.CallExpr{Fun: fun}); callee != nil {
+            target = analysis.LocalFuncName(callee, s.pkg.Types)
+        }
+    }
+    position := s.fset.Position(pos)
+    s.info.Goroutines = append(s.info.Goroutines, GoroutineSite{
+        Function: function,
+        Target:   target,
+        Via:      via,
+        File:     position.Filename,
+        Line:     position.Line,
+    })
+}
+
+// addGroupCall agrega as chamadas a um WaitGroup ou errgroup por função
+func (s *concurrencyScanner) addGroupCall(kind, function, method string, pos token.Pos) {
+    key := function + "|" + kind
+    i, ok := s.groups[key]
+    if !ok {
+        position := s.fset.Position(pos)
+        i = len(s.info.SyncGroups)
+        s.groups[key] = i
+        s.info.SyncGroups = append(s.info.SyncGroups, SyncGroupUse{
+            Kind:     kind,
+            Function: function,
+            File:     position.Filename,
+            Line:     position.Line,
+        })
+    }
+    s.info.SyncGroups[i].Calls = analysis.AppendUnique(s.info.SyncGroups[i].Calls, method)
+}
+
+// trackErrgroups marca as variáveis atribuídas a partir de errgroup.WithContext,
+// new(errgroup.Group) ou &errgroup.Group{}
+func (s *concurrencyScanner) trackErrgroups(lhs, rhs []ast.Expr) {
+    if len(rhs) != 1 || len(lhs) == 0 || !isErrgroupExpr(s.pkg.Info, rhs[0]) {
+        return
+    }
+    if obj := analysis.IdentObject(s.pkg.Info, lhs[0]); obj != nil {
+        s.errgroups[obj] = true
+    }
+}
+
+// finish ordena as listas e preenche as funções de cada trava
+func (s *concurrencyScanner) finish() {
+    for i := range s.info.Locks {
+        s.info.Locks[i].Methods = analysis.SortedKeys(s.users[i])
+    }
+    for i := range s.info.Channels {
+        sort.Strings(s.info.Channels[i].Senders)
+        sort.Strings(s.info.Channels[i].Receivers)
+    }
+    // Parâmetros e resultados sem operações não acrescentam informação além da assinatura
+    var channels []ChannelInfo
+    for _, ch := range s.info.Channels {
+        if ch.Kind == "field" || ch.Kind == "var" || len(ch.Senders)+len(ch.Receivers) > 0 {
+            channels = append(channels, ch)
+        }
+    }
+    s.info.Channels = channels
+}
+
+// varOf retorna a variável (campo, variável ou parâmetro) referenciada pela expressão
+func (s *concurrencyScanner) varOf(expr ast.Expr) *types.Var {
+    switch e := ast.Unparen(expr).(type) {
+    case *ast.Ident:
+        v, _ := s.pkg.Info.Uses[e].(*types.Var)
+        return v
+    case *ast.SelectorExpr:
+        if selection, ok := s.pkg.Info.Selections[e]; ok {
+            v, _ := selection.Obj().(*types.Var)
+            return v
+        }
+        v, _ := s.pkg.Info.Uses[e.Sel].(*types.Var)
+        return v
+    case *ast.StarExpr:
+        return s.varOf(e.X)
+    }
+    return nil
+}
+
+// varName descreve uma variável como Tipo.campo ou nome
+func (s *concurrencyScanner) varName(v *types.Var) string {
+    if v.IsField() {
+        scope := s.pkg.Types.Scope()
+        for _, name := range scope.Names() {
+            tn, ok := scope.Lookup(name).(*types.TypeName)
+            if !ok {
+                continue
+            }
+            if st, ok := tn.Type().Underlying().(*types.Struct); ok {
+                for i := 0; i < st.NumFields(); i++ {
+                    if st.Field(i) == v {
+                        return tn.Name() + "." + v.Name()
+                    }
+                }
+            }
+        }
+    }
+    return v.Name()
+}
+
+// isErrgroupExpr reconhece expressões que criam ou nomeiam um errgroup.Group
+func isErrgroupExpr(info *types.Info, expr ast.Expr) bool {
+    if expr == nil {
+        return false
+    }
+    found := false
+    ast.Inspect(expr, func(n ast.Node) bool {
+        sel, ok := n.(*ast.SelectorExpr)
+        if !ok {
+            return !found
+        }
+        if pkgName, ok := analysis.IdentObject(info, sel.X).(*types.PkgName); ok {
+            if strings.HasSuffix(pkgName.Imported().Path(), "/errgroup") &&
+                (sel.Sel.Name == "Group" || sel.Sel.Name == "WithContext") {
+                found = true
+            }
+        }
+        return false
+    })
+    return found
+}
+
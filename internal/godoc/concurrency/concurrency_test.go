@@ -0,0 +1,90 @@
+package concurrency_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/concurrency"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestConcurrency(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "worker/worker.go": `package worker
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type Pool struct {
+	sync.Mutex
+	jobs  chan int
+	mu    sync.RWMutex
+	count atomic.Int64
+	done  int64
+}
+
+func (p *Pool) Start(n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go p.run(&wg)
+	}
+	wg.Wait()
+}
+
+func (p *Pool) run(wg *sync.WaitGroup) {
+	defer wg.Done()
+	for job := range p.jobs {
+		p.count.Add(int64(job))
+		atomic.AddInt64(&p.done, 1)
+	}
+}
+
+func (p *Pool) Submit(job int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.jobs <- job
+}
+
+func (p *Pool) Reset() {
+	p.Lock()
+	defer p.Unlock()
+}
+`,
+    })
+
+    info := concurrency.Build(pass)[pass.Packages[0].Dir]
+    if info == nil {
+        t.Fatal("mapa de concorrência não gerado")
+    }
+
+    if len(info.Goroutines) != 1 || info.Goroutines[0].Function != "Pool.Start" || info.Goroutines[0].Target != "Pool.run" {
+        t.Errorf("goroutines = %+v; want Pool.Start -> Pool.run", info.Goroutines)
+    }
+    if len(info.Channels) != 1 || strings.Join(info.Channels[0].Senders, ",") != "Pool.Submit" ||
+        strings.Join(info.Channels[0].Receivers, ",") != "Pool.run" {
+        t.Errorf("canais = %+v; want Pool.jobs enviado por Submit e recebido por run", info.Channels)
+    }
+
+    locks := make(map[string][]string)
+    for _, l := range info.Locks {
+        locks[l.Name] = l.Methods
+    }
+    want := map[string]string{
+        "Pool.Mutex": "Pool.Reset",
+        "Pool.mu":    "Pool.Submit",
+        "Pool.count": "Pool.run",
+        "Pool.done":  "Pool.run",
+    }
+    for name, methods := range want {
+        if got := strings.Join(locks[name], ","); got != methods {
+            t.Errorf("trava %s usada por %q; want %q", name, got, methods)
+        }
+    }
+
+    if len(info.SyncGroups) != 2 {
+        t.Errorf("sync groups = %+v; want usos em Start e run", info.SyncGroups)
+    }
+}
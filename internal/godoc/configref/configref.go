@@ -0,0 +1,420 @@
+// Package configref gera a referência das opções de configuração lidas pelo código:
+// variáveis de ambiente e flags de linha de comando
+package configref
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Setting representa uma opção de configuração lida pelo código: variável
+// de ambiente (os.Getenv, os.LookupEnv ou tags env/envconfig) ou flag de linha de comando
+type Setting struct {
+    Name        string       `json:"name"                  yaml:"name"`
+    Source      string       `json:"source"                yaml:"source"` // env, flag
+    Set         string       `json:"set,omitempty"         yaml:"set,omitempty"` // nome do FlagSet; vazio para flag.CommandLine
+    Type        string       `json:"type,omitempty"        yaml:"type,omitempty"`
+    Default     string       `json:"default,omitempty"     yaml:"default,omitempty"`
+    Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+    Required    bool         `json:"required,omitempty"    yaml:"required,omitempty"`
+    Reads       []Read `json:"reads"                 yaml:"reads"` // onde a opção é lida ou definida
+}
+
+// Read representa o local em que uma opção de configuração é lida
+type Read struct {
+    Package  string `json:"package"            yaml:"package"`
+    Function string `json:"function,omitempty" yaml:"function,omitempty"` // vazio em declarações de pacote
+    File     string `json:"file"               yaml:"file"`
+    Line     int    `json:"line"               yaml:"line"`
+}
+
+// flagFunc descreve a posição dos argumentos de uma função de definição de flag
+type flagFunc struct {
+    name, value, usage int // índices; -1 quando o argumento não existe
+    typ                string
+}
+
+// flagFuncs lista as funções do pacote flag (e métodos de *flag.FlagSet) que definem flags
+var flagFuncs = map[string]flagFunc{
+    "String":      {0, 1, 2, "string"},
+    "Bool":        {0, 1, 2, "bool"},
+    "Int":         {0, 1, 2, "int"},
+    "Int64":       {0, 1, 2, "int64"},
+    "Uint":        {0, 1, 2, "uint"},
+    "Uint64":      {0, 1, 2, "uint64"},
+    "Float64":     {0, 1, 2, "float64"},
+    "Duration":    {0, 1, 2, "time.Duration"},
+    "StringVar":   {1, 2, 3, "string"},
+    "BoolVar":     {1, 2, 3, "bool"},
+    "IntVar":      {1, 2, 3, "int"},
+    "Int64Var":    {1, 2, 3, "int64"},
+    "UintVar":     {1, 2, 3, "uint"},
+    "Uint64Var":   {1, 2, 3, "uint64"},
+    "Float64Var":  {1, 2, 3, "float64"},
+    "DurationVar": {1, 2, 3, "time.Duration"},
+    "TextVar":     {1, 2, 3, ""},
+    "Var":         {1, -1, 2, ""},
+    "Func":        {0, -1, 1, "func(string) error"},
+    "BoolFunc":    {0, -1, 1, "bool"},
+}
+
+// envWrapper descreve uma função auxiliar que repassa um parâmetro para os.Getenv,
+// como getEnv(key, fallback string) string
+type envWrapper struct {
+    name     int // índice do parâmetro com o nome da variável
+    fallback int // índice do parâmetro com o valor padrão; -1 se não houver
+    typ      string
+}
+
+// configScanner acumula as opções de configuração de todos os pacotes
+type configScanner struct {
+    fset     *token.FileSet
+    pkg      *analysis.Package
+    wrappers map[*types.Func]envWrapper
+    flagSets map[types.Object]string // variáveis *flag.FlagSet -> nome do conjunto
+    settings map[string]*Setting
+    order    []string
+}
+
+// Build gera a referência de configuração do projeto
+func Build(pass *analysis.Pass) []Setting {
+    s := &configScanner{
+        fset:     pass.Fset,
+        wrappers: make(map[*types.Func]envWrapper),
+        settings: make(map[string]*Setting),
+    }
+    // Funções auxiliares são identificadas antes para reconhecer chamadas em outros pacotes
+    for _, pkg := range pass.Packages {
+        if pkg.Types != nil {
+            s.collectEnvWrappers(pkg)
+        }
+    }
+
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil {
+            continue
+        }
+        s.pkg = pkg
+        s.flagSets = make(map[types.Object]string)
+        s.collectFlagSets()
+        for _, file := range pkg.Files {
+            for _, decl := range file.Decls {
+                function := ""
+                if fd, ok := decl.(*ast.FuncDecl); ok {
+                    if obj, ok := pkg.Info.Defs[fd.Name].(*types.Func); ok {
+                        function = analysis.LocalFuncName(obj, pkg.Types)
+                    }
+                }
+                if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+                    s.scanStructTags(gen)
+                }
+                ast.Inspect(decl, func(n ast.Node) bool {
+                    if call, ok := n.(*ast.CallExpr); ok {
+                        s.inspectCall(function, call)
+                    }
+                    return true
+                })
+            }
+        }
+    }
+
+    var result []Setting
+    for _, key := range s.order {
+        result = append(result, *s.settings[key])
+    }
+    sort.SliceStable(result, func(i, j int) bool {
+        if result[i].Source != result[j].Source {
+            return result[i].Source < result[j].Source
+        }
+        if result[i].Set != result[j].Set {
+            return result[i].Set < result[j].Set
+        }
+        return result[i].Name < result[j].Name
+    })
+    return result
+}
+
+// Flags reconhece as definições de flags de um pacote, inclusive as feitas em
+// variáveis *flag.FlagSet criadas com flag.NewFlagSet
+type Flags struct {
+    scanner *configScanner
+}
+
+// NewFlags prepara o reconhecimento das flags de um pacote
+func NewFlags(pkg *analysis.Package) *Flags {
+    s := &configScanner{pkg: pkg, flagSets: make(map[types.Object]string)}
+    s.collectFlagSets()
+    return &Flags{scanner: s}
+}
+
+// Setting interpreta uma definição de flag do pacote flag ou de um *flag.FlagSet
+func (f *Flags) Setting(call *ast.CallExpr) (Setting, bool) {
+    return f.scanner.flagSetting(call)
+}
+
+// SetName retorna o nome do conjunto de flags atribuído à variável obj
+func (f *Flags) SetName(obj types.Object) (string, bool) {
+    name, ok := f.scanner.flagSets[obj]
+    return name, ok
+}
+
+// Value formata o valor padrão de uma flag
+func (f *Flags) Value(expr ast.Expr) string {
+    return f.scanner.valueString(expr)
+}
+
+// collectEnvWrappers encontra funções que repassam um parâmetro para os.Getenv/os.LookupEnv
+func (s *configScanner) collectEnvWrappers(pkg *analysis.Package) {
+    pkg.FuncDecls(func(decl *ast.FuncDecl, obj *types.Func) {
+        if decl.Body == nil {
+            return
+        }
+        sig := obj.Type().(*types.Signature)
+        ast.Inspect(decl.Body, func(n ast.Node) bool {
+            call, ok := n.(*ast.CallExpr)
+            if !ok || !isEnvCall(pkg.Info, call) || len(call.Args) != 1 {
+                return true
+            }
+            param, ok := analysis.IdentObject(pkg.Info, call.Args[0]).(*types.Var)
+            if !ok {
+                return true
+            }
+            for i := 0; i < sig.Params().Len(); i++ {
+                if sig.Params().At(i) != param {
+                    continue
+                }
+                w := envWrapper{name: i, fallback: -1, typ: "string"}
+                // O outro parâmetro de getEnv(key, fallback) é o valor padrão
+                if sig.Params().Len() == 2 {
+                    w.fallback = 1 - i
+                }
+                if sig.Results().Len() > 0 && analysis.IsValidType(sig.Results().At(0).Type()) {
+                    w.typ = types.TypeString(sig.Results().At(0).Type(), types.RelativeTo(pkg.Types))
+                }
+                s.wrappers[obj] = w
+            }
+            return true
+        })
+    })
+}
+
+// collectFlagSets associa as variáveis criadas com flag.NewFlagSet ao nome do conjunto
+func (s *configScanner) collectFlagSets() {
+    info := s.pkg.Info
+    track := func(lhs []ast.Expr, rhs []ast.Expr) {
+        if len(lhs) != len(rhs) {
+            return
+        }
+        for i, value := range rhs {
+            call, ok := ast.Unparen(value).(*ast.CallExpr)
+            if !ok || !analysis.IsPackageCall(info, call, "flag", "NewFlagSet") || len(call.Args) == 0 {
+                continue
+            }
+            name, ok := analysis.ConstString(info, call.Args[0])
+            if !ok {
+                name = types.ExprString(call.Args[0])
+            }
+            if obj := analysis.IdentObject(info, lhs[i]); obj != nil {
+                s.flagSets[obj] = name
+            }
+        }
+    }
+    for _, file := range s.pkg.Files {
+        ast.Inspect(file, func(n ast.Node) bool {
+            switch node := n.(type) {
+            case *ast.AssignStmt:
+                track(node.Lhs, node.Rhs)
+            case *ast.ValueSpec:
+                track(analysis.IdentsToExprs(node.Names), node.Values)
+            }
+            return true
+        })
+    }
+}
+
+// inspectCall trata leituras de variáveis de ambiente e definições de flags
+func (s *configScanner) inspectCall(function string, call *ast.CallExpr) {
+    info := s.pkg.Info
+
+    if isEnvCall(info, call) && len(call.Args) == 1 {
+        if name, ok := analysis.ConstString(info, call.Args[0]); ok {
+            s.add(Setting{Name: name, Source: "env", Type: "string"}, function, call.Pos())
+        }
+        return
+    }
+
+    if fn, _ := analysis.ResolveCallee(info, call); fn != nil {
+        if w, ok := s.wrappers[fn]; ok && w.name < len(call.Args) {
+            name, ok := analysis.ConstString(info, call.Args[w.name])
+            if !ok {
+                return
+            }
+            setting := Setting{Name: name, Source: "env", Type: w.typ}
+            if w.fallback >= 0 && w.fallback < len(call.Args) {
+                setting.Default = s.valueString(call.Args[w.fallback])
+            }
+            s.add(setting, function, call.Pos())
+            return
+        }
+    }
+
+    if setting, ok := s.flagSetting(call); ok {
+        s.add(setting, function, call.Pos())
+    }
+}
+
+// flagSetting interpreta uma definição de flag do pacote flag ou de um *flag.FlagSet
+func (s *configScanner) flagSetting(call *ast.CallExpr) (Setting, bool) {
+    info := s.pkg.Info
+    sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+    if !ok {
+        return Setting{}, false
+    }
+    def, ok := flagFuncs[sel.Sel.Name]
+    if !ok {
+        return Setting{}, false
+    }
+    set, ok := s.flagSetOf(sel.X)
+    if !ok || def.name >= len(call.Args) || def.usage >= len(call.Args) {
+        return Setting{}, false
+    }
+    name, ok := analysis.ConstString(info, call.Args[def.name])
+    if !ok {
+        return Setting{}, false
+    }
+    setting := Setting{Name: name, Source: "flag", Set: set, Type: def.typ}
+    if def.value >= 0 {
+        setting.Default = s.valueString(call.Args[def.value])
+    }
+    if setting.Type == "" {
+        // Var e TextVar: o tipo é o do valor passado
+        if t := analysis.TypeOrNil(info, call.Args[0]); analysis.IsValidType(t) {
+            setting.Type = types.TypeString(t, types.RelativeTo(s.pkg.Types))
+        }
+    }
+    setting.Description, _ = analysis.ConstString(info, call.Args[def.usage])
+    return setting, true
+}
+
+// flagSetOf informa se a expressão é o pacote flag ou um *flag.FlagSet e retorna o nome do conjunto
+func (s *configScanner) flagSetOf(expr ast.Expr) (string, bool) {
+    obj := analysis.IdentObject(s.pkg.Info, expr)
+    if pkgName, ok := obj.(*types.PkgName); ok {
+        return "", pkgName.Imported().Path() == "flag"
+    }
+    if name, ok := s.flagSets[obj]; ok {
+        return name, true
+    }
+    t := analysis.TypeOrNil(s.pkg.Info, expr)
+    if ptr, ok := t.(*types.Pointer); ok {
+        t = ptr.Elem()
+    }
+    if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil &&
+        named.Obj().Pkg().Path() == "flag" && named.Obj().Name() == "FlagSet" {
+        return types.ExprString(expr), true
+    }
+    return "", false
+}
+
+// scanStructTags registra os campos com tags env (caarlos0/env) ou envconfig
+func (s *configScanner) scanStructTags(gen *ast.GenDecl) {
+    for _, spec := range gen.Specs {
+        ts := spec.(*ast.TypeSpec)
+        st, ok := ts.Type.(*ast.StructType)
+        if !ok {
+            continue
+        }
+        for _, field := range st.Fields.List {
+            if field.Tag == nil {
+                continue
+            }
+            raw, err := strconv.Unquote(field.Tag.Value)
+            if err != nil {
+                continue
+            }
+            tag := reflect.StructTag(raw)
+            for _, key := range []string{"env", "envconfig"} {
+                value, ok := tag.Lookup(key)
+                if !ok {
+                    continue
+                }
+                name, opts, _ := strings.Cut(value, ",")
+                if name == "" || name == "-" {
+                    continue
+                }
+                setting := Setting{
+                    Name:     name,
+                    Source:   "env",
+                    Type:     types.ExprString(field.Type),
+                    Required: analysis.ContainsString(strings.Split(opts, ","), "required") || tag.Get("required") == "true",
+                }
+                if def, ok := tag.Lookup("envDefault"); ok {
+                    setting.Default = strconv.Quote(def)
+                } else if def, ok := tag.Lookup("default"); ok {
+                    setting.Default = strconv.Quote(def)
+                }
+                setting.Description = tag.Get("desc")
+                if setting.Description == "" {
+                    setting.Description = strings.Join(strings.Fields(field.Doc.Text()+field.Comment.Text()), " ")
+                }
+                function := ts.Name.Name
+                if len(field.Names) > 0 {
+                    function += "." + field.Names[0].Name
+                }
+                s.add(setting, function, field.Pos())
+            }
+        }
+    }
+}
+
+// add registra uma leitura, agrupando pela origem, conjunto e nome da opção
+func (s *configScanner) add(setting Setting, function string, pos token.Pos) {
+    key := setting.Source + "\x00" + setting.Set + "\x00" + setting.Name
+    existing, ok := s.settings[key]
+    if !ok {
+        existing = &Setting{Name: setting.Name, Source: setting.Source, Set: setting.Set}
+        s.settings[key] = existing
+        s.order = append(s.order, key)
+    }
+    if existing.Type == "" || existing.Type == "string" && setting.Type != "" {
+        existing.Type = setting.Type
+    }
+    if existing.Default == "" {
+        existing.Default = setting.Default
+    }
+    if existing.Description == "" {
+        existing.Description = setting.Description
+    }
+    existing.Required = existing.Required || setting.Required
+    position := s.fset.Position(pos)
+    existing.Reads = append(existing.Reads, Read{
+        Package:  s.pkg.ImportPath,
+        Function: function,
+        File:     position.Filename,
+        Line:     position.Line,
+    })
+}
+
+// valueString formata o valor padrão: constantes nomeadas pelo valor, demais expressões pelo código
+func (s *configScanner) valueString(expr ast.Expr) string {
+    switch ast.Unparen(expr).(type) {
+    case *ast.Ident, *ast.SelectorExpr:
+        if tv, ok := s.pkg.Info.Types[expr]; ok && tv.Value != nil {
+            return tv.Value.ExactString()
+        }
+    }
+    return types.ExprString(expr)
+}
+
+// isEnvCall reconhece chamadas a os.Getenv e os.LookupEnv
+func isEnvCall(info *types.Info, call *ast.CallExpr) bool {
+    return analysis.IsPackageCall(info, call, "os", "Getenv") || analysis.IsPackageCall(info, call, "os", "LookupEnv")
+}
+
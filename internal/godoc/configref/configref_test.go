@@ -0,0 +1,70 @@
+package configref_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/configref"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestConfigReference(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "app/app.go": `package app
+
+import (
+	"flag"
+	"os"
+	"strconv"
+)
+
+const defaultPort = 8080
+
+type Config struct {
+	// URL do banco de dados
+	DatabaseURL string ` + "`env:\"DATABASE_URL,required\"`" + `
+	LogLevel    string ` + "`envconfig:\"LOG_LEVEL\" default:\"info\" desc:\"Nível de log\"`" + `
+}
+
+func getEnvInt(key string, fallback int) int {
+	if v, ok := os.LookupEnv(key); ok {
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return fallback
+}
+
+func Load(args []string) {
+	_ = os.Getenv("HOME")
+	_ = getEnvInt("PORT", defaultPort)
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.String("addr", ":8080", "Endereço de escuta")
+	var verbose bool
+	flag.BoolVar(&verbose, "v", false, "Modo detalhado")
+	_ = fs.Parse(args)
+}
+`,
+    })
+
+    settings := configref.Build(pass)
+
+    var got []string
+    for _, s := range settings {
+        got = append(got, fmt.Sprintf("%s:%s:%s:%s:%s:%s:%v", s.Source, s.Set, s.Name, s.Type, s.Default, s.Description, s.Required))
+    }
+    want := []string{
+        "env::DATABASE_URL:string::URL do banco de dados:true",
+        "env::HOME:string:::false",
+        `env::LOG_LEVEL:string:"info":Nível de log:false`,
+        "env::PORT:int:8080::false",
+        "flag::v:bool:false:Modo detalhado:false",
+        `flag:serve:addr:string:":8080":Endereço de escuta:false`,
+    }
+    if strings.Join(got, "\n") != strings.Join(want, "\n") {
+        t.Errorf("configuração =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+    }
+    if reads := settings[3].Reads; len(reads) != 1 || reads[0].Function != "Load" || reads[0].Line == 0 {
+        t.Errorf("leituras de PORT = %+v", reads)
+    }
+}
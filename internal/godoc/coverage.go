@@ -0,0 +1,160 @@
+package godoc
+
+import (
+	"fmt"
+	"go/ast"
+	"net/url"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// DocCoverage representa a cobertura de documentação dos símbolos exportados
+type DocCoverage struct {
+    Total        int                  `json:"total"        yaml:"total"`
+    Documented   int                  `json:"documented"   yaml:"documented"`
+    Percent      float64              `json:"percent"      yaml:"percent"`
+    Packages     []CoverageGroup      `json:"packages"     yaml:"packages"`
+    Files        []CoverageGroup      `json:"files"        yaml:"files"`
+    Undocumented []UndocumentedSymbol `json:"undocumented" yaml:"undocumented"`
+}
+
+// CoverageGroup representa a cobertura de um pacote ou arquivo
+type CoverageGroup struct {
+    Name       string  `json:"name"       yaml:"name"`
+    Total      int     `json:"total"      yaml:"total"`
+    Documented int     `json:"documented" yaml:"documented"`
+    Percent    float64 `json:"percent"    yaml:"percent"`
+}
+
+// UndocumentedSymbol representa um símbolo exportado sem comentário de documentação
+type UndocumentedSymbol struct {
+    Kind string `json:"kind" yaml:"kind"` // function, method, type, field, const, var
+    Name string `json:"name" yaml:"name"`
+    File string `json:"file" yaml:"file"`
+    Line int    `json:"line" yaml:"line"`
+}
+
+// computeDocCoverage calcula a cobertura de documentação por pacote e por arquivo
+func (p *ProjectDoc) computeDocCoverage() *DocCoverage {
+    cov := &DocCoverage{}
+
+    for _, dir := range p.Directories {
+        pkg := CoverageGroup{Name: dir.ImportPath}
+        if pkg.Name == "" {
+            pkg.Name = dir.Path // diretório fora de um módulo
+        }
+        for _, file := range dir.Files {
+            group := CoverageGroup{Name: file.FileName}
+            check := func(kind, name, doc, fileName string, line int) {
+                if !ast.IsExported(name) {
+                    return
+                }
+                group.Total++
+                if doc != "" {
+                    group.Documented++
+                    return
+                }
+                cov.Undocumented = append(cov.Undocumented, UndocumentedSymbol{
+                    Kind: kind,
+                    Name: name,
+                    File: fileName,
+                    Line: line,
+                })
+            }
+
+            for _, fn := range file.Functions {
+                check("function", fn.Name, fn.Doc, fn.File, fn.Line)
+            }
+            for _, iface := range file.Interfaces {
+                check("type", iface.Name, iface.Doc, iface.File, iface.Line)
+                if !ast.IsExported(iface.Name) {
+                    continue
+                }
+                for _, m := range iface.Methods {
+                    if ast.IsExported(m.Name) {
+                        check("method", iface.Name+"."+m.Name, m.Doc, m.File, m.Line)
+                    }
+                }
+            }
+            for _, str := range file.Structs {
+                check("type", str.Name, str.Doc, str.File, str.Line)
+                if !ast.IsExported(str.Name) {
+                    continue
+                }
+                for _, f := range str.Fields {
+                    if ast.IsExported(f.Name) {
+                        check("field", str.Name+"."+f.Name, f.Doc, f.File, f.Line)
+                    }
+                }
+                for _, m := range str.Methods {
+                    if ast.IsExported(m.Name) {
+                        check("method", str.Name+"."+m.Name, m.Doc, m.File, m.Line)
+                    }
+                }
+            }
+            for _, c := range file.Constants {
+                check("const", c.Name, c.Doc, c.File, c.Line)
+            }
+            for _, v := range file.Variables {
+                check("var", v.Name, v.Doc, v.File, v.Line)
+            }
+
+            if group.Total == 0 {
+                continue
+            }
+            group.Percent = percentOf(group.Documented, group.Total)
+            cov.Files = append(cov.Files, group)
+            pkg.Total += group.Total
+            pkg.Documented += group.Documented
+        }
+
+        if pkg.Total == 0 {
+            continue
+        }
+        pkg.Percent = percentOf(pkg.Documented, pkg.Total)
+        cov.Packages = append(cov.Packages, pkg)
+        cov.Total += pkg.Total
+        cov.Documented += pkg.Documented
+    }
+
+    cov.Percent = percentOf(cov.Documented, cov.Total)
+    return cov
+}
+
+// percentOf calcula a porcentagem de part sobre total (100 quando não há símbolos)
+func percentOf(part, total int) float64 {
+    if total == 0 {
+        return 100
+    }
+    return float64(part) * 100 / float64(total)
+}
+
+// RuleDocCoverage é a regra do diagnóstico de cobertura de documentação abaixo do mínimo
+const RuleDocCoverage = "doc-coverage"
+
+// CheckDocCoverage gera um diagnóstico de erro quando a cobertura fica abaixo do mínimo
+func CheckDocCoverage(cov *DocCoverage, min float64) []analysis.Diagnostic {
+    if cov == nil || min <= 0 || cov.Percent >= min {
+        return nil
+    }
+    return []analysis.Diagnostic{{
+        Severity: analysis.SeverityError,
+        Rule:     RuleDocCoverage,
+        Message:  fmt.Sprintf("cobertura de documentação %.1f%% abaixo do mínimo %.1f%% (%d símbolos sem documentação)", cov.Percent, min, len(cov.Undocumented)),
+    }}
+}
+
+// Badge retorna um badge Markdown (shields.io) com a cobertura de documentação
+func (c *DocCoverage) Badge() string {
+    color := "red"
+    switch {
+    case c.Percent >= 90:
+        color = "brightgreen"
+    case c.Percent >= 75:
+        color = "green"
+    case c.Percent >= 50:
+        color = "yellow"
+    }
+    label := url.PathEscape(fmt.Sprintf("%.0f%%", c.Percent))
+    return fmt.Sprintf("![doc coverage](https://img.shields.io/badge/doc%%20coverage-%s-%s)", label, color)
+}
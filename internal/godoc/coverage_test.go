@@ -0,0 +1,136 @@
+package godoc_test
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestDocCoverage(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "store/store.go": `package store
+
+// Store guarda registros
+type Store struct {
+    // Name identifica o store
+    Name string
+    Size int
+    cache map[string]string
+}
+
+// Get busca um registro
+func (s *Store) Get(id string) string { return "" }
+
+func (s *Store) Put(id string) {}
+
+func helper() {}
+`,
+        "store/consts.go": `package store
+
+const Limit = 10
+`,
+        "util/util.go": `package util
+
+// Version é a versão da biblioteca
+var Version = "1"
+`,
+    })
+
+    cfg := config.GolangConfig{
+        Paths:       []string{root},
+        DocCoverage: config.DocCoverageConfig{Enabled: true},
+    }
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    cov := doc.DocCoverage
+    if cov == nil {
+        t.Fatal("cobertura não calculada")
+    }
+
+    // Store, Name, Size, Get, Put e Limit em store; Version em util
+    if cov.Total != 7 || cov.Documented != 4 {
+        t.Errorf("total = %d/%d; want 4/7", cov.Documented, cov.Total)
+    }
+
+    packages := make(map[string]string)
+    for _, p := range cov.Packages {
+        packages[p.Name] = groupCounts(p)
+    }
+    if packages["example.com/app/store"] != "3/6" || packages["example.com/app/util"] != "1/1" {
+        t.Errorf("pacotes = %v", packages)
+    }
+
+    files := make(map[string]string)
+    for _, f := range cov.Files {
+        files[filepath.Base(f.Name)] = groupCounts(f)
+    }
+    if files["store.go"] != "3/5" || files["consts.go"] != "0/1" || files["util.go"] != "1/1" {
+        t.Errorf("arquivos = %v", files)
+    }
+
+    var undocumented []string
+    for _, s := range cov.Undocumented {
+        undocumented = append(undocumented, s.Kind+":"+s.Name)
+    }
+    got := strings.Join(undocumented, " ")
+    for _, want := range []string{"field:Store.Size", "method:Store.Put", "const:Limit"} {
+        if !strings.Contains(got, want) {
+            t.Errorf("sem documentação = %q; want %s", got, want)
+        }
+    }
+    if len(undocumented) != 3 {
+        t.Errorf("sem documentação = %q; want 3 símbolos", got)
+    }
+}
+
+func TestCheckDocCoverage(t *testing.T) {
+    cov := &godoc.DocCoverage{Total: 4, Documented: 3, Percent: 75, Undocumented: make([]godoc.UndocumentedSymbol, 1)}
+
+    if d := godoc.CheckDocCoverage(cov, 0); d != nil {
+        t.Errorf("sem mínimo = %v; want nenhum diagnóstico", d)
+    }
+    if d := godoc.CheckDocCoverage(cov, 75); d != nil {
+        t.Errorf("no mínimo = %v; want nenhum diagnóstico", d)
+    }
+    d := godoc.CheckDocCoverage(cov, 80)
+    if len(d) != 1 || d[0].Rule != godoc.RuleDocCoverage || d[0].Severity != analysis.SeverityError || !analysis.HasErrors(d) {
+        t.Fatalf("abaixo do mínimo = %+v", d)
+    }
+    if !strings.Contains(d[0].Message, "75.0%") || !strings.Contains(d[0].Message, "80.0%") {
+        t.Errorf("mensagem = %q", d[0].Message)
+    }
+    if d := godoc.CheckDocCoverage(nil, 80); d != nil {
+        t.Errorf("sem cobertura = %v; want nenhum diagnóstico", d)
+    }
+}
+
+func TestDocCoverageBadge(t *testing.T) {
+    cases := []struct {
+        percent float64
+        want    string
+    }{
+        {95, "doc%20coverage-95%25-brightgreen"},
+        {80, "-80%25-green"},
+        {50, "-50%25-yellow"},
+        {12.4, "-12%25-red"},
+    }
+    for _, tc := range cases {
+        badge := (&godoc.DocCoverage{Percent: tc.percent}).Badge()
+        if !strings.HasPrefix(badge, "![doc coverage](https://img.shields.io/badge/") || !strings.Contains(badge, tc.want) {
+            t.Errorf("Badge(%v) = %q; want %q", tc.percent, badge, tc.want)
+        }
+    }
+}
+
+// groupCounts formata os símbolos documentados sobre o total de um grupo
+func groupCounts(g godoc.CoverageGroup) string {
+    return strconv.Itoa(g.Documented) + "/" + strconv.Itoa(g.Total)
+}
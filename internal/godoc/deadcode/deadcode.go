@@ -0,0 +1,368 @@
+// Package deadcode encontra as declarações que nenhum código do módulo alcança
+package deadcode
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Symbol representa uma declaração que nenhum código do módulo alcança
+type Symbol struct {
+    Package  string `json:"package"  yaml:"package"`
+    Name     string `json:"name"     yaml:"name"` // Func ou Tipo.Metodo
+    Kind     string `json:"kind"     yaml:"kind"` // function, method, type, const, var
+    Exported bool   `json:"exported" yaml:"exported"` // exportado em pacote internal ou main
+    File     string `json:"file"     yaml:"file"`
+    Line     int    `json:"line"     yaml:"line"`
+}
+
+// deadCodeDirective suprime o aviso de código não utilizado de uma declaração
+// (no comentário de documentação ou na mesma linha): //aimap:ignore dead-code
+const deadCodeDirective = "aimap:ignore"
+
+// deadNode representa uma declaração de nível de pacote no grafo de referências
+type deadNode struct {
+    obj       types.Object
+    pkg       *analysis.Package
+    name      string
+    kind      string
+    pos       token.Pos
+    recv      types.Object   // tipo receptor, para métodos
+    refs      []types.Object // objetos referenciados pela declaração
+    iface     []string       // métodos chamados via interface
+    root      bool
+    candidate bool
+}
+
+// Build marca as declarações alcançáveis a partir das raízes (símbolos exportados
+// de pacotes importáveis, main, init, inicializações de variáveis, símbolos citados
+// nos testes e diretivas) e retorna as demais. Métodos exportados são alcançados junto
+// com o tipo receptor, pois podem ser chamados por interfaces de outros módulos ou por reflexão.
+// Cada símbolo também é reportado como diagnóstico dead-code
+func Build(pass *analysis.Pass) ([]Symbol, []analysis.Diagnostic) {
+    nodes := make(map[types.Object]*deadNode)
+    var all []*deadNode
+    startup := &deadNode{root: true} // referências executadas na inicialização dos pacotes
+    add := func(n *deadNode) {
+        if n.obj == nil || n.obj.Name() == "_" {
+            return
+        }
+        nodes[n.obj] = n
+        all = append(all, n)
+    }
+
+    testRefs := testReferences(pass)
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil {
+            continue
+        }
+        restricted := pkg.Name == "main" || analysis.IsInternalPath(pkg.ImportPath)
+        suppressed := suppressedLines(pass.Fset, pkg)
+        isRoot := func(obj types.Object, doc *ast.CommentGroup) bool {
+            if (obj.Exported() && !restricted) || testRefs[obj.Pos()] {
+                return true
+            }
+            pos := pass.Fset.Position(obj.Pos())
+            if suppressed[fmt.Sprintf("%s:%d", pos.Filename, pos.Line)] {
+                return true
+            }
+            if doc != nil {
+                for _, c := range doc.List {
+                    if strings.HasPrefix(c.Text, "//export ") || strings.HasPrefix(c.Text, "//go:linkname ") || isDeadCodeDirective(c.Text) {
+                        return true
+                    }
+                }
+            }
+            return false
+        }
+
+        for _, file := range pkg.Files {
+            for _, decl := range file.Decls {
+                switch d := decl.(type) {
+                case *ast.FuncDecl:
+                    obj, _ := pkg.Info.Defs[d.Name].(*types.Func)
+                    if obj == nil {
+                        continue
+                    }
+                    n := &deadNode{obj: obj, pkg: pkg, name: obj.Name(), kind: "function", pos: d.Name.Pos()}
+                    if d.Recv != nil {
+                        n.kind = "method"
+                        if recv := analysis.ReceiverName(obj); recv != "" {
+                            n.name = recv + "." + obj.Name()
+                            n.recv = pkg.Types.Scope().Lookup(recv)
+                        }
+                    } else if obj.Name() == "init" || (pkg.Name == "main" && obj.Name() == "main") {
+                        n.root = true
+                    }
+                    n.refs, n.iface = deadRefs(pkg, d.Type)
+                    if d.Body != nil {
+                        refs, iface := deadRefs(pkg, d.Body)
+                        n.refs, n.iface = append(n.refs, refs...), append(n.iface, iface...)
+                    }
+                    if n.kind == "function" {
+                        n.root = n.root || isRoot(obj, d.Doc)
+                    } else {
+                        // Métodos exportados dependem apenas do tipo receptor
+                        n.root = !obj.Exported() && isRoot(obj, d.Doc)
+                    }
+                    n.candidate = !obj.Exported() || n.kind == "function"
+                    if obj.Name() == "init" {
+                        startup.refs = append(startup.refs, n.refs...)
+                        startup.iface = append(startup.iface, n.iface...)
+                        continue
+                    }
+                    add(n)
+                case *ast.GenDecl:
+                    for _, spec := range d.Specs {
+                        switch s := spec.(type) {
+                        case *ast.TypeSpec:
+                            obj := pkg.Info.Defs[s.Name]
+                            if obj == nil {
+                                continue
+                            }
+                            n := &deadNode{obj: obj, pkg: pkg, name: obj.Name(), kind: "type", pos: s.Name.Pos(), candidate: true}
+                            n.refs, n.iface = deadRefs(pkg, s)
+                            n.root = isRoot(obj, declDoc(d, s.Doc))
+                            add(n)
+                        case *ast.ValueSpec:
+                            kind := "var"
+                            if d.Tok == token.CONST {
+                                kind = "const"
+                            }
+                            refs, iface := deadRefs(pkg, s)
+                            if kind == "var" && (hasCall(s) || hasBlank(s)) {
+                                // Inicializações com chamadas e "var _ I = ..." sempre executam
+                                startup.refs = append(startup.refs, refs...)
+                                startup.iface = append(startup.iface, iface...)
+                            }
+                            for _, name := range s.Names {
+                                obj := pkg.Info.Defs[name]
+                                if obj == nil {
+                                    continue
+                                }
+                                n := &deadNode{obj: obj, pkg: pkg, name: obj.Name(), kind: kind, pos: name.Pos(), refs: refs, iface: iface, candidate: true}
+                                n.root = isRoot(obj, declDoc(d, s.Doc))
+                                add(n)
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    }
+
+    // Propagação a partir das raízes
+    methods := make(map[types.Object][]*deadNode)
+    for _, n := range all {
+        if n.kind == "method" && n.recv != nil {
+            methods[n.recv] = append(methods[n.recv], n)
+        }
+    }
+    reached := make(map[*deadNode]bool)
+    ifaceNames := make(map[string]bool)
+    var queue []*deadNode
+    mark := func(n *deadNode) {
+        if n != nil && !reached[n] {
+            reached[n] = true
+            queue = append(queue, n)
+        }
+    }
+    mark(startup)
+    for _, n := range all {
+        if n.root {
+            mark(n)
+        }
+    }
+    for len(queue) > 0 {
+        n := queue[0]
+        queue = queue[1:]
+        for _, ref := range n.refs {
+            mark(nodes[ref])
+        }
+        for _, name := range n.iface {
+            if ifaceNames[name] {
+                continue
+            }
+            ifaceNames[name] = true
+            for recv, list := range methods {
+                if reached[nodes[recv]] {
+                    for _, m := range list {
+                        if m.obj.Name() == name {
+                            mark(m)
+                        }
+                    }
+                }
+            }
+        }
+        if n.kind == "type" {
+            for _, m := range methods[n.obj] {
+                if m.obj.Exported() || ifaceNames[m.obj.Name()] {
+                    mark(m)
+                }
+            }
+        }
+    }
+
+    var dead []Symbol
+    for _, n := range all {
+        if reached[n] || !n.candidate {
+            continue
+        }
+        if n.recv != nil && !reached[nodes[n.recv]] {
+            continue // o tipo receptor já é reportado
+        }
+        pos := pass.Fset.Position(n.pos)
+        dead = append(dead, Symbol{
+            Package:  n.pkg.ImportPath,
+            Name:     n.name,
+            Kind:     n.kind,
+            Exported: n.obj.Exported(),
+            File:     pos.Filename,
+            Line:     pos.Line,
+        })
+    }
+    sort.SliceStable(dead, func(i, j int) bool {
+        if dead[i].File != dead[j].File {
+            return dead[i].File < dead[j].File
+        }
+        return dead[i].Line < dead[j].Line
+    })
+
+    var diagnostics []analysis.Diagnostic
+    for _, d := range dead {
+        label := deadKindLabel[d.Kind]
+        message := fmt.Sprintf("%s %s não é utilizad%s", label[0], d.Name, label[1])
+        if d.Exported {
+            message = fmt.Sprintf("%s exportad%s %s não é utilizad%s no módulo", label[0], label[1], d.Name, label[1])
+        }
+        diagnostics = append(diagnostics, analysis.Diagnostic{
+            Severity: analysis.SeverityWarning,
+            Rule:     "dead-code",
+            Message:  message,
+            File:     d.File,
+            Line:     d.Line,
+        })
+    }
+    return dead, diagnostics
+}
+
+// deadKindLabel nomeia os tipos de declaração (e a terminação de gênero) nas mensagens de diagnóstico
+var deadKindLabel = map[string][2]string{
+    "function": {"função", "a"},
+    "method":   {"método", "o"},
+    "type":     {"tipo", "o"},
+    "const":    {"constante", "a"},
+    "var":      {"variável", "a"},
+}
+
+// deadRefs coleta os objetos usados em um nó e os nomes dos métodos chamados via interface
+func deadRefs(pkg *analysis.Package, node ast.Node) ([]types.Object, []string) {
+    var refs []types.Object
+    var iface []string
+    ast.Inspect(node, func(n ast.Node) bool {
+        id, ok := n.(*ast.Ident)
+        if !ok {
+            return true
+        }
+        switch obj := pkg.Info.Uses[id].(type) {
+        case *types.Func:
+            if sig, ok := obj.Type().(*types.Signature); ok && sig.Recv() != nil && types.IsInterface(sig.Recv().Type()) {
+                iface = append(iface, obj.Name())
+            }
+            refs = append(refs, obj.Origin())
+        case *types.Var:
+            refs = append(refs, obj.Origin())
+        case types.Object:
+            refs = append(refs, obj)
+        }
+        return true
+    })
+    return refs, iface
+}
+
+// declDoc retorna o comentário da especificação ou, na falta dele, o da declaração
+func declDoc(decl *ast.GenDecl, doc *ast.CommentGroup) *ast.CommentGroup {
+    if doc != nil {
+        return doc
+    }
+    return decl.Doc
+}
+
+// hasCall informa se a inicialização de uma variável chama alguma função
+func hasCall(spec *ast.ValueSpec) bool {
+    found := false
+    for _, value := range spec.Values {
+        ast.Inspect(value, func(n ast.Node) bool {
+            if _, ok := n.(*ast.CallExpr); ok {
+                found = true
+            }
+            return !found
+        })
+    }
+    return found
+}
+
+// hasBlank informa se a especificação declara o identificador _
+func hasBlank(spec *ast.ValueSpec) bool {
+    for _, name := range spec.Names {
+        if name.Name == "_" {
+            return true
+        }
+    }
+    return false
+}
+
+// isDeadCodeDirective reconhece //aimap:ignore e //aimap:ignore dead-code
+func isDeadCodeDirective(comment string) bool {
+    text := strings.TrimSpace(strings.TrimPrefix(comment, "//"))
+    if !strings.HasPrefix(text, deadCodeDirective) {
+        return false
+    }
+    rules := strings.Fields(strings.TrimPrefix(text, deadCodeDirective))
+    return len(rules) == 0 || analysis.ContainsString(rules, "dead-code")
+}
+
+// suppressedLines retorna as linhas (arquivo:linha) que terminam com a diretiva de supressão
+func suppressedLines(fset *token.FileSet, pkg *analysis.Package) map[string]bool {
+    lines := make(map[string]bool)
+    for _, file := range pkg.Files {
+        for _, group := range file.Comments {
+            for _, c := range group.List {
+                if isDeadCodeDirective(c.Text) {
+                    pos := fset.Position(c.Pos())
+                    lines[fmt.Sprintf("%s:%d", pos.Filename, pos.Line)] = true
+                }
+            }
+        }
+    }
+    return lines
+}
+
+// testReferences coleta as posições das declarações citadas nos testes de todos os
+// pacotes, inclusive helpers usados apenas por testes de outros pacotes. Nos testes do
+// próprio pacote os objetos são cópias, identificadas pela mesma posição
+func testReferences(pass *analysis.Pass) map[token.Pos]bool {
+    refs := make(map[token.Pos]bool)
+    for _, pkg := range pass.Packages {
+        if pkg.TestInfo == nil {
+            continue
+        }
+        for _, file := range pkg.TestFiles {
+            ast.Inspect(file, func(n ast.Node) bool {
+                if id, ok := n.(*ast.Ident); ok {
+                    if obj := pkg.TestInfo.Uses[id]; obj != nil {
+                        refs[obj.Pos()] = true
+                    }
+                }
+                return true
+            })
+        }
+    }
+    return refs
+}
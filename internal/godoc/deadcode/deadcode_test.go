@@ -0,0 +1,105 @@
+package deadcode_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/deadcode"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestDeadCode(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "internal/store/store.go": `package store
+
+type Store struct{}
+
+func New() *Store { return &Store{} }
+
+// Unused não é chamado por nenhum pacote do módulo
+func Unused() {}
+
+func (s *Store) Get() string { return s.key() }
+
+func (s *Store) key() string { return prefix }
+
+func (s *Store) stale() {}
+
+const prefix = "k"
+
+// helper só é chamado por orphan, que também não é usado
+func helper() {}
+
+func orphan() { helper() }
+
+//aimap:ignore dead-code
+func kept() {}
+
+var legacy = 1 //aimap:ignore dead-code
+`,
+        "main.go": `package main
+
+import "example.com/app/internal/store"
+
+func main() {
+    println(store.New().Get())
+}
+`,
+    })
+
+    symbols, diagnostics := deadcode.Build(pass)
+
+    var got []string
+    for _, d := range symbols {
+        got = append(got, d.Kind+" "+d.Name)
+    }
+    want := "function Unused, method Store.stale, function helper, function orphan"
+    if strings.Join(got, ", ") != want {
+        t.Errorf("código não utilizado = %v; want %s", got, want)
+    }
+    if len(diagnostics) != 4 || diagnostics[0].Rule != "dead-code" {
+        t.Errorf("diagnósticos = %+v; want 4 dead-code", diagnostics)
+    }
+}
+
+func TestDeadCodeTestReferences(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "internal/store/store.go": `package store
+
+func parse(s string) string { return s }
+
+func unused() {}
+`,
+        "internal/store/store_test.go": `package store
+
+import "testing"
+
+func TestParse(t *testing.T) { _ = parse("x") }
+`,
+        // Helper usado apenas pelos testes de outro pacote
+        "internal/storetest/storetest.go": `package storetest
+
+func Fixture() string { return "x" }
+`,
+        "internal/store/fixture_test.go": `package store_test
+
+import (
+    "testing"
+
+    "example.com/app/internal/storetest"
+)
+
+func TestFixture(t *testing.T) { _ = storetest.Fixture() }
+`,
+    })
+
+    symbols, _ := deadcode.Build(pass)
+
+    var got []string
+    for _, d := range symbols {
+        got = append(got, d.Kind+" "+d.Name)
+    }
+    if strings.Join(got, ", ") != "function unused" {
+        t.Errorf("código não utilizado = %v; want apenas unused", got)
+    }
+}
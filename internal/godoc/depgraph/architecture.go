@@ -0,0 +1,96 @@
+package depgraph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// CheckArchitecture valida o grafo de dependências contra as regras declaradas
+// no bloco architecture da configuração e reporta ciclos de imports
+func CheckArchitecture(graph *Graph, cfg config.ArchitectureConfig) []analysis.Diagnostic {
+    if graph == nil {
+        return nil
+    }
+
+    var diagnostics []analysis.Diagnostic
+    for _, cycle := range graph.Cycles {
+        rel := make([]string, len(cycle))
+        for i, pkg := range cycle {
+            rel[i] = graph.RelPath(pkg)
+        }
+        file, line := "", 0
+        for _, e := range graph.Edges {
+            if e.From == cycle[0] && analysis.ContainsString(cycle, e.To) {
+                file, line = e.File, e.Line
+                break
+            }
+        }
+        diagnostics = append(diagnostics, analysis.Diagnostic{
+            Severity: analysis.SeverityError,
+            Rule:     "import-cycle",
+            Message:  "ciclo de imports entre " + strings.Join(rel, ", "),
+            File:     file,
+            Line:     line,
+        })
+    }
+
+    for _, e := range graph.Edges {
+        from, to := graph.RelPath(e.From), graph.RelPath(e.To)
+        for _, rule := range cfg.Rules {
+            if !matchPackagePattern(graph, rule.Package, from) {
+                continue
+            }
+            if pattern := firstMatch(graph, rule.Deny, to); pattern != "" {
+                diagnostics = append(diagnostics, analysis.Diagnostic{
+                    Severity: analysis.SeverityError,
+                    Rule:     "forbidden-dependency",
+                    Message:  fmt.Sprintf("%s não pode importar %s (regra deny %q)", from, to, pattern),
+                    File:     e.File,
+                    Line:     e.Line,
+                })
+            }
+            if len(rule.Allow) > 0 && firstMatch(graph, rule.Allow, to) == "" {
+                diagnostics = append(diagnostics, analysis.Diagnostic{
+                    Severity: analysis.SeverityError,
+                    Rule:     "unlisted-dependency",
+                    Message:  fmt.Sprintf("%s importa %s, que não está na lista allow de %q", from, to, rule.Package),
+                    File:     e.File,
+                    Line:     e.Line,
+                })
+            }
+        }
+    }
+
+    return diagnostics
+}
+
+// firstMatch retorna o primeiro padrão da lista que corresponde ao pacote
+func firstMatch(graph *Graph, patterns []string, pkg string) string {
+    for _, pattern := range patterns {
+        if matchPackagePattern(graph, pattern, pkg) {
+            return pattern
+        }
+    }
+    return ""
+}
+
+// matchPackagePattern compara um caminho relativo ao módulo com um padrão.
+// O padrão pode ser relativo ao módulo ou um import path completo, e o sufixo
+// "/..." inclui todos os subpacotes (como nos padrões do go list)
+func matchPackagePattern(graph *Graph, pattern, pkg string) bool {
+    pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "./")
+    if graph.Module != "" && (pattern == graph.Module || strings.HasPrefix(pattern, graph.Module+"/")) {
+        pattern = graph.RelPath(pattern)
+    }
+    if pattern == "..." {
+        return true
+    }
+    if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+        return pkg == prefix || strings.HasPrefix(pkg, prefix+"/")
+    }
+    return pkg == pattern
+}
+
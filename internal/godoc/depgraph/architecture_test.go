@@ -0,0 +1,70 @@
+package depgraph
+
+import (
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+)
+
+func TestCheckArchitecture(t *testing.T) {
+    graph := &Graph{
+        Module: "example.com/app",
+        Edges: []Edge{
+            {From: "example.com/app/internal/output", To: "example.com/app/internal/postgres", File: "output.go", Line: 5},
+            {From: "example.com/app/internal/output", To: "example.com/app/internal/godoc", File: "output.go", Line: 6},
+            {From: "example.com/app/internal/a", To: "example.com/app/internal/b"},
+            {From: "example.com/app/internal/b", To: "example.com/app/internal/a"},
+        },
+    }
+    graph.Cycles = graph.findCycles([]string{"example.com/app/internal/a", "example.com/app/internal/b"})
+
+    cfg := config.ArchitectureConfig{
+        Rules: []config.ArchitectureRule{
+            {Package: "internal/output/...", Deny: []string{"internal/postgres"}},
+            {Package: "internal/output", Allow: []string{"internal/postgres"}},
+        },
+    }
+
+    rules := make(map[string]int)
+    for _, d := range CheckArchitecture(graph, cfg) {
+        rules[d.Rule]++
+    }
+
+    cases := []struct {
+        rule  string
+        count int
+    }{
+        {"import-cycle", 1},
+        {"forbidden-dependency", 1},
+        {"unlisted-dependency", 1},
+    }
+    for _, tc := range cases {
+        if rules[tc.rule] != tc.count {
+            t.Errorf("diagnósticos %q = %d; want %d", tc.rule, rules[tc.rule], tc.count)
+        }
+    }
+}
+
+func TestMatchPackagePattern(t *testing.T) {
+    graph := &Graph{Module: "example.com/app"}
+    cases := []struct {
+        pattern string
+        pkg     string
+        want    bool
+    }{
+        {"internal/output", "internal/output", true},
+        {"./internal/output", "internal/output", true},
+        {"internal/output/...", "internal/output/html", true},
+        {"internal/output/...", "internal/outputs", false},
+        {"example.com/app", ".", true},
+        {"example.com/app/...", "internal/godoc", true},
+        {"example.com/app/internal/godoc", "internal/godoc", true},
+        {"example.com/apps/internal/godoc", "internal/godoc", false},
+        {"example.com/application/...", "internal/godoc", false},
+    }
+    for _, tc := range cases {
+        if got := matchPackagePattern(graph, tc.pattern, tc.pkg); got != tc.want {
+            t.Errorf("matchPackagePattern(%q, %q) = %v; want %v", tc.pattern, tc.pkg, got, tc.want)
+        }
+    }
+}
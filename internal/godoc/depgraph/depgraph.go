@@ -0,0 +1,237 @@
+// Package depgraph monta o grafo de dependências entre os pacotes do módulo e
+// valida as regras de arquitetura sobre ele
+package depgraph
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Graph representa o grafo de dependências entre pacotes internos do módulo
+type Graph struct {
+    Module   string           `json:"module"   yaml:"module"`
+    Packages []Node    `json:"packages" yaml:"packages"`
+    Edges    []Edge `json:"edges"    yaml:"edges"`
+    Cycles   [][]string       `json:"cycles,omitempty" yaml:"cycles,omitempty"`
+}
+
+// Node representa um pacote interno e sua camada no grafo
+type Node struct {
+    ImportPath string `json:"import_path" yaml:"import_path"`
+    RelPath    string `json:"rel_path"    yaml:"rel_path"`
+    Layer      int    `json:"layer"       yaml:"layer"`
+}
+
+// Edge representa um import de um pacote interno por outro
+type Edge struct {
+    From string `json:"from" yaml:"from"`
+    To   string `json:"to"   yaml:"to"`
+    File string `json:"file" yaml:"file"` // primeiro arquivo onde o import aparece
+    Line int    `json:"line" yaml:"line"`
+}
+
+// Build agrega os imports dos arquivos em um grafo de pacotes do módulo
+func Build(pass *analysis.Pass) *Graph {
+    graph := &Graph{}
+    known := make(map[string]bool)
+    var order []string
+
+    for _, dir := range pass.Dirs {
+        if graph.Module == "" {
+            graph.Module = dir.Module
+        }
+        if !known[dir.ImportPath] {
+            known[dir.ImportPath] = true
+            order = append(order, dir.ImportPath)
+        }
+    }
+
+    seen := make(map[string]bool)
+    for _, dir := range pass.Dirs {
+        from := dir.ImportPath
+        for _, file := range dir.Files {
+            for _, imp := range file.Imports {
+                to, err := strconv.Unquote(imp.Path.Value)
+                if err != nil || !known[to] || to == from {
+                    continue
+                }
+                key := from + "->" + to
+                if seen[key] {
+                    continue
+                }
+                seen[key] = true
+                pos := pass.Fset.Position(imp.Pos())
+                graph.Edges = append(graph.Edges, Edge{
+                    From: from,
+                    To:   to,
+                    File: pos.Filename,
+                    Line: pos.Line,
+                })
+            }
+        }
+    }
+
+    layers := graph.computeLayers(order)
+    for _, path := range order {
+        graph.Packages = append(graph.Packages, Node{
+            ImportPath: path,
+            RelPath:    graph.RelPath(path),
+            Layer:      layers[path],
+        })
+    }
+    graph.Cycles = graph.findCycles(order)
+
+    return graph
+}
+
+// RelPath retorna o caminho do pacote relativo ao módulo ("." para a raiz)
+func (g *Graph) RelPath(importPath string) string {
+    if g.Module == "" {
+        return importPath
+    }
+    if importPath == g.Module {
+        return "."
+    }
+    return strings.TrimPrefix(importPath, g.Module+"/")
+}
+
+// adjacency retorna as dependências de cada pacote
+func (g *Graph) adjacency() map[string][]string {
+    adj := make(map[string][]string)
+    for _, e := range g.Edges {
+        adj[e.From] = append(adj[e.From], e.To)
+    }
+    return adj
+}
+
+// computeLayers atribui camadas: pacotes sem dependências internas ficam na camada 0
+// e cada pacote fica uma camada acima da sua dependência mais alta
+func (g *Graph) computeLayers(order []string) map[string]int {
+    adj := g.adjacency()
+    layers := make(map[string]int)
+    visiting := make(map[string]bool)
+
+    var visit func(pkg string) int
+    visit = func(pkg string) int {
+        if layer, ok := layers[pkg]; ok {
+            return layer
+        }
+        if visiting[pkg] {
+            // Ciclo: a aresta de retorno não conta para a camada
+            return -1
+        }
+        visiting[pkg] = true
+        layer := 0
+        for _, dep := range adj[pkg] {
+            if l := visit(dep) + 1; l > layer {
+                layer = l
+            }
+        }
+        visiting[pkg] = false
+        layers[pkg] = layer
+        return layer
+    }
+
+    for _, pkg := range order {
+        visit(pkg)
+    }
+    return layers
+}
+
+// findCycles detecta ciclos de imports (componentes fortemente conexos, algoritmo de Tarjan)
+func (g *Graph) findCycles(order []string) [][]string {
+    adj := g.adjacency()
+    index := make(map[string]int)
+    lowlink := make(map[string]int)
+    onStack := make(map[string]bool)
+    var stack []string
+    var cycles [][]string
+    counter := 0
+
+    var strongConnect func(v string)
+    strongConnect = func(v string) {
+        index[v] = counter
+        lowlink[v] = counter
+        counter++
+        stack = append(stack, v)
+        onStack[v] = true
+
+        for _, w := range adj[v] {
+            if _, ok := index[w]; !ok {
+                strongConnect(w)
+                lowlink[v] = min(lowlink[v], lowlink[w])
+            } else if onStack[w] {
+                lowlink[v] = min(lowlink[v], index[w])
+            }
+        }
+
+        if lowlink[v] == index[v] {
+            var component []string
+            for {
+                w := stack[len(stack)-1]
+                stack = stack[:len(stack)-1]
+                onStack[w] = false
+                component = append(component, w)
+                if w == v {
+                    break
+                }
+            }
+            if len(component) > 1 {
+                sort.Strings(component)
+                cycles = append(cycles, component)
+            }
+        }
+    }
+
+    for _, pkg := range order {
+        if _, ok := index[pkg]; !ok {
+            strongConnect(pkg)
+        }
+    }
+    return cycles
+}
+
+// Mermaid renderiza o grafo em camadas, da camada mais alta (binários) para a mais baixa
+func (g *Graph) Mermaid() string {
+    var sb strings.Builder
+    sb.WriteString("flowchart TB\n")
+
+    ids := make(map[string]string)
+    byLayer := make(map[int][]Node)
+    maxLayer := 0
+    for i, p := range g.Packages {
+        ids[p.ImportPath] = fmt.Sprintf("p%d", i)
+        byLayer[p.Layer] = append(byLayer[p.Layer], p)
+        maxLayer = max(maxLayer, p.Layer)
+    }
+
+    for layer := maxLayer; layer >= 0; layer-- {
+        if len(byLayer[layer]) == 0 {
+            continue
+        }
+        sb.WriteString(fmt.Sprintf("    subgraph layer%d[\"Camada %d\"]\n", layer, layer))
+        for _, p := range byLayer[layer] {
+            sb.WriteString(fmt.Sprintf("        %s[\"%s\"]\n", ids[p.ImportPath], p.RelPath))
+        }
+        sb.WriteString("    end\n")
+    }
+
+    inCycle := make(map[string]bool)
+    for _, cycle := range g.Cycles {
+        for _, pkg := range cycle {
+            inCycle[pkg] = true
+        }
+    }
+    for _, e := range g.Edges {
+        arrow := "-->"
+        if inCycle[e.From] && inCycle[e.To] {
+            arrow = "-. ciclo .->"
+        }
+        sb.WriteString(fmt.Sprintf("    %s %s %s\n", ids[e.From], arrow, ids[e.To]))
+    }
+    return sb.String()
+}
@@ -0,0 +1,46 @@
+package godoc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// metricsDiagnostics gera diagnósticos de aviso para os hotspots encontrados
+func (p *ProjectDoc) metricsDiagnostics() []analysis.Diagnostic {
+    var diagnostics []analysis.Diagnostic
+    add := func(name, file string, line int, hotspots []string) {
+        if len(hotspots) == 0 {
+            return
+        }
+        diagnostics = append(diagnostics, analysis.Diagnostic{
+            Severity: analysis.SeverityWarning,
+            Rule:     "complexity",
+            Message:  fmt.Sprintf("%s excede limites: %s", name, strings.Join(hotspots, ", ")),
+            File:     file,
+            Line:     line,
+        })
+    }
+
+    for _, dir := range p.Directories {
+        for _, file := range dir.Files {
+            for _, fn := range file.Functions {
+                if fn.Metrics != nil {
+                    add(fn.Name, fn.File, fn.Line, fn.Metrics.Hotspots)
+                }
+            }
+            for _, str := range file.Structs {
+                for _, m := range str.Methods {
+                    if m.Metrics != nil {
+                        add(str.Name+"."+m.Name, m.File, m.Line, m.Metrics.Hotspots)
+                    }
+                }
+            }
+        }
+    }
+    for _, pm := range p.Metrics {
+        add("pacote "+pm.ImportPath, pm.Path, 0, pm.Hotspots)
+    }
+    return diagnostics
+}
@@ -0,0 +1,64 @@
+package godoc_test
+
+import (
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestMetricsDiagnostics(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "calc/calc.go": `package calc
+
+type Calc struct{}
+
+func (c *Calc) Apply(a, b, op int) int {
+    if op > 0 {
+        return a + b
+    }
+    return a - b
+}
+
+func Sum(a, b int) int { return a + b }
+`,
+    })
+
+    cfg := config.GolangConfig{
+        Paths: []string{root},
+        Metrics: config.MetricsConfig{
+            Enabled:    true,
+            Thresholds: config.MetricsThresholds{Params: 2, Cyclomatic: -1, PackageLOC: 5},
+        },
+    }
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+
+    got := make(map[string]analysis.Diagnostic)
+    for _, d := range doc.Diagnostics {
+        if d.Rule == "complexity" {
+            got[d.Message] = d
+        }
+    }
+    want := []string{
+        "Calc.Apply excede limites: params 3 > 2",
+        "pacote example.com/app/calc excede limites: loc 12 > 5",
+    }
+    for _, message := range want {
+        d, ok := got[message]
+        if !ok {
+            t.Errorf("diagnóstico %q ausente em %v", message, doc.Diagnostics)
+            continue
+        }
+        if d.Severity != analysis.SeverityWarning {
+            t.Errorf("%q: severidade %q; want warning", message, d.Severity)
+        }
+    }
+    if len(got) != len(want) {
+        t.Errorf("diagnósticos = %v; want só %v", doc.Diagnostics, want)
+    }
+}
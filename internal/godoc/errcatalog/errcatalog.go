@@ -0,0 +1,455 @@
+// Package errcatalog cataloga os erros declarados, retornados, envolvidos e verificados por cada pacote
+package errcatalog
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// errorIface é a interface error do universo, usada para identificar tipos de erro
+var errorIface = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// Catalog descreve os erros declarados, retornados e verificados por um pacote
+type Catalog struct {
+    Sentinels []SentinelError `json:"sentinels,omitempty" yaml:"sentinels,omitempty"`
+    Types     []ErrorType     `json:"types,omitempty"     yaml:"types,omitempty"`
+    Returns   []ErrorReturn   `json:"returns,omitempty"   yaml:"returns,omitempty"` // quais funções retornam quais erros
+    Wraps     []ErrorWrap     `json:"wraps,omitempty"     yaml:"wraps,omitempty"`
+    Checks    []ErrorCheck    `json:"checks,omitempty"    yaml:"checks,omitempty"`
+}
+
+// SentinelError representa uma variável de erro do pacote (var ErrX = errors.New(...))
+type SentinelError struct {
+    Name    string `json:"name"              yaml:"name"`
+    Message string `json:"message,omitempty" yaml:"message,omitempty"`
+    Doc     string `json:"doc,omitempty"     yaml:"doc,omitempty"`
+    File    string `json:"file"              yaml:"file"`
+    Line    int    `json:"line"              yaml:"line"`
+}
+
+// ErrorType representa um tipo com método Error() string
+type ErrorType struct {
+    Name     string `json:"name"              yaml:"name"`
+    Receiver string `json:"receiver"          yaml:"receiver"` // T ou *T, conforme o receptor de Error()
+    Message  string `json:"message,omitempty" yaml:"message,omitempty"` // texto ou formato retornado por Error()
+    Doc      string `json:"doc,omitempty"     yaml:"doc,omitempty"`
+    File     string `json:"file"              yaml:"file"`
+    Line     int    `json:"line"              yaml:"line"`
+}
+
+// ErrorReturn representa um erro retornado diretamente por uma função
+type ErrorReturn struct {
+    Function string `json:"function"          yaml:"function"`
+    Kind     string `json:"kind"              yaml:"kind"` // sentinel, type, wrap, new
+    Error    string `json:"error,omitempty"   yaml:"error,omitempty"` // sentinela ou tipo (para wrap, o erro envolvido)
+    Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+    File     string `json:"file"              yaml:"file"`
+    Line     int    `json:"line"              yaml:"line"`
+}
+
+// ErrorWrap representa uma chamada fmt.Errorf com %w
+type ErrorWrap struct {
+    Function string   `json:"function" yaml:"function"`
+    Format   string   `json:"format"   yaml:"format"`
+    Wrapped  []string `json:"wrapped"  yaml:"wrapped"` // expressões passadas para %w
+    File     string   `json:"file"     yaml:"file"`
+    Line     int      `json:"line"     yaml:"line"`
+}
+
+// ErrorCheck representa uma verificação errors.Is ou errors.As
+type ErrorCheck struct {
+    Function string `json:"function" yaml:"function"`
+    Kind     string `json:"kind"     yaml:"kind"` // errors.Is, errors.As
+    Target   string `json:"target"   yaml:"target"`
+    File     string `json:"file"     yaml:"file"`
+    Line     int    `json:"line"     yaml:"line"`
+}
+
+// errorScanner acumula o catálogo de erros de um pacote
+type errorScanner struct {
+    fset      *token.FileSet
+    pkg       *analysis.Package
+    sentinels map[*types.Var]string // sentinelas de todos os pacotes analisados -> mensagem
+    catalog   Catalog
+}
+
+// Build gera o catálogo de erros de cada pacote analisado, indexado pelo diretório
+func Build(pass *analysis.Pass) map[string]*Catalog {
+    result := make(map[string]*Catalog)
+    // Sentinelas são coletadas antes para que retornos de erros de outros
+    // pacotes internos também tenham a mensagem
+    sentinels := make(map[*types.Var]string)
+    declared := make(map[*analysis.Package][]SentinelError)
+    for _, pkg := range pass.Packages {
+        if pkg.Types != nil {
+            declared[pkg] = collectSentinels(pass.Fset, pkg, sentinels)
+        }
+    }
+
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil {
+            continue
+        }
+        s := &errorScanner{fset: pass.Fset, pkg: pkg, sentinels: sentinels}
+        s.catalog.Sentinels = declared[pkg]
+        s.collectErrorTypes()
+        pkg.FuncDecls(func(decl *ast.FuncDecl, obj *types.Func) {
+            if decl.Body != nil {
+                s.scanFunc(decl, obj)
+            }
+        })
+
+        c := s.catalog
+        if len(c.Sentinels)+len(c.Types)+len(c.Returns)+len(c.Wraps)+len(c.Checks) == 0 {
+            continue
+        }
+        result[pkg.Dir] = &c
+    }
+    return result
+}
+
+// collectSentinels lista as variáveis de pacote cujo tipo implementa error
+func collectSentinels(fset *token.FileSet, pkg *analysis.Package, messages map[*types.Var]string) []SentinelError {
+    var result []SentinelError
+    for _, file := range pkg.Files {
+        for _, decl := range file.Decls {
+            gen, ok := decl.(*ast.GenDecl)
+            if !ok || gen.Tok != token.VAR {
+                continue
+            }
+            for _, spec := range gen.Specs {
+                vs := spec.(*ast.ValueSpec)
+                doc := gen.Doc.Text()
+                if vs.Doc != nil {
+                    doc = vs.Doc.Text()
+                } else if vs.Comment != nil && gen.Lparen.IsValid() {
+                    doc = vs.Comment.Text()
+                }
+                for i, name := range vs.Names {
+                    v, ok := pkg.Info.Defs[name].(*types.Var)
+                    if !ok || name.Name == "_" || !isErrorType(v.Type()) {
+                        continue
+                    }
+                    var message string
+                    if len(vs.Values) == len(vs.Names) {
+                        message, _ = errorMessage(pkg.Info, vs.Values[i])
+                    }
+                    messages[v] = message
+                    pos := fset.Position(name.Pos())
+                    result = append(result, SentinelError{
+                        Name:    name.Name,
+                        Message: message,
+                        Doc:     strings.TrimSpace(doc),
+                        File:    pos.Filename,
+                        Line:    pos.Line,
+                    })
+                }
+            }
+        }
+    }
+    return result
+}
+
+// collectErrorTypes lista os tipos do pacote que implementam error (por valor ou ponteiro)
+func (s *errorScanner) collectErrorTypes() {
+    methods := make(map[string]*ast.FuncDecl)
+    docs := make(map[string]string)
+    for _, file := range s.pkg.Files {
+        for _, decl := range file.Decls {
+            switch d := decl.(type) {
+            case *ast.FuncDecl:
+                if d.Recv != nil && d.Name.Name == "Error" {
+                    methods[analysis.ReceiverTypeName(d.Recv.List[0].Type)] = d
+                }
+            case *ast.GenDecl:
+                for _, spec := range d.Specs {
+                    if ts, ok := spec.(*ast.TypeSpec); ok {
+                        doc := d.Doc
+                        if ts.Doc != nil {
+                            doc = ts.Doc
+                        }
+                        docs[ts.Name.Name] = strings.TrimSpace(doc.Text())
+                    }
+                }
+            }
+        }
+    }
+
+    scope := s.pkg.Types.Scope()
+    for _, name := range scope.Names() {
+        tn, ok := scope.Lookup(name).(*types.TypeName)
+        if !ok || tn.IsAlias() || types.IsInterface(tn.Type()) {
+            continue
+        }
+        receiver := ""
+        switch {
+        case types.Implements(tn.Type(), errorIface):
+            receiver = tn.Name()
+        case types.Implements(types.NewPointer(tn.Type()), errorIface):
+            receiver = "*" + tn.Name()
+        default:
+            continue
+        }
+        pos := s.fset.Position(tn.Pos())
+        errType := ErrorType{
+            Name:     tn.Name(),
+            Receiver: receiver,
+            Doc:      docs[tn.Name()],
+            File:     pos.Filename,
+            Line:     pos.Line,
+        }
+        if method := methods[tn.Name()]; method != nil && method.Body != nil {
+            errType.Message = s.errorMethodMessage(method.Body)
+        }
+        s.catalog.Types = append(s.catalog.Types, errType)
+    }
+}
+
+// errorMessage extrai o texto de errors.New("...") ou o formato de fmt.Errorf("...")
+func errorMessage(info *types.Info, expr ast.Expr) (string, bool) {
+    call, ok := ast.Unparen(expr).(*ast.CallExpr)
+    if !ok || len(call.Args) == 0 {
+        return "", false
+    }
+    callee, _ := analysis.ResolveCallee(info, call)
+    if callee == nil || callee.Pkg() == nil {
+        return "", false
+    }
+    switch callee.Pkg().Path() + "." + callee.Name() {
+    case "errors.New", "fmt.Errorf":
+        return analysis.ConstString(info, call.Args[0])
+    }
+    return "", false
+}
+
+// errorMethodMessage retorna o texto ou formato do primeiro return de Error()
+func (s *errorScanner) errorMethodMessage(body *ast.BlockStmt) string {
+    var message string
+    ast.Inspect(body, func(n ast.Node) bool {
+        if message != "" {
+            return false
+        }
+        ret, ok := n.(*ast.ReturnStmt)
+        if !ok || len(ret.Results) != 1 {
+            return true
+        }
+        ast.Inspect(ret.Results[0], func(n ast.Node) bool {
+            if message != "" {
+                return false
+            }
+            if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+                message, _ = strconv.Unquote(lit.Value)
+            }
+            return true
+        })
+        return false
+    })
+    return message
+}
+
+// scanFunc registra os erros retornados, os wraps e as verificações de uma função
+func (s *errorScanner) scanFunc(decl *ast.FuncDecl, obj *types.Func) {
+    function := analysis.LocalFuncName(obj, s.pkg.Types)
+
+    sig := obj.Type().(*types.Signature)
+    returnsError := sig.Results().Len() > 0 && isErrorType(sig.Results().At(sig.Results().Len()-1).Type())
+
+    // Retornos de funções literais pertencem à própria literal
+    var literals []*ast.FuncLit
+    ast.Inspect(decl.Body, func(n ast.Node) bool {
+        switch node := n.(type) {
+        case *ast.FuncLit:
+            literals = append(literals, node)
+        case *ast.ReturnStmt:
+            if returnsError && !insideAny(node, literals) && len(node.Results) == sig.Results().Len() {
+                s.addReturn(function, node.Results[len(node.Results)-1])
+            }
+        case *ast.CallExpr:
+            s.inspectErrorCall(function, node)
+        }
+        return true
+    })
+}
+
+// addReturn classifica a expressão de erro de um return
+func (s *errorScanner) addReturn(function string, expr ast.Expr) {
+    info := s.pkg.Info
+    pos := s.fset.Position(expr.Pos())
+    ret := ErrorReturn{Function: function, File: pos.Filename, Line: pos.Line}
+
+    switch e := ast.Unparen(expr).(type) {
+    case *ast.Ident, *ast.SelectorExpr:
+        name, message, ok := s.sentinelOf(e)
+        if !ok {
+            return
+        }
+        ret.Kind, ret.Error, ret.Message = "sentinel", name, message
+    case *ast.CallExpr:
+        callee, _ := analysis.ResolveCallee(info, e)
+        if callee == nil || callee.Pkg() == nil || len(e.Args) == 0 {
+            return
+        }
+        switch callee.Pkg().Path() + "." + callee.Name() {
+        case "errors.New":
+            ret.Kind = "new"
+            ret.Message, _ = analysis.ConstString(info, e.Args[0])
+        case "fmt.Errorf":
+            ret.Kind = "new"
+            ret.Message, _ = analysis.ConstString(info, e.Args[0])
+            if wrapped := s.wrappedNames(e); len(wrapped) > 0 {
+                ret.Kind = "wrap"
+                ret.Error = strings.Join(wrapped, ", ")
+            }
+        default:
+            return
+        }
+    case *ast.UnaryExpr, *ast.CompositeLit:
+        t := info.TypeOf(e)
+        if t == nil || !isErrorType(t) {
+            return
+        }
+        ret.Kind = "type"
+        ret.Error = types.TypeString(t, types.RelativeTo(s.pkg.Types))
+    default:
+        return
+    }
+    s.catalog.Returns = append(s.catalog.Returns, ret)
+}
+
+// inspectErrorCall registra fmt.Errorf com %w e as verificações errors.Is/As
+func (s *errorScanner) inspectErrorCall(function string, call *ast.CallExpr) {
+    info := s.pkg.Info
+    callee, _ := analysis.ResolveCallee(info, call)
+    if callee == nil || callee.Pkg() == nil {
+        return
+    }
+    pos := s.fset.Position(call.Pos())
+    qualifier := types.RelativeTo(s.pkg.Types)
+
+    switch callee.Pkg().Path() + "." + callee.Name() {
+    case "fmt.Errorf":
+        if len(call.Args) == 0 {
+            return
+        }
+        format, ok := analysis.ConstString(info, call.Args[0])
+        if !ok || !strings.Contains(format, "%w") {
+            return
+        }
+        var wrapped []string
+        for _, i := range wrapArgs(format) {
+            if i+1 < len(call.Args) {
+                wrapped = append(wrapped, types.ExprString(call.Args[i+1]))
+            }
+        }
+        s.catalog.Wraps = append(s.catalog.Wraps, ErrorWrap{
+            Function: function,
+            Format:   format,
+            Wrapped:  wrapped,
+            File:     pos.Filename,
+            Line:     pos.Line,
+        })
+    case "errors.Is", "errors.As":
+        if len(call.Args) != 2 {
+            return
+        }
+        target := types.ExprString(call.Args[1])
+        if callee.Name() == "As" {
+            // errors.As recebe um ponteiro para o destino: documenta o tipo procurado
+            if ptr, ok := info.TypeOf(call.Args[1]).(*types.Pointer); ok {
+                target = types.TypeString(ptr.Elem(), qualifier)
+            }
+        }
+        s.catalog.Checks = append(s.catalog.Checks, ErrorCheck{
+            Function: function,
+            Kind:     "errors." + callee.Name(),
+            Target:   target,
+            File:     pos.Filename,
+            Line:     pos.Line,
+        })
+    }
+}
+
+// sentinelOf identifica uma referência a variável de erro de pacote (interna ou externa)
+func (s *errorScanner) sentinelOf(expr ast.Expr) (string, string, bool) {
+    var id *ast.Ident
+    switch e := expr.(type) {
+    case *ast.Ident:
+        id = e
+    case *ast.SelectorExpr:
+        id = e.Sel
+    }
+    v, ok := s.pkg.Info.Uses[id].(*types.Var)
+    if !ok || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() || !isErrorType(v.Type()) {
+        return "", "", false
+    }
+    name := v.Name()
+    if v.Pkg() != s.pkg.Types {
+        name = v.Pkg().Name() + "." + name
+    }
+    return name, s.sentinels[v], true
+}
+
+// wrappedNames retorna as sentinelas e tipos passados para %w em um fmt.Errorf
+func (s *errorScanner) wrappedNames(call *ast.CallExpr) []string {
+    format, ok := analysis.ConstString(s.pkg.Info, call.Args[0])
+    if !ok {
+        return nil
+    }
+    var names []string
+    for _, i := range wrapArgs(format) {
+        if i+1 >= len(call.Args) {
+            continue
+        }
+        arg := ast.Unparen(call.Args[i+1])
+        if name, _, ok := s.sentinelOf(arg); ok {
+            names = append(names, name)
+        } else {
+            names = append(names, types.ExprString(arg))
+        }
+    }
+    return names
+}
+
+// wrapArgs retorna os índices dos argumentos consumidos por verbos %w no formato
+func wrapArgs(format string) []int {
+    var result []int
+    arg := 0
+    for i := 0; i < len(format); i++ {
+        if format[i] != '%' {
+            continue
+        }
+        i++
+        // Pula flags, largura e precisão até o verbo
+        for i < len(format) && strings.IndexByte("+-# 0123456789.*[]", format[i]) >= 0 {
+            i++
+        }
+        if i >= len(format) || format[i] == '%' {
+            continue
+        }
+        if format[i] == 'w' {
+            result = append(result, arg)
+        }
+        arg++
+    }
+    return result
+}
+
+// isErrorType informa se o tipo implementa error
+func isErrorType(t types.Type) bool {
+    return t != nil && analysis.IsValidType(t) && types.Implements(t, errorIface)
+}
+
+// insideAny informa se o nó está dentro de alguma das funções literais
+func insideAny(node ast.Node, literals []*ast.FuncLit) bool {
+    for _, lit := range literals {
+        if node.Pos() >= lit.Pos() && node.End() <= lit.End() {
+            return true
+        }
+    }
+    return false
+}
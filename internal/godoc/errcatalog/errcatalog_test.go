@@ -0,0 +1,107 @@
+package errcatalog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/errcatalog"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestErrorCatalog(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "store/store.go": `package store
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotFound indica que o registro não existe
+var ErrNotFound = errors.New("registro não encontrado")
+
+type ValidationError struct {
+	Field string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("campo inválido: %s", e.Field)
+}
+
+func Get(id string) (string, error) {
+	if id == "" {
+		return "", &ValidationError{Field: "id"}
+	}
+	if id == "eof" {
+		return "", io.EOF
+	}
+	return "", fmt.Errorf("buscando %s: %w", id, ErrNotFound)
+}
+
+func Exists(id string) bool {
+	_, err := Get(id)
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return false
+	}
+	return !errors.Is(err, ErrNotFound)
+}
+`,
+    })
+
+    catalog := errcatalog.Build(pass)[pass.Packages[0].Dir]
+    if catalog == nil {
+        t.Fatal("catálogo de erros não gerado")
+    }
+
+    if len(catalog.Sentinels) != 1 || catalog.Sentinels[0].Message != "registro não encontrado" {
+        t.Errorf("sentinelas = %+v", catalog.Sentinels)
+    }
+    if len(catalog.Types) != 1 || catalog.Types[0].Receiver != "*ValidationError" || catalog.Types[0].Message != "campo inválido: %s" {
+        t.Errorf("tipos = %+v", catalog.Types)
+    }
+
+    var returns []string
+    for _, r := range catalog.Returns {
+        returns = append(returns, r.Function+":"+r.Kind+":"+r.Error)
+    }
+    want := "Get:type:*ValidationError Get:sentinel:io.EOF Get:wrap:ErrNotFound"
+    if got := strings.Join(returns, " "); got != want {
+        t.Errorf("retornos = %q; want %q", got, want)
+    }
+    if len(catalog.Wraps) != 1 || strings.Join(catalog.Wraps[0].Wrapped, ",") != "ErrNotFound" {
+        t.Errorf("wraps = %+v", catalog.Wraps)
+    }
+
+    var checks []string
+    for _, c := range catalog.Checks {
+        checks = append(checks, c.Kind+":"+c.Target)
+    }
+    if got := strings.Join(checks, " "); got != "errors.As:*ValidationError errors.Is:ErrNotFound" {
+        t.Errorf("verificações = %q", got)
+    }
+}
+
+func TestErrorCatalogInvalidCode(t *testing.T) {
+    // Código que não compila ainda passa pelo catálogo, pois o loader tolera erros de tipos
+    pass := godoctest.LoadPass(t, map[string]string{
+        "store/store.go": `package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+func Broken() error {
+	_ = fmt.Errorf()
+	_ = errors.New()
+	return fmt.Errorf()
+}
+`,
+    })
+
+    if catalog := errcatalog.Build(pass)[pass.Packages[0].Dir]; catalog != nil && (len(catalog.Wraps) > 0 || len(catalog.Returns) > 0) {
+        t.Errorf("catálogo = %+v; want sem wraps nem retornos", catalog)
+    }
+}
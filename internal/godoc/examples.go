@@ -0,0 +1,230 @@
+package godoc
+
+import (
+	"bytes"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	"go/types"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Example representa uma função Example* extraída dos arquivos de teste
+type Example struct {
+    Name      string `json:"name"                yaml:"name"`   // nome da função (ExampleT_M_suffix)
+    Suffix    string `json:"suffix,omitempty"    yaml:"suffix,omitempty"`
+    Doc       string `json:"doc"                 yaml:"doc"`
+    Code      string `json:"code"                yaml:"code"`
+    Output    string `json:"output,omitempty"    yaml:"output,omitempty"`
+    Unordered bool   `json:"unordered,omitempty" yaml:"unordered,omitempty"`
+    File      string `json:"file"                yaml:"file"`
+    Line      int    `json:"line"                yaml:"line"`
+}
+
+// TestFunc representa uma função Test*, Benchmark* ou Fuzz* de um pacote
+type TestFunc struct {
+    Name       string   `json:"name"                 yaml:"name"`
+    Kind       string   `json:"kind"                 yaml:"kind"` // test, benchmark, fuzz
+    File       string   `json:"file"                 yaml:"file"`
+    Line       int      `json:"line"                 yaml:"line"`
+    References []string `json:"references,omitempty" yaml:"references,omitempty"` // símbolos do pacote usados no teste
+}
+
+// attachExamples associa os exemplos aos símbolos que documentam, seguindo a
+// convenção do go doc: Example (pacote), ExampleF, ExampleT e ExampleT_M
+func (a *Analyzer) attachExamples(dir *DirectoryDoc, testFiles []*ast.File) {
+    for _, ex := range doc.Examples(testFiles...) {
+        example := a.convertExample(ex)
+        target, method := ex.Name, ""
+        if idx := strings.Index(ex.Name, "_"); idx > 0 {
+            target, method = ex.Name[:idx], ex.Name[idx+1:]
+        }
+
+        if ex.Name == "" || !dir.attachExample(target, method, example) {
+            dir.Examples = append(dir.Examples, example)
+        }
+    }
+}
+
+// attachExample procura a função, tipo ou método documentado pelo exemplo
+func (d *DirectoryDoc) attachExample(target, method string, example Example) bool {
+    for f := range d.Files {
+        file := &d.Files[f]
+        if method == "" {
+            for i := range file.Functions {
+                if file.Functions[i].Name == target {
+                    file.Functions[i].Examples = append(file.Functions[i].Examples, example)
+                    return true
+                }
+            }
+        }
+        for i := range file.Structs {
+            str := &file.Structs[i]
+            if str.Name != target {
+                continue
+            }
+            if method == "" {
+                str.Examples = append(str.Examples, example)
+                return true
+            }
+            for j := range str.Methods {
+                if str.Methods[j].Name == method {
+                    str.Methods[j].Examples = append(str.Methods[j].Examples, example)
+                    return true
+                }
+            }
+        }
+        for i := range file.Interfaces {
+            if file.Interfaces[i].Name == target && method == "" {
+                file.Interfaces[i].Examples = append(file.Interfaces[i].Examples, example)
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// convertExample converte um exemplo do go/doc para o modelo de documentação
+func (a *Analyzer) convertExample(ex *doc.Example) Example {
+    var buf bytes.Buffer
+    format.Node(&buf, a.fset, ex.Code)
+    code := buf.String()
+    if _, ok := ex.Code.(*ast.BlockStmt); ok {
+        // Como no go doc, mostra apenas o corpo do exemplo
+        code = strings.TrimSuffix(strings.TrimPrefix(code, "{"), "}")
+        code = strings.ReplaceAll(code, "\n\t", "\n")
+    }
+    pos := a.fset.Position(ex.Code.Pos())
+
+    name := "Example"
+    if ex.Name != "" {
+        name += ex.Name
+    }
+    if ex.Suffix != "" {
+        name += "_" + ex.Suffix
+    }
+
+    return Example{
+        Name:      name,
+        Suffix:    ex.Suffix,
+        Doc:       strings.TrimSpace(ex.Doc),
+        Code:      strings.TrimSpace(code),
+        Output:    strings.TrimSpace(ex.Output),
+        Unordered: ex.Unordered,
+        File:      pos.Filename,
+        Line:      pos.Line,
+    }
+}
+
+// collectTests lista as funções de teste, benchmark e fuzz com os símbolos do pacote que
+// referenciam, resolvidos pela verificação de tipos dos arquivos de teste
+func (a *Analyzer) collectTests(dir *DirectoryDoc, pkg *analysis.Package) {
+    symbols, methods := dir.packageSymbols()
+    known := make(map[string]bool)
+    for name, owners := range methods {
+        for _, owner := range owners {
+            known[owner+"."+name] = true
+        }
+    }
+
+    for _, file := range pkg.TestFiles {
+        for _, decl := range file.Decls {
+            fn, ok := decl.(*ast.FuncDecl)
+            if !ok || fn.Recv != nil || fn.Body == nil {
+                continue
+            }
+            kind := testKind(fn.Name.Name)
+            if kind == "" {
+                continue
+            }
+
+            refs := make(map[string]bool)
+            ast.Inspect(fn.Body, func(n ast.Node) bool {
+                if id, ok := n.(*ast.Ident); ok {
+                    if ref := a.testReference(pkg, pkg.TestInfo.Uses[id]); symbols[ref] || known[ref] {
+                        refs[ref] = true
+                    }
+                }
+                return true
+            })
+
+            pos := a.fset.Position(fn.Pos())
+            dir.Tests = append(dir.Tests, TestFunc{
+                Name:       fn.Name.Name,
+                Kind:       kind,
+                File:       pos.Filename,
+                Line:       pos.Line,
+                References: analysis.SortedKeys(refs),
+            })
+        }
+    }
+}
+
+// testReference retorna o nome (Func ou Tipo.Metodo) de um objeto citado em um teste
+// quando ele é declarado fora dos testes no pacote testado; vazio nos demais casos
+func (a *Analyzer) testReference(pkg *analysis.Package, obj types.Object) string {
+    if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != pkg.ImportPath {
+        return ""
+    }
+    if strings.HasSuffix(a.fset.Position(obj.Pos()).Filename, "_test.go") {
+        return ""
+    }
+    if fn, ok := obj.(*types.Func); ok {
+        if recv := analysis.ReceiverName(fn); recv != "" {
+            return recv + "." + fn.Name()
+        }
+    }
+    if obj.Parent() != obj.Pkg().Scope() {
+        return "" // campos, parâmetros e variáveis locais
+    }
+    return obj.Name()
+}
+
+// testKind identifica funções de teste pelo prefixo, como o go test
+func testKind(name string) string {
+    isTestName := func(prefix string) bool {
+        if !strings.HasPrefix(name, prefix) {
+            return false
+        }
+        rest := name[len(prefix):]
+        return rest == "" || !(rest[0] >= 'a' && rest[0] <= 'z')
+    }
+    switch {
+    case isTestName("Test"):
+        return "test"
+    case isTestName("Benchmark"):
+        return "benchmark"
+    case isTestName("Fuzz"):
+        return "fuzz"
+    }
+    return ""
+}
+
+// packageSymbols retorna os símbolos de nível de pacote e os tipos que declaram cada método
+func (d *DirectoryDoc) packageSymbols() (map[string]bool, map[string][]string) {
+    symbols := make(map[string]bool)
+    methods := make(map[string][]string)
+    for _, file := range d.Files {
+        for _, fn := range file.Functions {
+            symbols[fn.Name] = true
+        }
+        for _, str := range file.Structs {
+            symbols[str.Name] = true
+            for _, m := range str.Methods {
+                methods[m.Name] = append(methods[m.Name], str.Name)
+            }
+        }
+        for _, iface := range file.Interfaces {
+            symbols[iface.Name] = true
+        }
+        for _, c := range file.Constants {
+            symbols[c.Name] = true
+        }
+        for _, v := range file.Variables {
+            symbols[v.Name] = true
+        }
+    }
+    return symbols, methods
+}
@@ -0,0 +1,148 @@
+package godoc_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestExamplesAndTests(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "calc/calc.go": `package calc
+
+// Sum soma dois inteiros
+func Sum(a, b int) int { return a + b }
+
+type Acc struct{ total int }
+
+func (a *Acc) Add(n int) { a.total += n }
+`,
+        "calc/calc_test.go": `package calc_test
+
+import (
+    "fmt"
+    "testing"
+
+    "example.com/app/calc"
+)
+
+func ExampleSum() {
+    fmt.Println(calc.Sum(1, 2))
+    // Output: 3
+}
+
+func ExampleAcc_Add() {
+    var acc calc.Acc
+    acc.Add(1)
+}
+
+func TestSum(t *testing.T) {
+    if calc.Sum(1, 1) != 2 {
+        t.Fail()
+    }
+}
+
+func BenchmarkSum(b *testing.B) {}
+`,
+    })
+
+    cfg := config.GolangConfig{
+        Paths:   []string{root},
+        Ignores: []string{".*_test\\.go$"},
+        ReportOptions: config.ReportOptions{ShowExamples: true, ShowTests: true},
+    }
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+
+    sum := godoctest.FindFunc(doc, "Sum")
+    if sum == nil || len(sum.Examples) != 1 {
+        t.Fatalf("Sum deveria ter 1 exemplo: %+v", sum)
+    }
+    if sum.Examples[0].Output != "3" {
+        t.Errorf("Output = %q; want %q", sum.Examples[0].Output, "3")
+    }
+
+    dir := doc.Directories[0]
+    acc := dir.Files[0].Structs[0]
+    if len(acc.Methods) != 1 || len(acc.Methods[0].Examples) != 1 {
+        t.Errorf("Acc.Add deveria ter 1 exemplo: %+v", acc.Methods)
+    }
+
+    if len(dir.Tests) != 2 {
+        t.Fatalf("Tests = %d; want 2", len(dir.Tests))
+    }
+    if dir.Tests[0].Kind != "test" || len(dir.Tests[0].References) != 1 || dir.Tests[0].References[0] != "Sum" {
+        t.Errorf("TestSum = %+v; want kind test referenciando Sum", dir.Tests[0])
+    }
+    if dir.Tests[1].Kind != "benchmark" {
+        t.Errorf("BenchmarkSum.Kind = %q; want benchmark", dir.Tests[1].Kind)
+    }
+}
+
+func TestTestReferences(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        // O nome do pacote difere do último elemento do import path
+        "stats/v2/stats.go": `package stats
+
+func Mean(xs []float64) float64 { return sum(xs) / float64(len(xs)) }
+
+func sum(xs []float64) float64 { return 0 }
+
+type Series struct{ values []float64 }
+
+func (s *Series) Len() int { return len(s.values) }
+`,
+        "stats/v2/stats_test.go": `package stats
+
+import "testing"
+
+func TestSum(t *testing.T) {
+    sum := sum(nil)
+    var s Series
+    _ = s.Len() + int(sum)
+    _ = fixture()
+}
+
+func fixture() []float64 { return nil }
+`,
+        "stats/v2/mean_test.go": `package stats_test
+
+import (
+    "testing"
+
+    "example.com/app/stats/v2"
+)
+
+func TestMean(t *testing.T) {
+    Mean := 0.0
+    _ = stats.Mean(nil) + Mean
+}
+`,
+    })
+
+    cfg := config.GolangConfig{
+        Paths:         []string{root},
+        ReportOptions: config.ReportOptions{ShowTests: true},
+    }
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+
+    got := make(map[string]string)
+    for _, test := range doc.Directories[0].Tests {
+        got[test.Name] = strings.Join(test.References, ",")
+    }
+    // Variáveis locais com o nome de um símbolo e helpers de teste não contam
+    want := map[string]string{"TestSum": "Series,Series.Len,sum", "TestMean": "Mean"}
+    for name, refs := range want {
+        if got[name] != refs {
+            t.Errorf("referências de %s = %q; want %q", name, got[name], refs)
+        }
+    }
+}
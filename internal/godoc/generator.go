@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc/callgraph"
 )
 
 // Generator é responsável por gerar a documentação em diferentes formatos
@@ -156,8 +157,11 @@ func (g *Generator) GenerateMarkdown() string {
     sb.WriteString("```\n\n")
 
     for _, dir := range g.projectDoc.Directories {
-        relPath, _ := filepath.Rel(g.basePath, dir.Path)
-        sb.WriteString(fmt.Sprintf("## Pacote: %s\n\n", relPath))
+        heading := dir.ImportPath
+        if heading == "" {
+            heading, _ = filepath.Rel(g.basePath, dir.Path)
+        }
+        sb.WriteString(fmt.Sprintf("## Pacote: %s\n\n", heading))
 
         for _, file := range dir.Files {
             // Pula arquivos de teste se não estiver configurado para mostrá-los
@@ -166,6 +170,9 @@ func (g *Generator) GenerateMarkdown() string {
             }
 
             sb.WriteString(fmt.Sprintf("### Arquivo: %s\n\n", filepath.Base(file.FileName)))
+            if file.Constraint != "" {
+                sb.WriteString(fmt.Sprintf("**Restrição de build:** `%s`\n\n", file.Constraint))
+            }
             
             // Documentar imports
             if len(file.Imports) > 0 && g.shouldIncludeContent("imports") {
@@ -222,6 +229,14 @@ func (g *Generator) GenerateMarkdown() string {
 
             sb.WriteString("\n---\n\n")
         }
+
+        if g.shouldIncludeContent("examples") && len(dir.Examples) > 0 {
+            sb.WriteString("### Exemplos do pacote\n\n")
+            g.writeExamplesMarkdown(&sb, dir.Examples)
+        }
+        if g.shouldIncludeContent("tests") {
+            g.writeTestsMarkdown(&sb, dir.Tests)
+        }
     }
 
     return sb.String()
@@ -304,7 +319,11 @@ func (g *Generator) writeStructMarkdown(sb *strings.Builder, str Struct) {
 
 // writeConstVarMarkdown documenta uma constante ou variável em Markdown
 func (g *Generator) writeConstVarMarkdown(sb *strings.Builder, cv ConstVar) {
-    sb.WriteString(fmt.Sprintf("- `%s %s`\n", cv.Name, cv.Type))
+    decl := strings.TrimSpace(cv.Name + " " + cv.Type)
+    if cv.Value != "" {
+        decl += " = " + cv.Value
+    }
+    sb.WriteString(fmt.Sprintf("- `%s`\n", decl))
     if cv.Doc != "" {
         sb.WriteString("  - " + strings.TrimSpace(cv.Doc) + "\n")
     }
@@ -316,6 +335,35 @@ func (g *Generator) writeFunctionMarkdown(sb *strings.Builder, fn FuncInfo) {
     if fn.Doc != "" {
         sb.WriteString(strings.TrimSpace(fn.Doc) + "\n\n")
     }
+    if g.shouldIncludeContent("examples") {
+        g.writeExamplesMarkdown(sb, fn.Examples)
+    }
+}
+
+// writeExamplesMarkdown documenta exemplos com código e output esperado
+func (g *Generator) writeExamplesMarkdown(sb *strings.Builder, examples []Example) {
+    for _, ex := range examples {
+        sb.WriteString(fmt.Sprintf("Exemplo `%s`:\n\n```go\n%s\n```\n\n", ex.Name, ex.Code))
+        if ex.Output != "" {
+            sb.WriteString(fmt.Sprintf("Output:\n\n```\n%s\n```\n\n", ex.Output))
+        }
+    }
+}
+
+// writeTestsMarkdown lista as funções de teste de um pacote
+func (g *Generator) writeTestsMarkdown(sb *strings.Builder, tests []TestFunc) {
+    if len(tests) == 0 {
+        return
+    }
+    sb.WriteString("### Testes\n\n")
+    for _, t := range tests {
+        sb.WriteString(fmt.Sprintf("- `%s` (%s)", t.Name, t.Kind))
+        if len(t.References) > 0 {
+            sb.WriteString(" → " + strings.Join(t.References, ", "))
+        }
+        sb.WriteString("\n")
+    }
+    sb.WriteString("\n")
 }
 
 // GenerateHTML gera a documentação em formato HTML
@@ -575,4 +623,141 @@ func (g *Generator) structImplementsInterface(str Struct, iface Interface) bool
     }
 
     return true
-}
\ No newline at end of file
+}
+// CallGraphDiagram representa um diagrama de chamadas renderizado
+type CallGraphDiagram struct {
+    Name    string `json:"name"    yaml:"name"`
+    Kind    string `json:"kind"    yaml:"kind"` // package, entry_point
+    Mermaid string `json:"mermaid" yaml:"mermaid"`
+    DOT     string `json:"dot"     yaml:"dot"`
+}
+
+// GenerateCallGraphDiagrams gera os grafos de chamadas por pacote e por ponto de entrada
+func (g *Generator) GenerateCallGraphDiagrams() []CallGraphDiagram {
+    graph := g.projectDoc.CallGraph
+    if graph == nil || !g.config.CallGraph.Enabled {
+        return nil
+    }
+
+    var diagrams []CallGraphDiagram
+    for _, pkg := range graph.Packages() {
+        sub := graph.Subgraph(pkg)
+        if len(sub.Edges) == 0 {
+            continue
+        }
+        diagrams = append(diagrams, CallGraphDiagram{
+            Name:    pkg,
+            Kind:    "package",
+            Mermaid: sub.Mermaid(),
+            DOT:     sub.DOT(pkg),
+        })
+    }
+
+    for _, entry := range graph.EntryPoints(g.config.CallGraph.EntryPoints) {
+        sub := graph.Reachable(entry.ID, g.config.CallGraph.MaxDepth)
+        diagrams = append(diagrams, CallGraphDiagram{
+            Name:    entry.ID,
+            Kind:    "entry_point",
+            Mermaid: sub.Mermaid(),
+            DOT:     sub.DOT(entry.ID),
+        })
+    }
+
+    return diagrams
+}
+
+// ClassDiagram representa um diagrama de classes renderizado
+type ClassDiagram struct {
+    Name     string `json:"name"     yaml:"name"`
+    Kind     string `json:"kind"     yaml:"kind"` // package, focus
+    Mermaid  string `json:"mermaid"  yaml:"mermaid"`
+    PlantUML string `json:"plantuml" yaml:"plantuml"`
+}
+
+// GenerateClassDiagrams gera os diagramas de classes por pacote e por tipo em foco
+func (g *Generator) GenerateClassDiagrams() []ClassDiagram {
+    graph := g.projectDoc.Classes
+    if graph == nil {
+        return nil
+    }
+    opts := g.config.ClassDiagram
+
+    var diagrams []ClassDiagram
+    for _, pkg := range graph.Packages() {
+        sub := graph.PackageGraph(pkg, opts.Depth)
+        diagrams = append(diagrams, ClassDiagram{
+            Name:     pkg,
+            Kind:     "package",
+            Mermaid:  sub.Mermaid(opts.MaxMethods),
+            PlantUML: sub.PlantUML(opts.MaxMethods),
+        })
+    }
+
+    for _, focus := range graph.Focus(opts.Focus) {
+        sub := graph.Neighborhood([]string{focus.ID}, opts.Depth)
+        diagrams = append(diagrams, ClassDiagram{
+            Name:     focus.ID,
+            Kind:     "focus",
+            Mermaid:  sub.Mermaid(opts.MaxMethods),
+            PlantUML: sub.PlantUML(opts.MaxMethods),
+        })
+    }
+
+    return diagrams
+}
+
+// SequenceDiagram representa um diagrama de sequência renderizado a partir de um ponto de entrada
+type SequenceDiagram struct {
+    Name     string `json:"name"     yaml:"name"`
+    Kind     string `json:"kind"     yaml:"kind"` // route, entry_point
+    Entry    string `json:"entry"    yaml:"entry"` // ID da função inicial
+    Mermaid  string `json:"mermaid"  yaml:"mermaid"`
+    PlantUML string `json:"plantuml" yaml:"plantuml"`
+}
+
+// GenerateSequenceDiagrams gera os diagramas de sequência das rotas HTTP e das
+// funções configuradas como pontos de entrada
+func (g *Generator) GenerateSequenceDiagrams() []SequenceDiagram {
+    graph := g.projectDoc.CallGraph
+    opts := g.config.Sequence
+    if graph == nil || !opts.Enabled {
+        return nil
+    }
+
+    var diagrams []SequenceDiagram
+    add := func(name, kind, entry string) {
+        seq := graph.Sequence(entry, opts.MaxDepth, opts.GroupBy)
+        if seq.Empty() {
+            return
+        }
+        diagrams = append(diagrams, SequenceDiagram{
+            Name:     name,
+            Kind:     kind,
+            Entry:    entry,
+            Mermaid:  seq.Mermaid(),
+            PlantUML: seq.PlantUML(),
+        })
+    }
+
+    if opts.Routes {
+        for _, route := range g.projectDoc.Routes {
+            if route.HandlerID != "" {
+                add(route.Method+" "+route.Path, "route", route.HandlerID)
+            }
+        }
+    }
+    for _, n := range graph.Nodes {
+        if callgraph.MatchesEntryPoint(n, opts.EntryPoints) {
+            add(n.Name, "entry_point", n.ID)
+        }
+    }
+    return diagrams
+}
+
+// GenerateDependencyDiagram gera o diagrama em camadas das dependências entre pacotes
+func (g *Generator) GenerateDependencyDiagram() string {
+    if g.projectDoc.Dependencies == nil || len(g.projectDoc.Dependencies.Packages) == 0 {
+        return ""
+    }
+    return g.projectDoc.Dependencies.Mermaid()
+}
@@ -0,0 +1,55 @@
+// Package godoctest reúne funções auxiliares para os testes das análises do godoc
+package godoctest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// WriteModule cria um módulo example.com/app temporário com os arquivos informados
+func WriteModule(t *testing.T, files map[string]string) string {
+    t.Helper()
+    root := t.TempDir()
+    files["go.mod"] = "module example.com/app\n\ngo 1.22\n"
+    for name, content := range files {
+        path := filepath.Join(root, name)
+        if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+            t.Fatalf("Erro ao criar diretório: %v", err)
+        }
+        if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+            t.Fatalf("Erro ao escrever arquivo %s: %v", name, err)
+        }
+    }
+    return root
+}
+
+// LoadPass cria o módulo com WriteModule e retorna o Pass com os pacotes e testes
+// verificados, para testar uma análise sem executar as demais
+func LoadPass(t *testing.T, files map[string]string) *analysis.Pass {
+    t.Helper()
+    root := WriteModule(t, files)
+    pass, err := godoc.NewAnalyzer(config.GolangConfig{Paths: []string{root}}).LoadPass()
+    if err != nil {
+        t.Fatalf("Erro ao carregar pacotes: %v", err)
+    }
+    return pass
+}
+
+// FindFunc procura uma função pelo nome em todo o projeto
+func FindFunc(doc *godoc.ProjectDoc, name string) *godoc.FuncInfo {
+    for d := range doc.Directories {
+        for f := range doc.Directories[d].Files {
+            for i := range doc.Directories[d].Files[f].Functions {
+                if fn := &doc.Directories[d].Files[f].Functions[i]; fn.Name == name {
+                    return fn
+                }
+            }
+        }
+    }
+    return nil
+}
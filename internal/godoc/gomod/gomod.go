@@ -0,0 +1,195 @@
+// Package gomod lê os arquivos go.mod e go.work
+package gomod
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Module representa um módulo Go descrito por um go.mod
+type Module struct {
+    Path      string          `json:"path"                yaml:"path"`
+    Dir       string          `json:"dir"                 yaml:"dir"`
+    GoVersion string          `json:"go_version"          yaml:"go_version"`
+    Toolchain string          `json:"toolchain,omitempty" yaml:"toolchain,omitempty"`
+    Requires  []Requirement   `json:"requires,omitempty"  yaml:"requires,omitempty"`
+    Replaces  []Replacement   `json:"replaces,omitempty"  yaml:"replaces,omitempty"`
+    Excludes  []Version `json:"excludes,omitempty"  yaml:"excludes,omitempty"`
+}
+
+// Requirement representa uma dependência declarada em require
+type Requirement struct {
+    Path     string `json:"path"     yaml:"path"`
+    Version  string `json:"version"  yaml:"version"`
+    Indirect bool   `json:"indirect" yaml:"indirect"`
+}
+
+// Replacement representa uma diretiva replace
+type Replacement struct {
+    Old        string `json:"old"                   yaml:"old"`
+    OldVersion string `json:"old_version,omitempty" yaml:"old_version,omitempty"`
+    New        string `json:"new"                   yaml:"new"`
+    NewVersion string `json:"new_version,omitempty" yaml:"new_version,omitempty"`
+}
+
+// Version representa um par módulo/versão (ex.: diretiva exclude)
+type Version struct {
+    Path    string `json:"path"    yaml:"path"`
+    Version string `json:"version" yaml:"version"`
+}
+
+// Workspace representa um workspace descrito por um go.work
+type Workspace struct {
+    File      string        `json:"file"               yaml:"file"`
+    GoVersion string        `json:"go_version"         yaml:"go_version"`
+    Uses      []string      `json:"uses"               yaml:"uses"`
+    Replaces  []Replacement `json:"replaces,omitempty" yaml:"replaces,omitempty"`
+}
+
+// DirectRequires retorna as dependências diretas do módulo
+func (m Module) DirectRequires() []Requirement {
+    return m.filterRequires(false)
+}
+
+// IndirectRequires retorna as dependências marcadas como // indirect
+func (m Module) IndirectRequires() []Requirement {
+    return m.filterRequires(true)
+}
+
+func (m Module) filterRequires(indirect bool) []Requirement {
+    var result []Requirement
+    for _, r := range m.Requires {
+        if r.Indirect == indirect {
+            result = append(result, r)
+        }
+    }
+    return result
+}
+
+// Parse lê um arquivo go.mod
+func Parse(path string) (*Module, error) {
+    info := &Module{Dir: filepath.Dir(path)}
+    err := parseDirectives(path, func(verb string, args []string, comment string) {
+        switch verb {
+        case "module":
+            if len(args) > 0 {
+                info.Path = args[0]
+            }
+        case "go":
+            if len(args) > 0 {
+                info.GoVersion = args[0]
+            }
+        case "toolchain":
+            if len(args) > 0 {
+                info.Toolchain = args[0]
+            }
+        case "require":
+            if len(args) >= 2 {
+                info.Requires = append(info.Requires, Requirement{
+                    Path:     args[0],
+                    Version:  args[1],
+                    Indirect: strings.Contains(comment, "indirect"),
+                })
+            }
+        case "replace":
+            if r, ok := parseReplacement(args); ok {
+                info.Replaces = append(info.Replaces, r)
+            }
+        case "exclude":
+            if len(args) >= 2 {
+                info.Excludes = append(info.Excludes, Version{Path: args[0], Version: args[1]})
+            }
+        }
+    })
+    if err != nil {
+        return nil, err
+    }
+    return info, nil
+}
+
+// ParseWork lê um arquivo go.work
+func ParseWork(path string) (*Workspace, error) {
+    info := &Workspace{File: path}
+    err := parseDirectives(path, func(verb string, args []string, comment string) {
+        switch verb {
+        case "go":
+            if len(args) > 0 {
+                info.GoVersion = args[0]
+            }
+        case "use":
+            if len(args) > 0 {
+                info.Uses = append(info.Uses, args[0])
+            }
+        case "replace":
+            if r, ok := parseReplacement(args); ok {
+                info.Replaces = append(info.Replaces, r)
+            }
+        }
+    })
+    if err != nil {
+        return nil, err
+    }
+    return info, nil
+}
+
+// parseReplacement interpreta "old [v] => new [v]"
+func parseReplacement(args []string) (Replacement, bool) {
+    arrow := -1
+    for i, arg := range args {
+        if arg == "=>" {
+            arrow = i
+            break
+        }
+    }
+    if arrow < 1 || arrow == len(args)-1 {
+        return Replacement{}, false
+    }
+    r := Replacement{Old: args[0], New: args[arrow+1]}
+    if arrow == 2 {
+        r.OldVersion = args[1]
+    }
+    if len(args) > arrow+2 {
+        r.NewVersion = args[arrow+2]
+    }
+    return r, true
+}
+
+// parseDirectives percorre as diretivas de um go.mod/go.work, expandindo blocos
+// "verb ( ... )" e repassando o comentário de fim de linha
+func parseDirectives(path string, fn func(verb string, args []string, comment string)) error {
+    file, err := os.Open(path)
+    if err != nil {
+        return err
+    }
+    defer file.Close()
+
+    block := ""
+    scanner := bufio.NewScanner(file)
+    for scanner.Scan() {
+        line, comment := scanner.Text(), ""
+        if idx := strings.Index(line, "//"); idx >= 0 {
+            line, comment = line[:idx], strings.TrimSpace(line[idx+2:])
+        }
+        fields := strings.Fields(line)
+        if len(fields) == 0 {
+            continue
+        }
+        for i := range fields {
+            fields[i] = strings.Trim(fields[i], `"`+"`")
+        }
+
+        switch {
+        case block != "" && fields[0] == ")":
+            block = ""
+        case block != "":
+            fn(block, fields, comment)
+        case len(fields) == 2 && fields[1] == "(":
+            block = fields[0]
+        default:
+            fn(fields[0], fields[1:], comment)
+        }
+    }
+    return scanner.Err()
+}
@@ -0,0 +1,50 @@
+package gomod_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/gomod"
+)
+
+func TestParse(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "go.mod")
+    content := `module example.com/app
+
+go 1.22
+
+require (
+    github.com/a/b v1.0.0
+    github.com/c/d v0.2.0 // indirect
+)
+
+replace github.com/a/b v1.0.0 => ../b
+
+exclude github.com/e/f v0.1.0
+`
+    if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+        t.Fatalf("Erro ao escrever go.mod: %v", err)
+    }
+
+    mod, err := gomod.Parse(path)
+    if err != nil {
+        t.Fatalf("Erro ao ler go.mod: %v", err)
+    }
+    if mod.Path != "example.com/app" || mod.GoVersion != "1.22" {
+        t.Errorf("module = %q, go = %q", mod.Path, mod.GoVersion)
+    }
+    if direct := mod.DirectRequires(); len(direct) != 1 || direct[0].Path != "github.com/a/b" {
+        t.Errorf("DirectRequires = %+v", direct)
+    }
+    if indirect := mod.IndirectRequires(); len(indirect) != 1 || indirect[0].Path != "github.com/c/d" {
+        t.Errorf("IndirectRequires = %+v", indirect)
+    }
+    want := gomod.Replacement{Old: "github.com/a/b", OldVersion: "v1.0.0", New: "../b"}
+    if len(mod.Replaces) != 1 || mod.Replaces[0] != want {
+        t.Errorf("Replaces = %+v; want [%+v]", mod.Replaces, want)
+    }
+    if len(mod.Excludes) != 1 || mod.Excludes[0].Version != "v0.1.0" {
+        t.Errorf("Excludes = %+v", mod.Excludes)
+    }
+}
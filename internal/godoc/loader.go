@@ -0,0 +1,188 @@
+package godoc
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// packageLoader verifica os tipos dos pacotes analisados, resolvendo
+// imports internos a partir das próprias ASTs e os demais via export data
+type packageLoader struct {
+    fset     *token.FileSet
+    byPath   map[string]*analysis.Package
+    checking map[string]bool
+    fallback types.Importer
+}
+
+// newPass monta o Pass com os diretórios lidos, na ordem da análise. Diretórios fora
+// de um módulo não têm import path e ficam de fora das análises
+func (a *Analyzer) newPass() *analysis.Pass {
+    pass := &analysis.Pass{Fset: a.fset}
+    for _, dir := range a.dirOrder {
+        importPath := a.importPathForDir(dir)
+        if importPath == "" {
+            slog.Warn("Diretório fora de um módulo Go ignorado nas análises", "path", dir)
+            continue
+        }
+        pass.Dirs = append(pass.Dirs, &analysis.Dir{
+            Path:       dir,
+            ImportPath: importPath,
+            Module:     a.moduleForDir(dir),
+            Files:      a.astFiles[dir],
+        })
+    }
+    return pass
+}
+
+// loadPackages executa o go/types no pacote principal de cada diretório e, com tests,
+// também nos arquivos _test.go
+func (a *Analyzer) loadPackages(dirs []*analysis.Dir, tests bool) []*analysis.Package {
+    loader := &packageLoader{
+        fset:     a.fset,
+        byPath:   make(map[string]*analysis.Package),
+        checking: make(map[string]bool),
+        fallback: importer.ForCompiler(a.fset, "gc", nil),
+    }
+
+    var packages []*analysis.Package
+    for _, dir := range dirs {
+        files := a.typeCheckFiles(dir.Files)
+        if len(files) == 0 {
+            continue
+        }
+        pkg := &analysis.Package{
+            Dir:        dir.Path,
+            ImportPath: dir.ImportPath,
+            Name:       files[0].Name.Name,
+            Files:      files,
+        }
+        loader.byPath[pkg.ImportPath] = pkg
+        packages = append(packages, pkg)
+    }
+
+    for _, pkg := range packages {
+        loader.check(pkg)
+    }
+    if tests {
+        for _, pkg := range packages {
+            loader.checkTests(pkg)
+        }
+    }
+
+    return packages
+}
+
+// typeCheckFiles seleciona os arquivos que fazem parte do pacote principal do diretório
+func (a *Analyzer) typeCheckFiles(files []*ast.File) []*ast.File {
+    var result []*ast.File
+    pkgName := ""
+    for _, f := range files {
+        fileName := a.fset.Position(f.Pos()).Filename
+        if strings.HasSuffix(fileName, "_test.go") {
+            continue
+        }
+        if pkgName == "" {
+            pkgName = f.Name.Name
+        }
+        if f.Name.Name != pkgName {
+            slog.Warn("Arquivo ignorado na verificação de tipos: pacote divergente",
+                "file", fileName, "package", f.Name.Name, "expected", pkgName)
+            continue
+        }
+        result = append(result, f)
+    }
+    return result
+}
+
+// check verifica os tipos de um pacote, tolerando erros de tipos e imports
+func (l *packageLoader) check(pkg *analysis.Package) *types.Package {
+    if pkg.Types != nil || l.checking[pkg.ImportPath] {
+        return pkg.Types
+    }
+    l.checking[pkg.ImportPath] = true
+    defer delete(l.checking, pkg.ImportPath)
+
+    info := newTypesInfo()
+    typesPkg, _ := l.config(pkg).Check(pkg.ImportPath, l.fset, pkg.Files, info)
+    pkg.Types = typesPkg
+    pkg.Info = info
+    return typesPkg
+}
+
+// checkTests verifica os tipos dos arquivos _test.go do pacote: os do próprio pacote
+// junto com os arquivos do pacote e os do pacote externo (pkg_test) importando-o
+func (l *packageLoader) checkTests(pkg *analysis.Package) {
+    files, err := analysis.ParseTestFiles(l.fset, pkg.Dir)
+    if err != nil {
+        slog.Error("Erro ao ler arquivos de teste", "path", pkg.Dir, "error", err)
+        return
+    }
+    var internal, external []*ast.File
+    for _, f := range files {
+        switch f.Name.Name {
+        case pkg.Name:
+            internal = append(internal, f)
+        case pkg.Name + "_test":
+            external = append(external, f)
+        }
+    }
+
+    info := newTypesInfo()
+    conf := l.config(pkg)
+    if len(internal) > 0 {
+        conf.Check(pkg.ImportPath, l.fset, append(append([]*ast.File{}, pkg.Files...), internal...), info)
+    }
+    if len(external) > 0 {
+        conf.Check(pkg.ImportPath+"_test", l.fset, external, info)
+    }
+    pkg.TestFiles = append(internal, external...)
+    pkg.TestInfo = info
+}
+
+// config retorna a configuração do go/types, tolerando erros de tipos e imports
+func (l *packageLoader) config(pkg *analysis.Package) *types.Config {
+    return &types.Config{
+        Importer:    l,
+        FakeImportC: true,
+        Error: func(err error) {
+            slog.Debug("Erro de verificação de tipos", "package", pkg.ImportPath, "error", err)
+        },
+    }
+}
+
+// newTypesInfo cria o types.Info com os mapas usados pelas análises
+func newTypesInfo() *types.Info {
+    return &types.Info{
+        Types:      make(map[ast.Expr]types.TypeAndValue),
+        Defs:       make(map[*ast.Ident]types.Object),
+        Uses:       make(map[*ast.Ident]types.Object),
+        Selections: make(map[*ast.SelectorExpr]*types.Selection),
+        Implicits:  make(map[ast.Node]types.Object),
+    }
+}
+
+// Import implementa types.Importer
+func (l *packageLoader) Import(path string) (*types.Package, error) {
+    if pkg, ok := l.byPath[path]; ok {
+        if typesPkg := l.check(pkg); typesPkg != nil {
+            return typesPkg, nil
+        }
+        // Ciclo de imports: devolve um pacote vazio para não interromper a análise
+        return types.NewPackage(path, pkg.Name), nil
+    }
+    if typesPkg, err := l.fallback.Import(path); err == nil {
+        return typesPkg, nil
+    }
+    // Dependência externa sem export data: usa um pacote vazio com o nome que o
+    // arquivo usaria (chi para github.com/go-chi/chi/v5), para que o seletor resolva
+    stub := types.NewPackage(path, analysis.ImportName(path))
+    stub.MarkComplete()
+    return stub, nil
+}
+
@@ -0,0 +1,314 @@
+// Package logcatalog cataloga as chamadas de log de cada pacote e as chaves estruturadas usadas
+package logcatalog
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Catalog descreve as chamadas de log de um pacote
+type Catalog struct {
+    Calls []LogCall `json:"calls"          yaml:"calls"`
+    Keys  []LogKey  `json:"keys,omitempty" yaml:"keys,omitempty"`
+}
+
+// LogCall representa uma chamada de log (slog ou log) ou um logger.With
+type LogCall struct {
+    Function string   `json:"function"          yaml:"function"`
+    Logger   string   `json:"logger"            yaml:"logger"` // slog, log ou a expressão do logger (ex.: s.logger)
+    Method   string   `json:"method"            yaml:"method"`
+    Level    string   `json:"level,omitempty"   yaml:"level,omitempty"` // DEBUG, INFO, WARN, ERROR, PRINT, FATAL, PANIC; vazio em With
+    Message  string   `json:"message,omitempty" yaml:"message,omitempty"` // mensagem ou formato constante
+    Dynamic  bool     `json:"dynamic,omitempty" yaml:"dynamic,omitempty"` // mensagem não constante
+    Keys     []string `json:"keys,omitempty"    yaml:"keys,omitempty"`
+    File     string   `json:"file"              yaml:"file"`
+    Line     int      `json:"line"              yaml:"line"`
+}
+
+// LogKey resume o uso de uma chave de atributo no pacote
+type LogKey struct {
+    Name     string   `json:"name"               yaml:"name"`
+    Count    int      `json:"count"              yaml:"count"`
+    Variants []string `json:"variants,omitempty" yaml:"variants,omitempty"` // outras grafias da mesma chave no projeto (ex.: err e error)
+}
+
+// logMethod descreve os argumentos de uma função ou método de log
+type logMethod struct {
+    level   string // nível fixo; vazio quando vem de um argumento ou em With
+    levelAt int    // índice do argumento com o nível (slog.Log/LogAttrs); -1 se fixo
+    msgAt   int    // índice da mensagem; -1 em With
+    argsAt  int    // índice dos pares chave/valor ou atributos
+}
+
+// slogMethods lista as funções do pacote log/slog e os métodos de *slog.Logger
+var slogMethods = map[string]logMethod{
+    "Debug":        {"DEBUG", -1, 0, 1},
+    "Info":         {"INFO", -1, 0, 1},
+    "Warn":         {"WARN", -1, 0, 1},
+    "Error":        {"ERROR", -1, 0, 1},
+    "DebugContext": {"DEBUG", -1, 1, 2},
+    "InfoContext":  {"INFO", -1, 1, 2},
+    "WarnContext":  {"WARN", -1, 1, 2},
+    "ErrorContext": {"ERROR", -1, 1, 2},
+    "Log":          {"", 1, 2, 3},
+    "LogAttrs":     {"", 1, 2, 3},
+    "With":         {"", -1, -1, 0},
+}
+
+// stdlogMethods lista as funções do pacote log e os métodos de *log.Logger
+var stdlogMethods = map[string]logMethod{
+    "Print": {"PRINT", -1, 0, -1}, "Printf": {"PRINT", -1, 0, -1}, "Println": {"PRINT", -1, 0, -1},
+    "Fatal": {"FATAL", -1, 0, -1}, "Fatalf": {"FATAL", -1, 0, -1}, "Fatalln": {"FATAL", -1, 0, -1},
+    "Panic": {"PANIC", -1, 0, -1}, "Panicf": {"PANIC", -1, 0, -1}, "Panicln": {"PANIC", -1, 0, -1},
+}
+
+// slogLevels traduz as constantes de nível do slog
+var slogLevels = map[string]string{
+    "LevelDebug": "DEBUG", "LevelInfo": "INFO", "LevelWarn": "WARN", "LevelError": "ERROR",
+}
+
+// logKeySynonyms agrupa abreviações comuns de chaves de log
+var logKeySynonyms = map[string]string{
+    "err": "error", "e": "error",
+    "msg": "message",
+    "req": "request", "resp": "response", "res": "response",
+    "cfg": "config", "conf": "config",
+    "pkg": "package",
+    "dur": "duration", "elapsed": "duration",
+    "addr": "address",
+    "ns": "namespace",
+}
+
+// Build gera o catálogo de logs de cada pacote, indexado pelo diretório, e aponta
+// chaves com grafias diferentes no projeto
+func Build(pass *analysis.Pass) (map[string]*Catalog, []analysis.Diagnostic) {
+    result := make(map[string]*Catalog)
+    var catalogs []*Catalog
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil {
+            continue
+        }
+        catalog := &Catalog{}
+        pkg.FuncDecls(func(decl *ast.FuncDecl, obj *types.Func) {
+            if decl.Body == nil {
+                return
+            }
+            function := analysis.LocalFuncName(obj, pkg.Types)
+            ast.Inspect(decl.Body, func(n ast.Node) bool {
+                if call, ok := n.(*ast.CallExpr); ok {
+                    if c, ok := logCall(pass.Fset, pkg, function, call); ok {
+                        catalog.Calls = append(catalog.Calls, c)
+                    }
+                }
+                return true
+            })
+        })
+        if len(catalog.Calls) == 0 {
+            continue
+        }
+        result[pkg.Dir] = catalog
+        catalogs = append(catalogs, catalog)
+    }
+
+    // Contagem das chaves por pacote e grafias de cada chave canônica no projeto
+    variants := make(map[string]map[string]int)
+    first := make(map[string]LogCall)
+    for _, catalog := range catalogs {
+        counts := make(map[string]int)
+        for _, call := range catalog.Calls {
+            for _, key := range call.Keys {
+                counts[key]++
+                canonical := canonicalLogKey(key)
+                if variants[canonical] == nil {
+                    variants[canonical] = make(map[string]int)
+                }
+                variants[canonical][key]++
+                if _, ok := first[key]; !ok {
+                    first[key] = call
+                }
+            }
+        }
+        for _, key := range analysis.SortedKeys(counts) {
+            catalog.Keys = append(catalog.Keys, LogKey{Name: key, Count: counts[key]})
+        }
+    }
+    for _, catalog := range catalogs {
+        for i, key := range catalog.Keys {
+            for _, other := range analysis.SortedKeys(variants[canonicalLogKey(key.Name)]) {
+                if other != key.Name {
+                    catalog.Keys[i].Variants = append(catalog.Keys[i].Variants, other)
+                }
+            }
+        }
+    }
+
+    var diagnostics []analysis.Diagnostic
+    for _, canonical := range analysis.SortedKeys(variants) {
+        keys := variants[canonical]
+        if len(keys) < 2 {
+            continue
+        }
+        names := analysis.SortedKeys(keys)
+        // Aponta para o primeiro uso da grafia menos frequente
+        rare := names[0]
+        var parts []string
+        for _, name := range names {
+            if keys[name] < keys[rare] {
+                rare = name
+            }
+            parts = append(parts, fmt.Sprintf("%q (%d)", name, keys[name]))
+        }
+        call := first[rare]
+        diagnostics = append(diagnostics, analysis.Diagnostic{
+            Severity: analysis.SeverityWarning,
+            Rule:     "log-keys",
+            Message:  "chaves de log com grafias diferentes: " + strings.Join(parts, ", "),
+            File:     call.File,
+            Line:     call.Line,
+        })
+    }
+    return result, diagnostics
+}
+
+// logCall interpreta uma chamada de log do slog ou do pacote log
+func logCall(fset *token.FileSet, pkg *analysis.Package, function string, call *ast.CallExpr) (LogCall, bool) {
+    sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+    if !ok {
+        return LogCall{}, false
+    }
+    var method logMethod
+    var logger string
+    switch loggerPackage(pkg.Info, sel.X) {
+    case "log/slog":
+        method, ok = slogMethods[sel.Sel.Name]
+        logger = "slog"
+    case "log":
+        method, ok = stdlogMethods[sel.Sel.Name]
+        logger = "log"
+    default:
+        return LogCall{}, false
+    }
+    if !ok {
+        return LogCall{}, false
+    }
+    if _, isPkg := analysis.IdentObject(pkg.Info, sel.X).(*types.PkgName); !isPkg {
+        logger = types.ExprString(sel.X)
+    }
+
+    pos := fset.Position(call.Pos())
+    result := LogCall{
+        Function: function,
+        Logger:   logger,
+        Method:   sel.Sel.Name,
+        Level:    method.level,
+        File:     pos.Filename,
+        Line:     pos.Line,
+    }
+    if method.levelAt >= 0 && method.levelAt < len(call.Args) {
+        level := call.Args[method.levelAt]
+        result.Level = types.ExprString(level)
+        if s, ok := ast.Unparen(level).(*ast.SelectorExpr); ok && slogLevels[s.Sel.Name] != "" {
+            result.Level = slogLevels[s.Sel.Name]
+        }
+    }
+    if method.msgAt >= 0 && method.msgAt < len(call.Args) {
+        result.Message, ok = analysis.ConstString(pkg.Info, call.Args[method.msgAt])
+        result.Dynamic = !ok
+    }
+    if method.argsAt >= 0 && method.argsAt <= len(call.Args) {
+        args := call.Args[method.argsAt:]
+        if call.Ellipsis.IsValid() && len(args) > 0 {
+            args = args[:len(args)-1] // slice repassada com ...: chaves desconhecidas
+        }
+        result.Keys = logKeys(pkg.Info, args, "")
+    }
+    return result, true
+}
+
+// logKeys extrai as chaves dos pares chave/valor e dos atributos (slog.String, slog.Group, ...).
+// Valores sem chave aparecem como !BADKEY, como no próprio slog
+func logKeys(info *types.Info, args []ast.Expr, prefix string) []string {
+    var keys []string
+    for i := 0; i < len(args); i++ {
+        arg := args[i]
+        if key, ok := analysis.ConstString(info, arg); ok {
+            keys = append(keys, prefix+key)
+            i++ // o próximo argumento é o valor
+            continue
+        }
+        if call, ok := ast.Unparen(arg).(*ast.CallExpr); ok && isAttrConstructor(info, call) && len(call.Args) > 0 {
+            key, ok := analysis.ConstString(info, call.Args[0])
+            if !ok {
+                continue
+            }
+            keys = append(keys, prefix+key)
+            if sel := ast.Unparen(call.Fun).(*ast.SelectorExpr); sel.Sel.Name == "Group" {
+                keys = append(keys, logKeys(info, call.Args[1:], prefix+key+".")...)
+            }
+            continue
+        }
+        if isSlogType(analysis.TypeOrNil(info, arg), "Attr") {
+            continue // atributo montado fora da chamada
+        }
+        keys = append(keys, prefix+"!BADKEY")
+    }
+    return keys
+}
+
+// isAttrConstructor reconhece slog.String, slog.Int, slog.Any, slog.Group e semelhantes
+func isAttrConstructor(info *types.Info, call *ast.CallExpr) bool {
+    sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+    if !ok {
+        return false
+    }
+    pkgName, ok := analysis.IdentObject(info, sel.X).(*types.PkgName)
+    if !ok || pkgName.Imported().Path() != "log/slog" {
+        return false
+    }
+    switch sel.Sel.Name {
+    case "String", "Int", "Int64", "Uint64", "Float64", "Bool", "Time", "Duration", "Any", "Group":
+        return true
+    }
+    return false
+}
+
+// loggerPackage retorna o pacote de log ("log/slog" ou "log") de um seletor de pacote
+// ou de um valor *slog.Logger/*log.Logger
+func loggerPackage(info *types.Info, expr ast.Expr) string {
+    if pkgName, ok := analysis.IdentObject(info, expr).(*types.PkgName); ok {
+        return pkgName.Imported().Path()
+    }
+    t := analysis.TypeOrNil(info, expr)
+    if ptr, ok := t.(*types.Pointer); ok {
+        t = ptr.Elem()
+    }
+    if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Name() == "Logger" {
+        return named.Obj().Pkg().Path()
+    }
+    return ""
+}
+
+// isSlogType informa se o tipo é log/slog.<name>
+func isSlogType(t types.Type, name string) bool {
+    named, ok := t.(*types.Named)
+    return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "log/slog" && named.Obj().Name() == name
+}
+
+// canonicalLogKey normaliza uma chave para comparar grafias: userID, user_id e
+// user-id são equivalentes, assim como abreviações conhecidas (err e error)
+func canonicalLogKey(key string) string {
+    key = strings.ToLower(key)
+    key = strings.NewReplacer("_", "", "-", "").Replace(key)
+    parts := strings.Split(key, ".")
+    for i, part := range parts {
+        if synonym, ok := logKeySynonyms[part]; ok {
+            parts[i] = synonym
+        }
+    }
+    return strings.Join(parts, ".")
+}
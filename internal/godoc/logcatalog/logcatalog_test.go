@@ -0,0 +1,65 @@
+package logcatalog_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/logcatalog"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestLoggingCatalog(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "svc/svc.go": `package svc
+
+import (
+	"context"
+	"log"
+	"log/slog"
+)
+
+type Service struct {
+	logger *slog.Logger
+}
+
+func (s *Service) Start(ctx context.Context, id string) {
+	l := s.logger.With("service_id", id)
+	l.InfoContext(ctx, "serviço iniciado", slog.Group("req", slog.String("id", id)))
+	s.logger.Log(ctx, slog.LevelWarn, "fila cheia", "size", 10)
+}
+
+func Run(err error) {
+	slog.Error("falha ao executar", "error", err)
+	slog.Warn("nova tentativa", "err", err, 42)
+	log.Printf("finalizado: %v", err)
+}
+`,
+    })
+
+    catalogs, diagnostics := logcatalog.Build(pass)
+    catalog := catalogs[pass.Packages[0].Dir]
+    if catalog == nil {
+        t.Fatal("catálogo de logs não gerado")
+    }
+
+    var got []string
+    for _, c := range catalog.Calls {
+        got = append(got, fmt.Sprintf("%s %s %s %q %v", c.Function, c.Logger, c.Level, c.Message, c.Keys))
+    }
+    want := []string{
+        `Service.Start s.logger  "" [service_id]`,
+        `Service.Start l INFO "serviço iniciado" [req req.id]`,
+        `Service.Start s.logger WARN "fila cheia" [size]`,
+        `Run slog ERROR "falha ao executar" [error]`,
+        `Run slog WARN "nova tentativa" [err !BADKEY]`,
+        `Run log PRINT "finalizado: %v" []`,
+    }
+    if strings.Join(got, "\n") != strings.Join(want, "\n") {
+        t.Errorf("chamadas =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+    }
+
+    if len(diagnostics) != 1 || diagnostics[0].Rule != "log-keys" || !strings.Contains(diagnostics[0].Message, `"err" (1), "error" (1)`) {
+        t.Errorf("diagnósticos = %v", diagnostics)
+    }
+}
@@ -0,0 +1,568 @@
+// Package messaging monta a topologia de mensageria do projeto: tópicos, filas e
+// subjects com os binários que produzem e consomem cada um
+package messaging
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+)
+
+// Bus representa a topologia de mensageria: quem publica e quem consome cada tópico
+type Bus struct {
+    Topics    []Topic      `json:"topics"              yaml:"topics"`
+    Endpoints []Endpoint   `json:"endpoints"           yaml:"endpoints"`
+    Bindings  []Binding    `json:"bindings,omitempty"  yaml:"bindings,omitempty"`  // RabbitMQ: exchange -> fila
+    Workloads map[string][]string `json:"workloads,omitempty" yaml:"workloads,omitempty"` // binário -> cargas do Kubernetes (Kind/nome)
+}
+
+// Topic agrega produtores e consumidores de um tópico, subject, exchange ou fila
+type Topic struct {
+    Broker    string   `json:"broker"              yaml:"broker"` // kafka, nats, rabbitmq
+    Name      string   `json:"name"                yaml:"name"`
+    Dynamic   bool     `json:"dynamic,omitempty"   yaml:"dynamic,omitempty"` // nome não constante (expressão no código)
+    Producers []string `json:"producers,omitempty" yaml:"producers,omitempty"` // binários (ou pacotes, sem binário)
+    Consumers []string `json:"consumers,omitempty" yaml:"consumers,omitempty"`
+}
+
+// Endpoint representa uma chamada de publicação ou assinatura encontrada no código
+type Endpoint struct {
+    Broker   string   `json:"broker"             yaml:"broker"`
+    Client   string   `json:"client"             yaml:"client"` // kafka-go, sarama, nats.go, amqp091
+    Role     string   `json:"role"               yaml:"role"`   // producer, consumer
+    Topic    string   `json:"topic"              yaml:"topic"`
+    Dynamic  bool     `json:"dynamic,omitempty"  yaml:"dynamic,omitempty"`
+    Group    string   `json:"group,omitempty"    yaml:"group,omitempty"` // consumer group, fila do NATS ou routing key
+    Package  string   `json:"package"            yaml:"package"`
+    Binaries []string `json:"binaries,omitempty" yaml:"binaries,omitempty"` // pacotes main que importam o código
+    Function string   `json:"function,omitempty" yaml:"function,omitempty"`
+    File     string   `json:"file"               yaml:"file"`
+    Line     int      `json:"line"               yaml:"line"`
+}
+
+// Binding representa um QueueBind do RabbitMQ
+type Binding struct {
+    Exchange string `json:"exchange" yaml:"exchange"`
+    Queue    string `json:"queue"    yaml:"queue"`
+    Key      string `json:"key"      yaml:"key"`
+    File     string `json:"file"     yaml:"file"`
+    Line     int    `json:"line"     yaml:"line"`
+}
+
+// Workload representa uma carga do Kubernetes e os nomes pelos quais ela pode executar
+// um binário (nome do recurso, containers, imagens e comandos)
+type Workload struct {
+    Kind    string
+    Name    string
+    Aliases []string
+}
+
+// busMethod descreve um método de cliente: papel e posição dos argumentos (sem o context)
+type busMethod struct {
+    role  string // producer, consumer, binding
+    topic int
+    group int  // -1 quando ausente
+    list  bool // o argumento do tópico é um []string
+}
+
+// busField descreve um literal composto de cliente e os campos com tópico e grupo
+type busField struct {
+    role   string
+    topic  string
+    topics string // campo []string (ex.: GroupTopics)
+    group  string
+}
+
+// busClient descreve um cliente de mensageria suportado
+type busClient struct {
+    broker   string
+    name     string
+    match    func(path string) bool
+    methods  map[string]busMethod
+    literals map[string]busField
+}
+
+// busClients lista os clientes reconhecidos
+var busClients = []busClient{
+    {
+        broker: "kafka",
+        name:   "kafka-go",
+        match:  func(path string) bool { return path == "github.com/segmentio/kafka-go" },
+        literals: map[string]busField{
+            "Writer":       {role: "producer", topic: "Topic"},
+            "Message":      {role: "producer", topic: "Topic"},
+            "ReaderConfig": {role: "consumer", topic: "Topic", topics: "GroupTopics", group: "GroupID"},
+        },
+    },
+    {
+        broker: "kafka",
+        name:   "sarama",
+        match: func(path string) bool {
+            return path == "github.com/IBM/sarama" || path == "github.com/Shopify/sarama"
+        },
+        methods: map[string]busMethod{
+            "ConsumePartition": {role: "consumer", topic: 0, group: -1},
+            "Consume":          {role: "consumer", topic: 0, group: -1, list: true},
+        },
+        literals: map[string]busField{
+            "ProducerMessage": {role: "producer", topic: "Topic"},
+        },
+    },
+    {
+        broker: "nats",
+        name:   "nats.go",
+        match: func(path string) bool {
+            return path == "github.com/nats-io/nats.go" || path == "github.com/nats-io/nats.go/jetstream"
+        },
+        methods: map[string]busMethod{
+            "Publish":                    {role: "producer", topic: 0, group: -1},
+            "PublishAsync":               {role: "producer", topic: 0, group: -1},
+            "Request":                    {role: "producer", topic: 0, group: -1},
+            "RequestWithContext":         {role: "producer", topic: 0, group: -1},
+            "Subscribe":                  {role: "consumer", topic: 0, group: -1},
+            "SubscribeSync":              {role: "consumer", topic: 0, group: -1},
+            "ChanSubscribe":              {role: "consumer", topic: 0, group: -1},
+            "QueueSubscribe":             {role: "consumer", topic: 0, group: 1},
+            "QueueSubscribeSync":         {role: "consumer", topic: 0, group: 1},
+            "ChanQueueSubscribe":         {role: "consumer", topic: 0, group: 1},
+            "QueueSubscribeSyncWithChan": {role: "consumer", topic: 0, group: 1},
+            "PullSubscribe":              {role: "consumer", topic: 0, group: 1},
+        },
+    },
+    {
+        broker: "rabbitmq",
+        name:   "amqp091",
+        match: func(path string) bool {
+            return path == "github.com/rabbitmq/amqp091-go" || path == "github.com/streadway/amqp"
+        },
+        methods: map[string]busMethod{
+            "Publish":            {role: "producer", topic: 0, group: 1},
+            "PublishWithContext": {role: "producer", topic: 0, group: 1},
+            "Consume":            {role: "consumer", topic: 0, group: -1},
+            "ConsumeWithContext": {role: "consumer", topic: 0, group: -1},
+            "QueueBind":          {role: "binding", topic: 0, group: 1},
+        },
+    },
+}
+
+// busScanner procura chamadas e literais de clientes de mensageria em um pacote
+type busScanner struct {
+    pkg     *analysis.Package
+    imports analysis.Imports // resolução de imports sem export data
+    bus     *Bus
+    fset    *token.FileSet
+}
+
+// Build detecta publicações e assinaturas dos clientes kafka-go, sarama,
+// nats.go e amqp091 e monta o mapa tópico -> produtores/consumidores
+func Build(pass *analysis.Pass, deps *depgraph.Graph) *Bus {
+    bus := &Bus{}
+    for _, pkg := range pass.Packages {
+        if pkg.Types == nil {
+            continue
+        }
+        s := &busScanner{pkg: pkg, imports: analysis.PackageImports(pkg), bus: bus, fset: pass.Fset}
+        for _, file := range pkg.Files {
+            clients := s.fileClients(file)
+            if len(clients) == 0 {
+                continue
+            }
+            for _, decl := range file.Decls {
+                function := ""
+                if fd, ok := decl.(*ast.FuncDecl); ok {
+                    if obj, ok := pkg.Info.Defs[fd.Name].(*types.Func); ok {
+                        function = obj.Name()
+                        if recv := analysis.ReceiverName(obj); recv != "" {
+                            function = recv + "." + obj.Name()
+                        }
+                    }
+                }
+                ast.Inspect(decl, func(n ast.Node) bool {
+                    switch n := n.(type) {
+                    case *ast.CompositeLit:
+                        s.inspectLiteral(n, clients, function)
+                    case *ast.CallExpr:
+                        s.inspectCall(n, clients, function)
+                    }
+                    return true
+                })
+            }
+        }
+    }
+    if len(bus.Endpoints) == 0 && len(bus.Bindings) == 0 {
+        return nil
+    }
+
+    binaries := binariesByPackage(deps, pass.Packages)
+    for i := range bus.Endpoints {
+        bus.Endpoints[i].Binaries = binaries[bus.Endpoints[i].Package]
+    }
+    bus.aggregate()
+    return bus
+}
+
+// fileClients retorna os clientes importados por um arquivo
+func (s *busScanner) fileClients(file *ast.File) []busClient {
+    var clients []busClient
+    for _, client := range busClients {
+        for _, spec := range file.Imports {
+            if client.match(strings.Trim(spec.Path.Value, `"`)) {
+                clients = append(clients, client)
+                break
+            }
+        }
+    }
+    return clients
+}
+
+// inspectLiteral reconhece literais como kafka.Writer{Topic: ...} e sarama.ProducerMessage{Topic: ...}
+func (s *busScanner) inspectLiteral(lit *ast.CompositeLit, clients []busClient, function string) {
+    if lit.Type == nil {
+        return
+    }
+    sel, ok := lit.Type.(*ast.SelectorExpr)
+    if !ok {
+        return
+    }
+    for _, client := range clients {
+        field, ok := client.literals[sel.Sel.Name]
+        if !ok || !s.imports.ImportedFrom(s.pkg.Info, lit.Type, client.match, sel.Sel.Name) {
+            continue
+        }
+        fields := analysis.LiteralFields(lit)
+        group := ""
+        if field.group != "" {
+            if expr, ok := fields[field.group]; ok {
+                group, _ = s.topicName(expr)
+            }
+        }
+        if expr, ok := fields[field.topic]; ok {
+            s.addEndpoint(client, field.role, expr, group, function, lit)
+        }
+        if expr, ok := fields[field.topics]; ok && field.topics != "" {
+            for _, topic := range s.topicList(expr) {
+                s.addEndpoint(client, field.role, topic, group, function, lit)
+            }
+        }
+        return
+    }
+}
+
+// inspectCall reconhece métodos de publicação e assinatura (nc.Publish, ch.Consume, ...)
+func (s *busScanner) inspectCall(call *ast.CallExpr, clients []busClient, function string) {
+    sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+    if !ok {
+        return
+    }
+    if selection, ok := s.pkg.Info.Selections[sel]; ok {
+        // Receptor com tipo conhecido: o método precisa ser do pacote do cliente
+        path := namedPackagePath(selection.Recv())
+        var matched []busClient
+        for _, client := range clients {
+            if client.match(path) {
+                matched = append(matched, client)
+            }
+        }
+        clients = matched
+    } else if ident, ok := sel.X.(*ast.Ident); ok {
+        // Dependência sem export data: descarta chamadas de funções de pacote
+        if _, isPkg := analysis.IdentObject(s.pkg.Info, ident).(*types.PkgName); isPkg || s.imports[ident.Name] != "" {
+            return
+        }
+    }
+
+    args := call.Args
+    if len(args) > 0 && isContextArg(s.pkg.Info, args[0]) {
+        args = args[1:]
+    }
+    for _, client := range clients {
+        method, ok := client.methods[sel.Sel.Name]
+        if !ok || method.topic >= len(args) {
+            continue
+        }
+        group := ""
+        if method.group >= 0 && method.group < len(args) {
+            group, _ = s.topicName(args[method.group])
+        }
+        switch {
+        case method.role == "binding":
+            // QueueBind(fila, routing key, exchange, ...)
+            if len(args) < 3 {
+                return
+            }
+            queue, _ := s.topicName(args[0])
+            exchange, _ := s.topicName(args[2])
+            pos := s.fset.Position(call.Pos())
+            s.bus.Bindings = append(s.bus.Bindings, Binding{Exchange: exchange, Queue: queue, Key: group, File: pos.Filename, Line: pos.Line})
+        case method.list:
+            for _, topic := range s.topicList(args[method.topic]) {
+                s.addEndpoint(client, method.role, topic, group, function, call)
+            }
+        case client.broker == "rabbitmq" && method.role == "producer":
+            // Publish(exchange, routing key, ...): na exchange padrão a routing key é a fila
+            if exchange, ok := s.topicName(args[0]); ok && exchange == "" && method.group < len(args) {
+                s.addEndpoint(client, method.role, args[method.group], "", function, call)
+                return
+            }
+            s.addEndpoint(client, method.role, args[method.topic], group, function, call)
+        default:
+            s.addEndpoint(client, method.role, args[method.topic], group, function, call)
+        }
+        return
+    }
+}
+
+// addEndpoint registra um produtor ou consumidor
+func (s *busScanner) addEndpoint(client busClient, role string, topic ast.Expr, group, function string, node ast.Node) {
+    name, ok := s.topicName(topic)
+    pos := s.fset.Position(node.Pos())
+    s.bus.Endpoints = append(s.bus.Endpoints, Endpoint{
+        Broker:   client.broker,
+        Client:   client.name,
+        Role:     role,
+        Topic:    name,
+        Dynamic:  !ok,
+        Group:    group,
+        Package:  s.pkg.ImportPath,
+        Function: function,
+        File:     pos.Filename,
+        Line:     pos.Line,
+    })
+}
+
+// topicName resolve o nome constante de um tópico; sem constante, retorna a expressão
+func (s *busScanner) topicName(expr ast.Expr) (string, bool) {
+    if value, ok := analysis.ConstString(s.pkg.Info, expr); ok {
+        return value, true
+    }
+    return types.ExprString(expr), false
+}
+
+// topicList retorna os elementos de um []string{...}; outras expressões viram um único tópico dinâmico
+func (s *busScanner) topicList(expr ast.Expr) []ast.Expr {
+    if lit, ok := ast.Unparen(expr).(*ast.CompositeLit); ok {
+        return lit.Elts
+    }
+    return []ast.Expr{expr}
+}
+
+// isContextArg informa se o argumento é um context.Context (ou, sem tipos, um ctx)
+func isContextArg(info *types.Info, expr ast.Expr) bool {
+    if t := analysis.TypeOrNil(info, expr); t != nil && analysis.IsValidType(t) {
+        return t.String() == "context.Context"
+    }
+    if ident, ok := expr.(*ast.Ident); ok {
+        return ident.Name == "ctx"
+    }
+    if call, ok := expr.(*ast.CallExpr); ok {
+        if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+            if ident, ok := sel.X.(*ast.Ident); ok {
+                return ident.Name == "context"
+            }
+        }
+    }
+    return false
+}
+
+// namedPackagePath retorna o import path do tipo nomeado (ou ponteiro para ele)
+func namedPackagePath(t types.Type) string {
+    if ptr, ok := t.(*types.Pointer); ok {
+        t = ptr.Elem()
+    }
+    if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil {
+        return named.Obj().Pkg().Path()
+    }
+    return ""
+}
+
+// binariesByPackage associa cada pacote aos pacotes main que o importam direta ou
+// indiretamente; o nome do binário é o do diretório do pacote main
+func binariesByPackage(graph *depgraph.Graph, packages []*analysis.Package) map[string][]string {
+    imports := make(map[string][]string)
+    if graph != nil {
+        for _, e := range graph.Edges {
+            imports[e.From] = append(imports[e.From], e.To)
+        }
+    }
+    result := make(map[string][]string)
+    for _, pkg := range packages {
+        if pkg.Name != "main" {
+            continue
+        }
+        binary := filepath.Base(pkg.Dir)
+        seen := map[string]bool{pkg.ImportPath: true}
+        queue := []string{pkg.ImportPath}
+        for len(queue) > 0 {
+            current := queue[0]
+            queue = queue[1:]
+            result[current] = append(result[current], binary)
+            for _, next := range imports[current] {
+                if !seen[next] {
+                    seen[next] = true
+                    queue = append(queue, next)
+                }
+            }
+        }
+    }
+    for path := range result {
+        sort.Strings(result[path])
+    }
+    return result
+}
+
+// aggregate monta a lista de tópicos a partir dos endpoints
+func (b *Bus) aggregate() {
+    index := make(map[string]int)
+    for _, e := range b.Endpoints {
+        key := e.Broker + "\x00" + e.Topic
+        i, ok := index[key]
+        if !ok {
+            i = len(b.Topics)
+            index[key] = i
+            b.Topics = append(b.Topics, Topic{Broker: e.Broker, Name: e.Topic, Dynamic: e.Dynamic})
+        }
+        participants := e.Binaries
+        if len(participants) == 0 {
+            participants = []string{e.Package}
+        }
+        for _, p := range participants {
+            if e.Role == "producer" && !analysis.ContainsString(b.Topics[i].Producers, p) {
+                b.Topics[i].Producers = append(b.Topics[i].Producers, p)
+            }
+            if e.Role == "consumer" && !analysis.ContainsString(b.Topics[i].Consumers, p) {
+                b.Topics[i].Consumers = append(b.Topics[i].Consumers, p)
+            }
+        }
+    }
+    for i := range b.Topics {
+        sort.Strings(b.Topics[i].Producers)
+        sort.Strings(b.Topics[i].Consumers)
+    }
+    sort.SliceStable(b.Topics, func(i, j int) bool {
+        if b.Topics[i].Broker != b.Topics[j].Broker {
+            return b.Topics[i].Broker < b.Topics[j].Broker
+        }
+        return b.Topics[i].Name < b.Topics[j].Name
+    })
+}
+
+// MapWorkloads associa os binários às cargas do Kubernetes cujo nome, container,
+// imagem ou comando coincide com o nome do binário
+func (b *Bus) MapWorkloads(workloads []Workload) {
+    normalize := func(name string) string {
+        return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+    }
+    binaries := make(map[string]bool)
+    for _, e := range b.Endpoints {
+        for _, binary := range e.Binaries {
+            binaries[binary] = true
+        }
+    }
+    b.Workloads = nil
+    for _, binary := range analysis.SortedKeys(binaries) {
+        for _, w := range workloads {
+            for _, alias := range w.Aliases {
+                if normalize(alias) == normalize(binary) {
+                    if b.Workloads == nil {
+                        b.Workloads = make(map[string][]string)
+                    }
+                    b.Workloads[binary] = append(b.Workloads[binary], w.Kind+"/"+w.Name)
+                    break
+                }
+            }
+        }
+        sort.Strings(b.Workloads[binary])
+    }
+}
+
+// brokerTitles nomeia os brokers no diagrama
+var brokerTitles = map[string]string{"kafka": "Kafka", "nats": "NATS", "rabbitmq": "RabbitMQ"}
+
+// Mermaid renderiza o fluxo produtores -> tópicos -> consumidores, agrupando os tópicos
+// por broker e indicando as cargas do Kubernetes que executam cada binário
+func (b *Bus) Mermaid() string {
+    escape := strings.NewReplacer(`"`, "#quot;").Replace
+    var sb strings.Builder
+    sb.WriteString("flowchart LR\n")
+
+    topicIDs := make(map[string]string)
+    topicID := func(broker, name string) string {
+        return topicIDs[broker+"\x00"+name]
+    }
+    var brokers []string
+    for _, t := range b.Topics {
+        if !analysis.ContainsString(brokers, t.Broker) {
+            brokers = append(brokers, t.Broker)
+        }
+    }
+    if len(b.Bindings) > 0 && !analysis.ContainsString(brokers, "rabbitmq") {
+        brokers = append(brokers, "rabbitmq")
+    }
+    for _, broker := range brokers {
+        sb.WriteString(fmt.Sprintf("    subgraph %s [\"%s\"]\n", broker, brokerTitles[broker]))
+        addTopic := func(name string, dynamic bool) {
+            key := broker + "\x00" + name
+            if _, ok := topicIDs[key]; ok {
+                return
+            }
+            id := fmt.Sprintf("t%d", len(topicIDs))
+            topicIDs[key] = id
+            label := escape(name)
+            if dynamic {
+                label = "<i>" + label + "</i>"
+            }
+            sb.WriteString(fmt.Sprintf("        %s[(\"%s\")]\n", id, label))
+        }
+        for _, t := range b.Topics {
+            if t.Broker == broker {
+                addTopic(t.Name, t.Dynamic)
+            }
+        }
+        if broker == "rabbitmq" {
+            for _, bd := range b.Bindings {
+                addTopic(bd.Exchange, false)
+                addTopic(bd.Queue, false)
+            }
+        }
+        sb.WriteString("    end\n")
+    }
+
+    participantIDs := make(map[string]string)
+    participant := func(name string) string {
+        if id, ok := participantIDs[name]; ok {
+            return id
+        }
+        id := fmt.Sprintf("n%d", len(participantIDs))
+        participantIDs[name] = id
+        label := escape(name)
+        for _, w := range b.Workloads[name] {
+            label += "<br/>" + escape(w)
+        }
+        sb.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", id, label))
+        return id
+    }
+    for _, t := range b.Topics {
+        for _, p := range t.Producers {
+            sb.WriteString(fmt.Sprintf("    %s --> %s\n", participant(p), topicID(t.Broker, t.Name)))
+        }
+        for _, c := range t.Consumers {
+            sb.WriteString(fmt.Sprintf("    %s --> %s\n", topicID(t.Broker, t.Name), participant(c)))
+        }
+    }
+    for _, bd := range b.Bindings {
+        from, to := topicID("rabbitmq", bd.Exchange), topicID("rabbitmq", bd.Queue)
+        if bd.Key != "" {
+            sb.WriteString(fmt.Sprintf("    %s -->|\"%s\"| %s\n", from, escape(bd.Key), to))
+        } else {
+            sb.WriteString(fmt.Sprintf("    %s --> %s\n", from, to))
+        }
+    }
+    return sb.String()
+}
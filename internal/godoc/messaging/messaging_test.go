@@ -0,0 +1,94 @@
+package messaging_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+	"github.com/edgardnogueira/aimap/internal/godoc/messaging"
+)
+
+func TestMessageBus(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "internal/events/events.go": `package events
+
+import (
+    "context"
+
+    amqp "github.com/rabbitmq/amqp091-go"
+    "github.com/nats-io/nats.go"
+    "github.com/segmentio/kafka-go"
+)
+
+const TopicOrders = "orders.created"
+
+func NewWriter() *kafka.Writer {
+    return &kafka.Writer{Topic: TopicOrders}
+}
+
+func NewReader(group string) *kafka.Reader {
+    return kafka.NewReader(kafka.ReaderConfig{Topic: TopicOrders, GroupID: "billing"})
+}
+
+func Notify(nc *nats.Conn, subject string) {
+    nc.Publish("orders.notify", nil)
+    nc.QueueSubscribe(subject, "workers", nil)
+}
+
+func Declare(ctx context.Context, ch *amqp.Channel) {
+    ch.QueueBind("emails", "order.*", "orders", false, nil)
+    ch.PublishWithContext(ctx, "orders", "order.created", false, false, amqp.Publishing{})
+    ch.Consume("emails", "", true, false, false, false, nil)
+}
+`,
+        "cmd/api/main.go": `package main
+
+import "example.com/app/internal/events"
+
+func main() {
+    events.NewWriter()
+}
+`,
+        "cmd/worker/main.go": `package main
+
+import "example.com/app/internal/events"
+
+func main() {
+    events.NewReader("")
+}
+`,
+    })
+
+    bus := messaging.Build(pass, depgraph.Build(pass))
+    if bus == nil {
+        t.Fatal("topologia de mensageria ausente")
+    }
+
+    var topics []string
+    for _, topic := range bus.Topics {
+        topics = append(topics, fmt.Sprintf("%s %s %v -> %v", topic.Broker, topic.Name, topic.Producers, topic.Consumers))
+    }
+    want := []string{
+        "kafka orders.created [api worker] -> [api worker]",
+        "nats orders.notify [api worker] -> []",
+        "nats subject [] -> [api worker]",
+        "rabbitmq emails [] -> [api worker]",
+        "rabbitmq orders [api worker] -> []",
+    }
+    if strings.Join(topics, "\n") != strings.Join(want, "\n") {
+        t.Errorf("tópicos =\n%s\nwant\n%s", strings.Join(topics, "\n"), strings.Join(want, "\n"))
+    }
+    if len(bus.Bindings) != 1 || bus.Bindings[0].Exchange != "orders" || bus.Bindings[0].Queue != "emails" || bus.Bindings[0].Key != "order.*" {
+        t.Errorf("bindings = %+v", bus.Bindings)
+    }
+
+    bus.MapWorkloads([]messaging.Workload{{Kind: "Deployment", Name: "orders-api", Aliases: []string{"orders-api", "api"}}})
+    if got := bus.Workloads["api"]; len(got) != 1 || got[0] != "Deployment/orders-api" {
+        t.Errorf("cargas do binário api = %v", got)
+    }
+    if mermaid := bus.Mermaid(); !strings.Contains(mermaid, `n0["api<br/>Deployment/orders-api"]`) || !strings.Contains(mermaid, `-->|"order.*"|`) {
+        t.Errorf("diagrama inesperado:\n%s", mermaid)
+    }
+}
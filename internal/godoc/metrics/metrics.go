@@ -0,0 +1,220 @@
+// Package metrics calcula métricas de complexidade de funções e de tamanho e
+// acoplamento de pacotes, marcando os hotspots acima dos limites configurados
+package metrics
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+)
+
+// Func representa as métricas de complexidade de uma função ou método
+type Func struct {
+    Cyclomatic int      `json:"cyclomatic"         yaml:"cyclomatic"`
+    Statements int      `json:"statements"         yaml:"statements"`
+    Params     int      `json:"params"             yaml:"params"`
+    MaxNesting int      `json:"max_nesting"        yaml:"max_nesting"`
+    Hotspots   []string `json:"hotspots,omitempty" yaml:"hotspots,omitempty"` // limites excedidos
+}
+
+// Package representa as métricas agregadas de um pacote
+type Package struct {
+    Path          string   `json:"path"               yaml:"path"`
+    ImportPath    string   `json:"import_path"        yaml:"import_path"`
+    LOC           int      `json:"loc"                yaml:"loc"`
+    Exported      int      `json:"exported"           yaml:"exported"`
+    Unexported    int      `json:"unexported"         yaml:"unexported"`
+    ExportedRatio float64  `json:"exported_ratio"     yaml:"exported_ratio"`
+    Afferent      int      `json:"afferent"           yaml:"afferent"`  // pacotes internos que importam este
+    Efferent      int      `json:"efferent"           yaml:"efferent"`  // pacotes internos importados por este
+    Instability   float64  `json:"instability"        yaml:"instability"` // Ce / (Ca + Ce)
+    Hotspots      []string `json:"hotspots,omitempty" yaml:"hotspots,omitempty"`
+}
+
+// computeFuncMetrics calcula as métricas de uma declaração de função. Funções anônimas
+// não entram na conta da função que as declara
+func computeFuncMetrics(decl *ast.FuncDecl) *Func {
+    m := &Func{Cyclomatic: 1}
+    if decl.Type.Params != nil {
+        for _, field := range decl.Type.Params.List {
+            if len(field.Names) == 0 {
+                m.Params++
+            } else {
+                m.Params += len(field.Names)
+            }
+        }
+    }
+    if decl.Body == nil {
+        return m
+    }
+
+    ast.Inspect(decl.Body, func(n ast.Node) bool {
+        switch node := n.(type) {
+        case *ast.FuncLit:
+            return false
+        case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+            m.Cyclomatic++
+        case *ast.CaseClause:
+            if node.List != nil {
+                m.Cyclomatic++
+            }
+        case *ast.CommClause:
+            if node.Comm != nil {
+                m.Cyclomatic++
+            }
+        case *ast.BinaryExpr:
+            if node.Op == token.LAND || node.Op == token.LOR {
+                m.Cyclomatic++
+            }
+        }
+        // Blocos e cláusulas de switch/select só agrupam instruções
+        switch n.(type) {
+        case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+        case ast.Stmt:
+            m.Statements++
+        }
+        return true
+    })
+
+    m.MaxNesting = nestingDepth(decl.Body, 0)
+    return m
+}
+
+// nestingDepth calcula a profundidade máxima de blocos de controle aninhados
+func nestingDepth(node ast.Node, depth int) int {
+    maxDepth := depth
+    ast.Inspect(node, func(n ast.Node) bool {
+        if n == node {
+            return true
+        }
+        var body ast.Node
+        switch s := n.(type) {
+        case *ast.IfStmt:
+            // else-if não aumenta o aninhamento
+            if d := nestingDepth(s.Body, depth+1); d > maxDepth {
+                maxDepth = d
+            }
+            if s.Else != nil {
+                elseDepth := depth + 1
+                if _, isElseIf := s.Else.(*ast.IfStmt); isElseIf {
+                    elseDepth = depth
+                }
+                if d := nestingDepth(s.Else, elseDepth); d > maxDepth {
+                    maxDepth = d
+                }
+            }
+            return false
+        case *ast.ForStmt:
+            body = s.Body
+        case *ast.RangeStmt:
+            body = s.Body
+        case *ast.SwitchStmt:
+            body = s.Body
+        case *ast.TypeSwitchStmt:
+            body = s.Body
+        case *ast.SelectStmt:
+            body = s.Body
+        case *ast.FuncLit:
+            return false
+        default:
+            return true
+        }
+        if d := nestingDepth(body, depth+1); d > maxDepth {
+            maxDepth = d
+        }
+        return false
+    })
+    return maxDepth
+}
+
+// ForFunc calcula as métricas de uma função e marca os limites excedidos
+func ForFunc(decl *ast.FuncDecl, t config.MetricsThresholds) *Func {
+    m := computeFuncMetrics(decl)
+    check := func(name string, value, limit int) {
+        if limit > 0 && value > limit {
+            m.Hotspots = append(m.Hotspots, fmt.Sprintf("%s %d > %d", name, value, limit))
+        }
+    }
+    check("cyclomatic", m.Cyclomatic, t.Cyclomatic)
+    check("statements", m.Statements, t.Statements)
+    check("params", m.Params, t.Params)
+    check("nesting", m.MaxNesting, t.Nesting)
+    return m
+}
+
+// Packages agrega as métricas por pacote a partir das ASTs e do grafo de dependências
+func Packages(pass *analysis.Pass, deps *depgraph.Graph, t config.MetricsThresholds) []Package {
+    afferent := make(map[string]int)
+    efferent := make(map[string]int)
+    if deps != nil {
+        for _, e := range deps.Edges {
+            efferent[e.From]++
+            afferent[e.To]++
+        }
+    }
+
+    var result []Package
+    for _, dir := range pass.Dirs {
+        importPath := dir.ImportPath
+        pm := Package{
+            Path:       dir.Path,
+            ImportPath: importPath,
+            Afferent:   afferent[importPath],
+            Efferent:   efferent[importPath],
+        }
+        for _, file := range dir.Files {
+            pm.LOC += pass.Fset.File(file.Pos()).LineCount()
+            countSymbols(file, &pm)
+        }
+        if total := pm.Exported + pm.Unexported; total > 0 {
+            pm.ExportedRatio = float64(pm.Exported) / float64(total)
+        }
+        if coupling := pm.Afferent + pm.Efferent; coupling > 0 {
+            pm.Instability = float64(pm.Efferent) / float64(coupling)
+        }
+
+        if t.PackageLOC > 0 && pm.LOC > t.PackageLOC {
+            pm.Hotspots = append(pm.Hotspots, fmt.Sprintf("loc %d > %d", pm.LOC, t.PackageLOC))
+        }
+        if t.Efferent > 0 && pm.Efferent > t.Efferent {
+            pm.Hotspots = append(pm.Hotspots, fmt.Sprintf("efferent %d > %d", pm.Efferent, t.Efferent))
+        }
+        result = append(result, pm)
+    }
+    return result
+}
+
+// countSymbols conta os símbolos de nível de pacote exportados e não exportados
+func countSymbols(file *ast.File, pm *Package) {
+    count := func(name string) {
+        if name == "_" || name == "init" {
+            return
+        }
+        if ast.IsExported(name) {
+            pm.Exported++
+        } else {
+            pm.Unexported++
+        }
+    }
+    for _, decl := range file.Decls {
+        switch d := decl.(type) {
+        case *ast.FuncDecl:
+            count(d.Name.Name)
+        case *ast.GenDecl:
+            for _, spec := range d.Specs {
+                switch s := spec.(type) {
+                case *ast.TypeSpec:
+                    count(s.Name.Name)
+                case *ast.ValueSpec:
+                    for _, name := range s.Names {
+                        count(name.Name)
+                    }
+                }
+            }
+        }
+    }
+}
@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+)
+
+func TestComputeFuncMetrics(t *testing.T) {
+    src := `package p
+
+func f(a, b int, c string) int {
+    if a > 0 && b > 0 {
+        for i := 0; i < a; i++ {
+            switch c {
+            case "x":
+                return i
+            default:
+            }
+        }
+    } else if b < 0 {
+        return 1
+    }
+    return 0
+}
+`
+    file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, 0)
+    if err != nil {
+        t.Fatalf("Erro ao fazer parse: %v", err)
+    }
+    m := computeFuncMetrics(file.Decls[0].(*ast.FuncDecl))
+
+    // 1 + if + && + for + case "x" + else if
+    if m.Cyclomatic != 6 {
+        t.Errorf("Cyclomatic = %d; want 6", m.Cyclomatic)
+    }
+    if m.Params != 3 {
+        t.Errorf("Params = %d; want 3", m.Params)
+    }
+    if m.MaxNesting != 3 {
+        t.Errorf("MaxNesting = %d; want 3", m.MaxNesting)
+    }
+}
+
+func TestComputeFuncMetricsClausesAndClosures(t *testing.T) {
+    src := `package p
+
+func f(ch chan int, n int) {
+    switch n {
+    case 1:
+        n++
+    default:
+        n--
+    }
+    select {
+    case v := <-ch:
+        n = v
+    default:
+    }
+    go func() {
+        if n > 0 {
+            for range ch {
+            }
+        }
+    }()
+}
+`
+    file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, 0)
+    if err != nil {
+        t.Fatalf("Erro ao fazer parse: %v", err)
+    }
+    m := computeFuncMetrics(file.Decls[0].(*ast.FuncDecl))
+
+    // switch, n++, n--, select, v := <-ch, n = v, go: cláusulas e o corpo da closure não contam
+    if m.Statements != 7 {
+        t.Errorf("Statements = %d; want 7", m.Statements)
+    }
+    // 1 + case 1 + case v := <-ch
+    if m.Cyclomatic != 3 {
+        t.Errorf("Cyclomatic = %d; want 3", m.Cyclomatic)
+    }
+    if m.MaxNesting != 1 {
+        t.Errorf("MaxNesting = %d; want 1", m.MaxNesting)
+    }
+}
+
+func TestForFuncThresholds(t *testing.T) {
+    src := `package p
+
+func f(a, b, c int) {
+    if a > 0 {
+        if b > 0 {
+            c++
+        }
+    }
+}
+`
+    file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, 0)
+    if err != nil {
+        t.Fatalf("Erro ao fazer parse: %v", err)
+    }
+    decl := file.Decls[0].(*ast.FuncDecl)
+
+    m := ForFunc(decl, config.MetricsThresholds{Cyclomatic: 2, Params: 2, Nesting: 1, Statements: 10})
+    want := []string{"cyclomatic 3 > 2", "params 3 > 2", "nesting 2 > 1"}
+    if !reflect.DeepEqual(m.Hotspots, want) {
+        t.Errorf("Hotspots = %v; want %v", m.Hotspots, want)
+    }
+
+    // Limites negativos desativam a verificação
+    m = ForFunc(decl, config.MetricsThresholds{Cyclomatic: -1, Params: -1, Nesting: -1, Statements: -1})
+    if len(m.Hotspots) != 0 {
+        t.Errorf("Hotspots = %v; want nenhum com limites desativados", m.Hotspots)
+    }
+}
+
+func TestPackages(t *testing.T) {
+    fset := token.NewFileSet()
+    parse := func(name, src string) *ast.File {
+        file, err := parser.ParseFile(fset, name, src, 0)
+        if err != nil {
+            t.Fatalf("Erro ao fazer parse: %v", err)
+        }
+        return file
+    }
+    pass := &analysis.Pass{
+        Fset: fset,
+        Dirs: []*analysis.Dir{
+            {
+                Path:       "app",
+                ImportPath: "example.com/app",
+                Files: []*ast.File{
+                    parse("app/a.go", "package app\n\nfunc Run() {}\n\nfunc helper() {}\n"),
+                    parse("app/b.go", "package app\n\nconst Version = \"1\"\n\ntype config struct{}\n\nfunc init() {}\n"),
+                },
+            },
+            {
+                Path:       "store",
+                ImportPath: "example.com/store",
+                Files:      []*ast.File{parse("store/store.go", "package store\n\ntype Store struct{}\n")},
+            },
+        },
+    }
+    deps := &depgraph.Graph{Edges: []depgraph.Edge{{From: "example.com/app", To: "example.com/store"}}}
+
+    got := Packages(pass, deps, config.MetricsThresholds{PackageLOC: 10, Efferent: -1})
+    if len(got) != 2 {
+        t.Fatalf("pacotes = %d; want 2", len(got))
+    }
+    app, store := got[0], got[1]
+    // a.go tem 5 linhas e b.go 7; init não conta como símbolo
+    if app.LOC != 12 || app.Exported != 2 || app.Unexported != 2 || app.ExportedRatio != 0.5 {
+        t.Errorf("app = %+v", app)
+    }
+    if app.Efferent != 1 || app.Afferent != 0 || app.Instability != 1 {
+        t.Errorf("acoplamento de app = %+v", app)
+    }
+    if store.Afferent != 1 || store.Instability != 0 {
+        t.Errorf("acoplamento de store = %+v", store)
+    }
+    // efferent -1 desativa o limite de acoplamento
+    if !reflect.DeepEqual(app.Hotspots, []string{"loc 12 > 10"}) || len(store.Hotspots) != 0 {
+        t.Errorf("hotspots = %v, %v; want só loc em app", app.Hotspots, store.Hotspots)
+    }
+}
@@ -0,0 +1,153 @@
+package godoc
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/gomod"
+)
+
+// moduleRoot é o go.mod mais próximo de um diretório
+type moduleRoot struct {
+    dir  string // raiz do módulo; vazio quando o diretório não pertence a um módulo
+    info *gomod.Module
+}
+
+// moduleFor procura o go.mod mais próximo de um diretório absoluto. O resultado fica
+// guardado no analisador para todos os diretórios percorridos, de modo que cada go.mod
+// é lido uma única vez por análise
+func (a *Analyzer) moduleFor(dir string) moduleRoot {
+    if cached, ok := a.modules[dir]; ok {
+        return cached
+    }
+
+    var visited []string
+    var found moduleRoot
+    for current := dir; ; {
+        if cached, ok := a.modules[current]; ok {
+            found = cached
+            break
+        }
+        visited = append(visited, current)
+        if info, err := gomod.Parse(filepath.Join(current, "go.mod")); err == nil && info.Path != "" {
+            found = moduleRoot{dir: current, info: info}
+            break
+        }
+        parent := filepath.Dir(current)
+        if parent == current {
+            break
+        }
+        current = parent
+    }
+    for _, d := range visited {
+        a.modules[d] = found
+    }
+    return found
+}
+
+// importPathForDir calcula o import path de um diretório a partir do go.mod mais próximo;
+// vazio quando o diretório não pertence a um módulo
+func (a *Analyzer) importPathForDir(dir string) string {
+    absDir, err := filepath.Abs(dir)
+    if err != nil {
+        return ""
+    }
+    root := a.moduleFor(absDir)
+    if root.info == nil {
+        return ""
+    }
+    rel, err := filepath.Rel(root.dir, absDir)
+    if err != nil || rel == "." {
+        return root.info.Path
+    }
+    return root.info.Path + "/" + filepath.ToSlash(rel)
+}
+
+// moduleForDir retorna o module path do go.mod mais próximo do diretório
+func (a *Analyzer) moduleForDir(dir string) string {
+    absDir, err := filepath.Abs(dir)
+    if err != nil {
+        return ""
+    }
+    if root := a.moduleFor(absDir); root.info != nil {
+        return root.info.Path
+    }
+    return ""
+}
+
+// findWorkspace procura o go.work mais próximo a partir de um diretório. Como no go
+// tool, GOWORK=off desativa o workspace e um caminho em GOWORK substitui a busca
+func findWorkspace(dir string) string {
+    switch gowork := os.Getenv("GOWORK"); gowork {
+    case "off":
+        return ""
+    case "":
+    default:
+        if abs, err := filepath.Abs(gowork); err == nil {
+            return abs
+        }
+        return ""
+    }
+
+    absDir, err := filepath.Abs(dir)
+    if err != nil {
+        return ""
+    }
+    for {
+        candidate := filepath.Join(absDir, "go.work")
+        if _, err := os.Stat(candidate); err == nil {
+            return candidate
+        }
+        parent := filepath.Dir(absDir)
+        if parent == absDir {
+            return ""
+        }
+        absDir = parent
+    }
+}
+
+// collectModules lê o go.mod de cada módulo com pacotes analisados e o go.work, se houver
+func (a *Analyzer) collectModules(projectDoc *ProjectDoc) {
+    seen := make(map[string]bool)
+    addModule := func(root moduleRoot) {
+        if root.info == nil || seen[root.dir] {
+            return
+        }
+        seen[root.dir] = true
+        projectDoc.Modules = append(projectDoc.Modules, *root.info)
+    }
+
+    for _, dir := range projectDoc.Directories {
+        if absDir, err := filepath.Abs(dir.Path); err == nil {
+            addModule(a.moduleFor(absDir))
+        }
+    }
+
+    // Qualquer caminho configurado pode estar dentro do workspace
+    workFile := ""
+    for _, path := range a.config.Paths {
+        found := findWorkspace(path)
+        switch {
+        case found == "" || found == workFile:
+        case workFile == "":
+            workFile = found
+        default:
+            slog.Warn("Caminhos em workspaces diferentes; usando o primeiro", "workspace", workFile, "ignored", found)
+        }
+    }
+    if workFile == "" {
+        return
+    }
+    workspace, err := gomod.ParseWork(workFile)
+    if err != nil {
+        return
+    }
+    projectDoc.Workspace = workspace
+    for _, use := range workspace.Uses {
+        useDir := filepath.Join(filepath.Dir(workFile), use)
+        if root := a.moduleFor(useDir); root.dir == useDir {
+            addModule(root)
+        }
+    }
+}
@@ -0,0 +1,111 @@
+package godoc_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+)
+
+func TestModuleCachePerAnalyzer(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "api/api.go": "package api\n",
+    })
+    importPath := func() string {
+        doc, err := godoc.NewAnalyzer(config.GolangConfig{Paths: []string{root}}).Analyze()
+        if err != nil {
+            t.Fatalf("Erro ao analisar projeto: %v", err)
+        }
+        return doc.Directories[0].ImportPath
+    }
+    if got := importPath(); got != "example.com/app/api" {
+        t.Fatalf("import path = %q", got)
+    }
+
+    // Um novo analisador relê o go.mod alterado
+    if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/renamed\n\ngo 1.22\n"), 0644); err != nil {
+        t.Fatalf("Erro ao escrever go.mod: %v", err)
+    }
+    if got := importPath(); got != "example.com/renamed/api" {
+        t.Errorf("import path = %q; want example.com/renamed/api", got)
+    }
+}
+
+func TestWorkspaceFromAnyPath(t *testing.T) {
+    t.Setenv("GOWORK", "")
+    outside := godoctest.WriteModule(t, map[string]string{
+        "tool/tool.go": "package tool\n",
+    })
+    workspace := godoctest.WriteModule(t, map[string]string{
+        "go.work":        "go 1.22\n\nuse (\n    ./svc\n    ./lib\n)\n",
+        "svc/go.mod":     "module example.com/svc\n\ngo 1.22\n",
+        "svc/svc/svc.go": "package svc\n",
+        "lib/go.mod":     "module example.com/lib\n\ngo 1.22\n",
+    })
+
+    cfg := config.GolangConfig{Paths: []string{outside, filepath.Join(workspace, "svc")}}
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    if doc.Workspace == nil || len(doc.Workspace.Uses) != 2 {
+        t.Fatalf("Workspace = %+v; want go.work do segundo caminho", doc.Workspace)
+    }
+    var modules []string
+    for _, m := range doc.Modules {
+        modules = append(modules, m.Path)
+    }
+    if got := strings.Join(modules, " "); got != "example.com/app example.com/svc example.com/lib" {
+        t.Errorf("módulos = %q", got)
+    }
+}
+
+func TestWorkspaceFromGOWORK(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "go.work":    "go 1.22\n\nuse .\n",
+        "api/api.go": "package api\n",
+    })
+    other := filepath.Join(t.TempDir(), "other.work")
+    if err := os.WriteFile(other, []byte("go 1.22\n\nuse (\n    ./a\n    ./b\n)\n"), 0644); err != nil {
+        t.Fatalf("Erro ao escrever go.work: %v", err)
+    }
+    analyze := func() *godoc.ProjectDoc {
+        doc, err := godoc.NewAnalyzer(config.GolangConfig{Paths: []string{root}}).Analyze()
+        if err != nil {
+            t.Fatalf("Erro ao analisar projeto: %v", err)
+        }
+        return doc
+    }
+
+    t.Setenv("GOWORK", "off")
+    if doc := analyze(); doc.Workspace != nil {
+        t.Errorf("GOWORK=off: Workspace = %+v; want nenhum", doc.Workspace)
+    }
+    t.Setenv("GOWORK", other)
+    if doc := analyze(); doc.Workspace == nil || len(doc.Workspace.Uses) != 2 {
+        t.Errorf("GOWORK=%s: Workspace = %+v; want o arquivo de GOWORK", other, doc.Workspace)
+    }
+}
+
+func TestDirectoryWithoutModule(t *testing.T) {
+    root := t.TempDir()
+    if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println() }\n"), 0644); err != nil {
+        t.Fatalf("Erro ao escrever arquivo: %v", err)
+    }
+
+    doc, err := godoc.NewAnalyzer(config.GolangConfig{Paths: []string{root}}).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    // O diretório continua documentado, mas sem import path e fora das análises
+    if len(doc.Directories) != 1 || doc.Directories[0].ImportPath != "" {
+        t.Fatalf("Directories = %+v; want um diretório sem import path", doc.Directories)
+    }
+    if doc.Dependencies != nil && len(doc.Dependencies.Packages) != 0 {
+        t.Errorf("Dependencies = %+v; want nenhum pacote", doc.Dependencies.Packages)
+    }
+}
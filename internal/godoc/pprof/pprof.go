@@ -0,0 +1,552 @@
+// Package pprof sobrepõe um perfil pprof (CPU, heap, ...) às funções do projeto
+package pprof
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Info resume um perfil pprof (CPU, heap, ...) sobreposto ao código do projeto
+type Info struct {
+    File       string        `json:"file"                yaml:"file"`
+    SampleType string        `json:"sample_type"         yaml:"sample_type"` // ex.: cpu/nanoseconds, inuse_space/bytes
+    Total      int64         `json:"total"               yaml:"total"`
+    Functions  []FuncCost `json:"functions"           yaml:"functions"` // funções do projeto, por custo cumulativo
+    HotPaths   []HotPath     `json:"hot_paths,omitempty" yaml:"hot_paths,omitempty"`
+    Edges      []Edge `json:"edges,omitempty"     yaml:"edges,omitempty"` // chamadas entre funções do projeto
+
+    byID map[string]FuncCost // custo das funções por ID
+}
+
+// FuncCost representa o custo de uma função no perfil
+type FuncCost struct {
+    ID          string  `json:"id"           yaml:"id"` // importpath.Func ou importpath.Tipo.Metodo
+    Name        string  `json:"name"         yaml:"name"` // pkg.Func
+    Flat        int64   `json:"flat"         yaml:"flat"`
+    Cum         int64   `json:"cum"          yaml:"cum"`
+    FlatPercent float64 `json:"flat_percent" yaml:"flat_percent"`
+    CumPercent  float64 `json:"cum_percent"  yaml:"cum_percent"`
+}
+
+// Func representa a participação de uma função ou método nas amostras do perfil
+type Func struct {
+    FlatPercent float64 `json:"flat_percent" yaml:"flat_percent"`
+    CumPercent  float64 `json:"cum_percent"  yaml:"cum_percent"`
+}
+
+// HotPath representa uma sequência de chamadas do projeto (da mais externa para a mais interna)
+type HotPath struct {
+    Frames  []string `json:"frames"  yaml:"frames"`
+    Value   int64    `json:"value"   yaml:"value"`
+    Percent float64  `json:"percent" yaml:"percent"`
+}
+
+// Edge representa o custo acumulado das chamadas de Caller para Callee
+type Edge struct {
+    Caller  string  `json:"caller"  yaml:"caller"`
+    Callee  string  `json:"callee"  yaml:"callee"`
+    Value   int64   `json:"value"   yaml:"value"`
+    Percent float64 `json:"percent" yaml:"percent"`
+}
+
+// maxHotPaths limita os caminhos quentes listados
+const maxHotPaths = 10
+
+// minHeatPercent é o custo cumulativo mínimo para uma função entrar no grafo de calor
+const minHeatPercent = 1.0
+
+// rawProfile contém os campos do profile.proto usados pelo aimap
+type rawProfile struct {
+    sampleTypes       [][2]int64 // índices de tipo e unidade na tabela de strings
+    samples           []rawSample
+    locations         map[uint64][]uint64 // location -> funções (a primeira é a mais interna)
+    functions         map[uint64]rawFunction
+    strings           []string
+    defaultSampleType int64
+}
+
+// rawFunction contém os índices do nome e do arquivo de uma função na tabela de strings
+type rawFunction struct {
+    name, filename int64
+}
+
+// frame representa uma função de uma pilha do perfil
+type frame struct {
+    name, file string
+}
+
+// rawSample representa uma amostra: pilha de locations (folha primeiro) e valores
+type rawSample struct {
+    locations []uint64
+    values    []int64
+}
+
+// closureSuffix reconhece sufixos de closures e funções aninhadas (.func1, .func1.2)
+var closureSuffix = regexp.MustCompile(`(\.func\d+|\.\d+)+$`)
+
+// parsePprof decodifica um perfil pprof (protobuf, opcionalmente com gzip)
+func parsePprof(data []byte) (*rawProfile, error) {
+    if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+        gz, err := gzip.NewReader(bytes.NewReader(data))
+        if err != nil {
+            return nil, fmt.Errorf("erro ao descompactar perfil: %w", err)
+        }
+        if data, err = io.ReadAll(gz); err != nil {
+            return nil, fmt.Errorf("erro ao descompactar perfil: %w", err)
+        }
+    }
+
+    p := &rawProfile{locations: make(map[uint64][]uint64), functions: make(map[uint64]rawFunction)}
+    err := protoFields(data, func(field int, value uint64, raw []byte) error {
+        switch field {
+        case 1: // sample_type
+            var vt [2]int64
+            err := protoFields(raw, func(f int, v uint64, _ []byte) error {
+                if f == 1 || f == 2 {
+                    vt[f-1] = int64(v)
+                }
+                return nil
+            })
+            p.sampleTypes = append(p.sampleTypes, vt)
+            return err
+        case 2: // sample
+            var s rawSample
+            err := protoFields(raw, func(f int, v uint64, b []byte) error {
+                switch f {
+                case 1:
+                    if b != nil {
+                        return protoPacked(b, func(x uint64) { s.locations = append(s.locations, x) })
+                    }
+                    s.locations = append(s.locations, v)
+                case 2:
+                    if b != nil {
+                        return protoPacked(b, func(x uint64) { s.values = append(s.values, int64(x)) })
+                    }
+                    s.values = append(s.values, int64(v))
+                }
+                return nil
+            })
+            p.samples = append(p.samples, s)
+            return err
+        case 4: // location
+            var id uint64
+            var funcs []uint64
+            err := protoFields(raw, func(f int, v uint64, b []byte) error {
+                switch f {
+                case 1:
+                    id = v
+                case 4: // line
+                    return protoFields(b, func(lf int, lv uint64, _ []byte) error {
+                        if lf == 1 {
+                            funcs = append(funcs, lv)
+                        }
+                        return nil
+                    })
+                }
+                return nil
+            })
+            p.locations[id] = funcs
+            return err
+        case 5: // function
+            var id uint64
+            var fn rawFunction
+            err := protoFields(raw, func(f int, v uint64, _ []byte) error {
+                switch f {
+                case 1:
+                    id = v
+                case 2:
+                    fn.name = int64(v)
+                case 4:
+                    fn.filename = int64(v)
+                }
+                return nil
+            })
+            p.functions[id] = fn
+            return err
+        case 6: // string_table
+            p.strings = append(p.strings, string(raw))
+        case 14: // default_sample_type
+            p.defaultSampleType = int64(value)
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, fmt.Errorf("perfil pprof inválido: %w", err)
+    }
+    return p, nil
+}
+
+// protoFields percorre os campos de uma mensagem protobuf. Para campos length-delimited
+// raw contém os bytes; para os demais, value contém o valor
+func protoFields(data []byte, fn func(field int, value uint64, raw []byte) error) error {
+    for len(data) > 0 {
+        key, n := binary.Uvarint(data)
+        if n <= 0 {
+            return errors.New("varint inválido")
+        }
+        data = data[n:]
+        field, wire := int(key>>3), key&7
+        var value uint64
+        var raw []byte
+        switch wire {
+        case 0:
+            value, n = binary.Uvarint(data)
+            if n <= 0 {
+                return errors.New("varint inválido")
+            }
+            data = data[n:]
+        case 1:
+            if len(data) < 8 {
+                return errors.New("campo fixo de 64 bits truncado")
+            }
+            value, data = binary.LittleEndian.Uint64(data), data[8:]
+        case 2:
+            size, n := binary.Uvarint(data)
+            if n <= 0 || uint64(len(data)-n) < size {
+                return errors.New("campo length-delimited truncado")
+            }
+            raw, data = data[n:n+int(size)], data[n+int(size):]
+            if raw == nil {
+                raw = []byte{}
+            }
+        case 5:
+            if len(data) < 4 {
+                return errors.New("campo fixo de 32 bits truncado")
+            }
+            value, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+        default:
+            return fmt.Errorf("tipo de campo protobuf %d não suportado", wire)
+        }
+        if err := fn(field, value, raw); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// protoPacked decodifica um campo repeated de varints empacotado
+func protoPacked(data []byte, fn func(uint64)) error {
+    for len(data) > 0 {
+        v, n := binary.Uvarint(data)
+        if n <= 0 {
+            return errors.New("varint inválido")
+        }
+        fn(v)
+        data = data[n:]
+    }
+    return nil
+}
+
+// str retorna uma entrada da tabela de strings
+func (p *rawProfile) str(i int64) string {
+    if i < 0 || int(i) >= len(p.strings) {
+        return ""
+    }
+    return p.strings[i]
+}
+
+// sampleIndex escolhe o valor analisado: default_sample_type ou o último tipo (como o go tool pprof)
+func (p *rawProfile) sampleIndex() int {
+    if p.defaultSampleType != 0 {
+        for i, st := range p.sampleTypes {
+            if st[0] == p.defaultSampleType {
+                return i
+            }
+        }
+    }
+    return len(p.sampleTypes) - 1
+}
+
+// stack retorna as funções da amostra, da folha para a raiz (inclui funções inline)
+func (p *rawProfile) stack(s rawSample) []frame {
+    var frames []frame
+    for _, loc := range s.locations {
+        for _, id := range p.locations[loc] {
+            fn := p.functions[id]
+            frames = append(frames, frame{name: p.str(fn.name), file: p.str(fn.filename)})
+        }
+    }
+    return frames
+}
+
+// pprofFuncID normaliza o nome de uma função do pprof para importpath.Func ou
+// importpath.Tipo.Metodo; closures são atribuídas à função que as declara
+func pprofFuncID(name string) string {
+    // Instâncias genéricas: Map[...] -> Map
+    for {
+        open := strings.Index(name, "[")
+        if open < 0 {
+            break
+        }
+        end := analysis.MatchingBracket(name, open)
+        if end < 0 {
+            break
+        }
+        name = name[:open] + name[end+1:]
+    }
+    name = closureSuffix.ReplaceAllString(name, "")
+    pkg := profilePackage(name)
+    rest := strings.NewReplacer("(*", "", "(", "", ")", "").Replace(strings.TrimPrefix(name, pkg+"."))
+    return pkg + "." + rest
+}
+
+// profilePackage retorna o import path de um nome de função do pprof
+func profilePackage(name string) string {
+    slash := strings.LastIndex(name, "/")
+    dot := strings.Index(name[slash+1:], ".")
+    if dot < 0 {
+        return name
+    }
+    return name[:slash+1+dot]
+}
+
+// Load lê o perfil pprof e resume o custo das funções do projeto
+func Load(pass *analysis.Pass, path string) (*Info, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("erro ao ler perfil pprof: %w", err)
+    }
+    prof, err := parsePprof(data)
+    if err != nil {
+        return nil, err
+    }
+    index := prof.sampleIndex()
+    if index < 0 {
+        return nil, fmt.Errorf("perfil pprof sem tipos de amostra: %s", path)
+    }
+
+    projectPkgs := make(map[string]bool)
+    var mains []string // import paths dos pacotes main
+    for _, dir := range pass.Dirs {
+        projectPkgs[dir.ImportPath] = true
+        if len(dir.Files) > 0 && dir.Files[0].Name.Name == "main" {
+            mains = append(mains, dir.ImportPath)
+        }
+    }
+    // O pprof identifica as funções de programas pelo pacote "main", não pelo import
+    // path; o arquivo da função indica a qual dos pacotes main ela pertence
+    funcID := func(f frame) string {
+        id := pprofFuncID(f.name)
+        if profilePackage(id) == "main" {
+            pkg := mainPackage(mains, f.file)
+            if pkg == "" {
+                return ""
+            }
+            id = pkg + strings.TrimPrefix(id, "main")
+        }
+        if !projectPkgs[profilePackage(id)] {
+            return ""
+        }
+        return id
+    }
+
+    info := &Info{
+        File:       path,
+        SampleType: prof.str(prof.sampleTypes[index][0]) + "/" + prof.str(prof.sampleTypes[index][1]),
+    }
+    flat := make(map[string]int64)
+    cum := make(map[string]int64)
+    paths := make(map[string]int64)
+    edges := make(map[[2]string]int64)
+    for _, s := range prof.samples {
+        if index >= len(s.values) || s.values[index] == 0 {
+            continue
+        }
+        value := s.values[index]
+        info.Total += value
+
+        frames := prof.stack(s)
+        var ids []string // funções do projeto, da raiz para a folha, sem repetições consecutivas
+        seen := make(map[string]bool)
+        for i := len(frames) - 1; i >= 0; i-- {
+            id := funcID(frames[i])
+            if id == "" {
+                continue
+            }
+            if !seen[id] {
+                seen[id] = true
+                cum[id] += value
+            }
+            if len(ids) == 0 || ids[len(ids)-1] != id {
+                ids = append(ids, id)
+            }
+        }
+        if len(frames) > 0 {
+            if leaf := funcID(frames[0]); leaf != "" {
+                flat[leaf] += value
+            }
+        }
+        if len(ids) > 0 {
+            paths[strings.Join(ids, "\x00")] += value
+        }
+        for i := 1; i < len(ids); i++ {
+            edges[[2]string{ids[i-1], ids[i]}] += value
+        }
+    }
+
+    percent := func(v int64) float64 {
+        if info.Total == 0 {
+            return 0
+        }
+        return float64(v) * 100 / float64(info.Total)
+    }
+    for _, id := range analysis.SortedKeys(cum) {
+        info.Functions = append(info.Functions, FuncCost{
+            ID:          id,
+            Name:        profileShortName(id),
+            Flat:        flat[id],
+            Cum:         cum[id],
+            FlatPercent: percent(flat[id]),
+            CumPercent:  percent(cum[id]),
+        })
+    }
+    sort.SliceStable(info.Functions, func(i, j int) bool { return info.Functions[i].Cum > info.Functions[j].Cum })
+
+    for _, key := range analysis.SortedKeys(paths) {
+        var frames []string
+        for _, id := range strings.Split(key, "\x00") {
+            frames = append(frames, profileShortName(id))
+        }
+        info.HotPaths = append(info.HotPaths, HotPath{Frames: frames, Value: paths[key], Percent: percent(paths[key])})
+    }
+    sort.SliceStable(info.HotPaths, func(i, j int) bool { return info.HotPaths[i].Value > info.HotPaths[j].Value })
+    if len(info.HotPaths) > maxHotPaths {
+        info.HotPaths = info.HotPaths[:maxHotPaths]
+    }
+
+    for key, value := range edges {
+        info.Edges = append(info.Edges, Edge{Caller: key[0], Callee: key[1], Value: value, Percent: percent(value)})
+    }
+    sort.Slice(info.Edges, func(i, j int) bool {
+        if info.Edges[i].Value != info.Edges[j].Value {
+            return info.Edges[i].Value > info.Edges[j].Value
+        }
+        return info.Edges[i].Caller+info.Edges[i].Callee < info.Edges[j].Caller+info.Edges[j].Callee
+    })
+
+    info.byID = make(map[string]FuncCost)
+    for _, f := range info.Functions {
+        info.byID[f.ID] = f
+    }
+    return info, nil
+}
+
+// Func retorna a participação no perfil de uma função (Func ou Tipo.Metodo) do pacote
+func (p *Info) Func(importPath, name string) *Func {
+    if f, ok := p.byID[importPath+"."+name]; ok {
+        return &Func{FlatPercent: f.FlatPercent, CumPercent: f.CumPercent}
+    }
+    return nil
+}
+
+// mainPackage escolhe, entre os pacotes main do projeto, aquele cujo import path
+// compartilha mais elementos finais com o diretório do arquivo registrado no perfil
+// (absoluto ou, com -trimpath, relativo ao módulo). Sem arquivo, só resolve quando
+// há um único pacote main
+func mainPackage(mains []string, file string) string {
+    if file == "" {
+        if len(mains) == 1 {
+            return mains[0]
+        }
+        return ""
+    }
+    dir := strings.Split(path.Dir(filepath.ToSlash(file)), "/")
+    best, bestLen := "", 0
+    for _, pkg := range mains {
+        elems := strings.Split(pkg, "/")
+        n := 0
+        for n < len(elems) && n < len(dir) && elems[len(elems)-1-n] == dir[len(dir)-1-n] {
+            n++
+        }
+        if n > bestLen {
+            best, bestLen = pkg, n
+        }
+    }
+    return best
+}
+
+// profileShortName reduz importpath.Func a pkg.Func
+func profileShortName(id string) string {
+    return id[strings.LastIndex(id, "/")+1:]
+}
+
+// heatClass classifica o custo cumulativo para colorir o grafo
+func heatClass(percent float64) string {
+    switch {
+    case percent >= 20:
+        return "hot"
+    case percent >= 5:
+        return "warm"
+    }
+    return "cool"
+}
+
+// heatColors associa as classes de calor às cores do grafo
+var heatColors = map[string]string{"hot": "#e74c3c", "warm": "#f39c12", "cool": "#f7dc6f"}
+
+// heatFunctions retorna as funções com custo cumulativo acima do mínimo do grafo de calor
+func (p *Info) heatFunctions() (map[string]bool, []FuncCost) {
+    include := make(map[string]bool)
+    var funcs []FuncCost
+    for _, f := range p.Functions {
+        if f.CumPercent >= minHeatPercent {
+            include[f.ID] = true
+            funcs = append(funcs, f)
+        }
+    }
+    return include, funcs
+}
+
+// Mermaid renderiza o grafo de calor: funções do projeto coloridas pelo custo cumulativo
+// e arestas com a porcentagem das amostras que passam pela chamada
+func (p *Info) Mermaid() string {
+    include, funcs := p.heatFunctions()
+    var sb strings.Builder
+    sb.WriteString("flowchart LR\n")
+    ids := make(map[string]string)
+    for i, f := range funcs {
+        ids[f.ID] = fmt.Sprintf("f%d", i)
+        sb.WriteString(fmt.Sprintf("    f%d[\"%s<br/>flat %.1f%% · cum %.1f%%\"]:::%s\n", i, f.Name, f.FlatPercent, f.CumPercent, heatClass(f.CumPercent)))
+    }
+    for _, e := range p.Edges {
+        if include[e.Caller] && include[e.Callee] {
+            sb.WriteString(fmt.Sprintf("    %s -->|%.1f%%| %s\n", ids[e.Caller], e.Percent, ids[e.Callee]))
+        }
+    }
+    for _, class := range []string{"hot", "warm", "cool"} {
+        sb.WriteString(fmt.Sprintf("    classDef %s fill:%s\n", class, heatColors[class]))
+    }
+    return sb.String()
+}
+
+// DOT renderiza o grafo de calor no formato Graphviz DOT
+func (p *Info) DOT() string {
+    include, funcs := p.heatFunctions()
+    var sb strings.Builder
+    sb.WriteString("digraph \"pprof\" {\n")
+    sb.WriteString("    rankdir=LR;\n")
+    sb.WriteString("    node [shape=box, style=filled, fontname=\"Helvetica\"];\n")
+    for _, f := range funcs {
+        label := fmt.Sprintf("%s\\nflat %.1f%% · cum %.1f%%", f.Name, f.FlatPercent, f.CumPercent)
+        sb.WriteString(fmt.Sprintf("    %q [label=\"%s\", fillcolor=%q];\n", f.ID, label, heatColors[heatClass(f.CumPercent)]))
+    }
+    for _, e := range p.Edges {
+        if include[e.Caller] && include[e.Callee] {
+            sb.WriteString(fmt.Sprintf("    %q -> %q [label=\"%.1f%%\"];\n", e.Caller, e.Callee, e.Percent))
+        }
+    }
+    sb.WriteString("}\n")
+    return sb.String()
+}
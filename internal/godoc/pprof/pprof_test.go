@@ -0,0 +1,170 @@
+package pprof_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+	"github.com/edgardnogueira/aimap/internal/godoc/pprof"
+)
+
+// pbField codifica um campo protobuf varint
+func pbField(b []byte, field int, v uint64) []byte {
+    b = binary.AppendUvarint(b, uint64(field)<<3)
+    return binary.AppendUvarint(b, v)
+}
+
+// pbBytes codifica um campo protobuf length-delimited
+func pbBytes(b []byte, field int, data []byte) []byte {
+    b = binary.AppendUvarint(b, uint64(field)<<3|2)
+    b = binary.AppendUvarint(b, uint64(len(data)))
+    return append(b, data...)
+}
+
+// profileFunc representa uma função do perfil de teste
+type profileFunc struct {
+    name, file string
+}
+
+// profileSample representa uma amostra do perfil de teste: funções da folha para a raiz
+type profileSample struct {
+    funcs []uint64
+    cpu   uint64
+}
+
+// writeProfile grava um perfil de CPU com gzip, com uma location por função
+func writeProfile(t *testing.T, funcs []profileFunc, samples []profileSample) string {
+    t.Helper()
+    strs := []string{"", "samples", "count", "cpu", "nanoseconds"}
+    str := func(v string) uint64 {
+        strs = append(strs, v)
+        return uint64(len(strs) - 1)
+    }
+    var prof []byte
+    prof = pbBytes(prof, 1, pbField(pbField(nil, 1, 1), 2, 2))
+    prof = pbBytes(prof, 1, pbField(pbField(nil, 1, 3), 2, 4))
+    // Valores [samples, cpu]
+    for _, s := range samples {
+        var packed []byte
+        for _, l := range s.funcs {
+            packed = binary.AppendUvarint(packed, l)
+        }
+        prof = pbBytes(prof, 2, pbField(pbField(pbBytes(nil, 1, packed), 2, 1), 2, s.cpu))
+    }
+    for i, f := range funcs {
+        id := uint64(i + 1)
+        prof = pbBytes(prof, 4, pbBytes(pbField(nil, 1, id), 4, pbField(pbField(nil, 1, id), 2, 10)))
+        fn := pbField(pbField(nil, 1, id), 2, str(f.name))
+        if f.file != "" {
+            fn = pbField(fn, 4, str(f.file))
+        }
+        prof = pbBytes(prof, 5, fn)
+    }
+    for _, v := range strs {
+        prof = pbBytes(prof, 6, []byte(v))
+    }
+    var buf bytes.Buffer
+    gz := gzip.NewWriter(&buf)
+    gz.Write(prof)
+    gz.Close()
+    path := filepath.Join(t.TempDir(), "cpu.pprof")
+    if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+        t.Fatal(err)
+    }
+    return path
+}
+
+func TestProfile(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "calc/calc.go": `package calc
+
+type Calc struct{}
+
+func Add(a, b int) int {
+    return a + b
+}
+
+func (c *Calc) Div(a, b int) int {
+    return a / b
+}
+`,
+    })
+
+    // Pilhas da folha para a raiz, com índices de funcs a partir de 1
+    path := writeProfile(t, []profileFunc{
+        {name: "example.com/app/calc.Add"},
+        {name: "example.com/app/calc.(*Calc).Div"},
+        {name: "runtime.main"},
+        {name: "example.com/app/calc.(*Calc).Div.func1"},
+    }, []profileSample{{[]uint64{1, 2, 3}, 60}, {[]uint64{4, 2, 3}, 30}, {[]uint64{3}, 10}})
+
+    info, err := pprof.Load(pass, path)
+    if err != nil {
+        t.Fatalf("Erro ao ler perfil: %v", err)
+    }
+    if info.Total != 100 || info.SampleType != "cpu/nanoseconds" {
+        t.Fatalf("perfil = %+v; want total 100 cpu/nanoseconds", info)
+    }
+
+    if add := info.Func("example.com/app/calc", "Add"); add == nil || add.FlatPercent != 60 || add.CumPercent != 60 {
+        t.Errorf("perfil de Add = %+v; want flat 60, cum 60", add)
+    }
+    // A closure Div.func1 conta como Div
+    if div := info.Func("example.com/app/calc", "Calc.Div"); div == nil || div.FlatPercent != 30 || div.CumPercent != 90 {
+        t.Errorf("perfil de Calc.Div = %+v; want flat 30, cum 90", div)
+    }
+
+    if len(info.HotPaths) != 2 || strings.Join(info.HotPaths[0].Frames, " ") != "calc.Calc.Div calc.Add" || info.HotPaths[0].Percent != 60 {
+        t.Errorf("hot paths = %+v", info.HotPaths)
+    }
+    mermaid := info.Mermaid()
+    for _, want := range []string{"f0 -->|60.0%| f1", "calc.Calc.Div<br/>flat 30.0% · cum 90.0%\"]:::hot", "calc.Add"} {
+        if !strings.Contains(mermaid, want) {
+            t.Errorf("grafo de calor sem %q:\n%s", want, mermaid)
+        }
+    }
+}
+
+func TestProfileMainPackages(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "cmd/api/main.go": `package main
+
+func main() { run() }
+
+func run() {}
+`,
+        "cmd/worker/main.go": `package main
+
+func main() { run() }
+
+func run() {}
+`,
+    })
+
+    // Os dois binários aparecem como "main" no perfil; o arquivo os distingue
+    path := writeProfile(t, []profileFunc{
+        {name: "main.run", file: "/build/app/cmd/api/main.go"},
+        {name: "main.main", file: "/build/app/cmd/api/main.go"},
+        {name: "main.run", file: "example.com/app/cmd/worker/main.go"},
+        {name: "main.main", file: "example.com/app/cmd/worker/main.go"},
+    }, []profileSample{{[]uint64{1, 2}, 70}, {[]uint64{3, 4}, 30}})
+
+    info, err := pprof.Load(pass, path)
+    if err != nil {
+        t.Fatalf("Erro ao ler perfil: %v", err)
+    }
+    want := map[string]float64{"example.com/app/cmd/api": 70, "example.com/app/cmd/worker": 30}
+    for importPath, flat := range want {
+        if run := info.Func(importPath, "run"); run == nil || run.FlatPercent != flat {
+            t.Errorf("perfil de run em %s = %+v; want flat %.0f", importPath, run, flat)
+        }
+    }
+    if len(info.HotPaths) != 2 || strings.Join(info.HotPaths[0].Frames, " ") != "api.main api.run" {
+        t.Errorf("hot paths = %+v", info.HotPaths)
+    }
+}
@@ -0,0 +1,639 @@
+// Package routes extrai as rotas HTTP registradas com net/http, chi, gin e echo
+package routes
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Frameworks HTTP reconhecidos na extração de rotas
+const (
+    FrameworkNetHTTP = "net/http"
+    FrameworkChi     = "chi"
+    FrameworkGin     = "gin"
+    FrameworkEcho    = "echo"
+)
+
+// routeImports associa o prefixo do import path de cada roteador ao framework
+var routeImports = map[string]string{
+    "github.com/go-chi/chi":    FrameworkChi,
+    "github.com/gin-gonic/gin": FrameworkGin,
+    "github.com/labstack/echo": FrameworkEcho,
+}
+
+// chiMethods mapeia os métodos do chi.Router para o verbo HTTP
+var chiMethods = map[string]string{
+    "Get": "GET", "Post": "POST", "Put": "PUT", "Patch": "PATCH", "Delete": "DELETE",
+    "Head": "HEAD", "Options": "OPTIONS", "Connect": "CONNECT", "Trace": "TRACE",
+}
+
+// routerConstructors são as funções que criam roteadores em cada framework
+var routerConstructors = map[string]map[string]bool{
+    FrameworkChi:  {"NewRouter": true, "NewMux": true},
+    FrameworkGin:  {"New": true, "Default": true},
+    FrameworkEcho: {"New": true},
+}
+
+// routerInterfaces são as interfaces de roteador implementadas por tipos do projeto
+var routerInterfaces = map[string][]string{
+    FrameworkChi: {"Router"},
+    FrameworkGin: {"IRouter", "IRoutes"},
+}
+
+// upperMethods são os métodos de registro do gin e do echo (r.GET, e.POST, ...)
+var upperMethods = map[string]bool{
+    "GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true,
+    "HEAD": true, "OPTIONS": true, "CONNECT": true, "TRACE": true, "Any": true,
+}
+
+// Route representa um endpoint HTTP registrado no código
+type Route struct {
+    Method    string          `json:"method"               yaml:"method"` // ANY quando o padrão não restringe o método
+    Path      string          `json:"path"                 yaml:"path"`
+    Handler   string          `json:"handler"              yaml:"handler"`
+    HandlerID string          `json:"handler_id,omitempty" yaml:"handler_id,omitempty"` // nome completo da função, quando resolvida
+    Doc       string          `json:"doc,omitempty"        yaml:"doc,omitempty"`        // comentário de documentação do handler
+    Request   string          `json:"request,omitempty"    yaml:"request,omitempty"`    // tipo decodificado do corpo da requisição
+    Responses []RouteResponse `json:"responses,omitempty"  yaml:"responses,omitempty"`
+    Framework string          `json:"framework"            yaml:"framework"`
+    Package   string          `json:"package"              yaml:"package"`
+    File      string          `json:"file"                 yaml:"file"`
+    Line      int             `json:"line"                 yaml:"line"`
+}
+
+// RouteResponse representa uma resposta JSON escrita pelo handler
+type RouteResponse struct {
+    Status int    `json:"status"         yaml:"status"`
+    Type   string `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+// handlerDecl guarda a declaração de uma função candidata a handler
+type handlerDecl struct {
+    decl *ast.FuncDecl
+    pkg  *analysis.Package
+}
+
+// routeScanner acompanha os prefixos de grupos e sub-roteadores de um pacote
+type routeScanner struct {
+    fset     *token.FileSet
+    pkg      *analysis.Package
+    handlers map[*types.Func]handlerDecl
+    prefixes map[types.Object]string
+    origins  map[types.Object]string // framework de variáveis, parâmetros e campos de roteador
+    routes   []Route
+}
+
+// Build encontra os registros de rotas do net/http (padrões do Go 1.22),
+// chi, gin e echo em todos os pacotes analisados
+func Build(pass *analysis.Pass) []Route {
+    handlers := make(map[*types.Func]handlerDecl)
+    for _, pkg := range pass.Packages {
+        pkg.FuncDecls(func(decl *ast.FuncDecl, obj *types.Func) {
+            handlers[obj] = handlerDecl{decl: decl, pkg: pkg}
+        })
+    }
+
+    var routes []Route
+    for _, pkg := range pass.Packages {
+        s := &routeScanner{
+            fset:     pass.Fset,
+            pkg:      pkg,
+            handlers: handlers,
+            prefixes: make(map[types.Object]string),
+            origins:  make(map[types.Object]string),
+        }
+        for _, file := range pkg.Files {
+            s.trackOrigins(file)
+        }
+        for _, file := range pkg.Files {
+            frameworks := fileFrameworks(file)
+            ast.Inspect(file, func(n ast.Node) bool {
+                switch node := n.(type) {
+                case *ast.AssignStmt:
+                    s.trackGroups(node)
+                case *ast.CallExpr:
+                    s.inspectCall(node, frameworks)
+                }
+                return true
+            })
+        }
+        routes = append(routes, s.routes...)
+    }
+    return routes
+}
+
+// routerPackage retorna o framework de um import path de roteador
+func routerPackage(importPath string) string {
+    for prefix, framework := range routeImports {
+        if strings.HasPrefix(importPath, prefix) {
+            return framework
+        }
+    }
+    return ""
+}
+
+// trackOrigins registra o framework de parâmetros, campos e variáveis declarados com
+// tipos de roteador (r chi.Router, r *gin.Engine) ou criados por construtores e grupos
+// (chi.NewRouter(), echo.New(), r.Group("/v1")). Serve de alternativa ao tipo quando o
+// pacote do roteador não tem export data e a verificação de tipos não o resolve
+func (s *routeScanner) trackOrigins(file *ast.File) {
+    record := func(x ast.Expr, framework string) {
+        if obj := s.objectOf(x); obj != nil && framework != "" {
+            s.origins[obj] = framework
+        }
+    }
+    ast.Inspect(file, func(n ast.Node) bool {
+        switch node := n.(type) {
+        case *ast.Field:
+            for _, name := range node.Names {
+                record(name, s.typeExprFramework(node.Type))
+            }
+        case *ast.ValueSpec:
+            for i, name := range node.Names {
+                framework := ""
+                if node.Type != nil {
+                    framework = s.typeExprFramework(node.Type)
+                } else if i < len(node.Values) && len(node.Names) == len(node.Values) {
+                    framework = s.constructorFramework(node.Values[i])
+                }
+                record(name, framework)
+            }
+        case *ast.AssignStmt:
+            if len(node.Lhs) == len(node.Rhs) {
+                for i, lhs := range node.Lhs {
+                    record(lhs, s.constructorFramework(node.Rhs[i]))
+                }
+            }
+        }
+        return true
+    })
+}
+
+// typeExprFramework identifica o framework de uma expressão de tipo como *gin.Engine
+func (s *routeScanner) typeExprFramework(expr ast.Expr) string {
+    if star, ok := expr.(*ast.StarExpr); ok {
+        expr = star.X
+    }
+    sel, ok := expr.(*ast.SelectorExpr)
+    if !ok {
+        return ""
+    }
+    if pkgName, ok := analysis.IdentObject(s.pkg.Info, sel.X).(*types.PkgName); ok {
+        return routerPackage(pkgName.Imported().Path())
+    }
+    return ""
+}
+
+// constructorFramework identifica o framework de um roteador criado por construtor
+// (chi.NewRouter()) ou derivado de outro roteador (r.Group("/v1"), r.With(mw))
+func (s *routeScanner) constructorFramework(expr ast.Expr) string {
+    call, ok := ast.Unparen(expr).(*ast.CallExpr)
+    if !ok {
+        return ""
+    }
+    sel, ok := call.Fun.(*ast.SelectorExpr)
+    if !ok {
+        return ""
+    }
+    if pkgName, ok := analysis.IdentObject(s.pkg.Info, sel.X).(*types.PkgName); ok {
+        framework := routerPackage(pkgName.Imported().Path())
+        if routerConstructors[framework][sel.Sel.Name] {
+            return framework
+        }
+        return ""
+    }
+    switch sel.Sel.Name {
+    case "Group", "Route", "With":
+        return s.routerFramework(sel.X)
+    }
+    return ""
+}
+
+// routerFramework identifica o framework do roteador que recebe uma chamada. Com o tipo
+// resolvido, exige um tipo do chi, gin ou echo, ou um tipo do projeto que implemente
+// chi.Router ou gin.IRoutes; sem ele, usa a origem registrada por trackOrigins
+func (s *routeScanner) routerFramework(x ast.Expr) string {
+    if call, ok := ast.Unparen(x).(*ast.CallExpr); ok {
+        // r.With(middleware).Get(...) e chi.NewRouter().Get(...)
+        if framework := s.constructorFramework(call); framework != "" {
+            return framework
+        }
+    }
+    t := s.pkg.Info.TypeOf(x)
+    if ptr, ok := t.(*types.Pointer); ok {
+        t = ptr.Elem()
+    }
+    if !analysis.IsValidType(t) {
+        if obj := s.objectOf(x); obj != nil {
+            return s.origins[obj]
+        }
+        return ""
+    }
+    if named, ok := types.Unalias(t).(*types.Named); ok && named.Obj().Pkg() != nil {
+        if framework := routerPackage(named.Obj().Pkg().Path()); framework != "" {
+            return framework
+        }
+    }
+    for _, imported := range s.pkg.Types.Imports() {
+        framework := routerPackage(imported.Path())
+        for _, name := range routerInterfaces[framework] {
+            obj, ok := imported.Scope().Lookup(name).(*types.TypeName)
+            if !ok {
+                continue
+            }
+            if iface, ok := obj.Type().Underlying().(*types.Interface); ok && iface.NumMethods() > 0 &&
+                (types.Implements(t, iface) || types.Implements(types.NewPointer(t), iface)) {
+                return framework
+            }
+        }
+    }
+    return ""
+}
+
+// fileFrameworks retorna os frameworks de roteamento importados por um arquivo
+func fileFrameworks(file *ast.File) map[string]bool {
+    frameworks := make(map[string]bool)
+    for _, imp := range file.Imports {
+        importPath, _ := strconv.Unquote(imp.Path.Value)
+        if importPath == "net/http" {
+            frameworks[FrameworkNetHTTP] = true
+        }
+        for prefix, framework := range routeImports {
+            if strings.HasPrefix(importPath, prefix) {
+                frameworks[framework] = true
+            }
+        }
+    }
+    return frameworks
+}
+
+// trackGroups registra o prefixo de variáveis criadas por r.Group("/v1") (gin/echo)
+func (s *routeScanner) trackGroups(assign *ast.AssignStmt) {
+    if len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+        return
+    }
+    call, ok := assign.Rhs[0].(*ast.CallExpr)
+    if !ok {
+        return
+    }
+    sel, ok := call.Fun.(*ast.SelectorExpr)
+    if !ok || sel.Sel.Name != "Group" || len(call.Args) == 0 {
+        return
+    }
+    prefix, ok := s.stringValue(call.Args[0])
+    if !ok {
+        return
+    }
+    if obj := s.objectOf(assign.Lhs[0]); obj != nil {
+        s.prefixes[obj] = joinRoutePath(s.prefixOf(sel.X), prefix)
+    }
+}
+
+// inspectCall reconhece uma chamada de registro de rota
+func (s *routeScanner) inspectCall(call *ast.CallExpr, frameworks map[string]bool) {
+    sel, ok := call.Fun.(*ast.SelectorExpr)
+    if !ok {
+        return
+    }
+    name := sel.Sel.Name
+    args := call.Args
+
+    if (name == "Handle" || name == "HandleFunc") && len(args) == 2 && s.isNetHTTP(sel.X, frameworks) {
+        pattern, ok := s.stringValue(args[0])
+        if !ok {
+            return
+        }
+        method, routePath := splitServeMuxPattern(pattern)
+        s.add(call, FrameworkNetHTTP, method, joinRoutePath(s.prefixOf(sel.X), routePath), args[1])
+        return
+    }
+
+    // Só chamadas sobre roteadores do chi, gin ou echo registram rotas
+    framework := s.routerFramework(sel.X)
+    switch {
+    case framework == FrameworkChi:
+        s.inspectChi(call, sel, args)
+
+    case (framework == FrameworkGin || framework == FrameworkEcho) && len(args) >= 2:
+        // gin: handlers ao final (middlewares antes); echo: handler logo após o path
+        handler := args[len(args)-1]
+        if framework == FrameworkEcho {
+            handler = args[1]
+        }
+        if upperMethods[name] {
+            routePath, ok := s.stringValue(args[0])
+            if !ok {
+                return
+            }
+            method := name
+            if name == "Any" {
+                method = "ANY"
+            }
+            s.add(call, framework, method, joinRoutePath(s.prefixOf(sel.X), routePath), handler)
+            return
+        }
+        // r.Handle("GET", "/x", h) (gin) e e.Add("GET", "/x", h) (echo)
+        if (name == "Handle" || name == "Add") && len(args) >= 3 {
+            method, okMethod := s.stringValue(args[0])
+            routePath, okPath := s.stringValue(args[1])
+            if !okMethod || !okPath {
+                return
+            }
+            handler = args[len(args)-1]
+            if framework == FrameworkEcho {
+                handler = args[2]
+            }
+            s.add(call, framework, strings.ToUpper(method), joinRoutePath(s.prefixOf(sel.X), routePath), handler)
+        }
+    }
+}
+
+// inspectChi trata os métodos do chi.Router, incluindo sub-rotas via Route e Group
+func (s *routeScanner) inspectChi(call *ast.CallExpr, sel *ast.SelectorExpr, args []ast.Expr) {
+    name := sel.Sel.Name
+    prefix := s.prefixOf(sel.X)
+
+    switch {
+    case chiMethods[name] != "" && len(args) == 2:
+        if routePath, ok := s.stringValue(args[0]); ok {
+            s.add(call, FrameworkChi, chiMethods[name], joinRoutePath(prefix, routePath), args[1])
+        }
+    case (name == "Handle" || name == "HandleFunc") && len(args) == 2:
+        // O chi v5 também aceita padrões com método ("GET /users")
+        if pattern, ok := s.stringValue(args[0]); ok {
+            method, routePath := splitServeMuxPattern(pattern)
+            s.add(call, FrameworkChi, method, joinRoutePath(prefix, routePath), args[1])
+        }
+    case (name == "Method" || name == "MethodFunc") && len(args) == 3:
+        method, okMethod := s.stringValue(args[0])
+        routePath, okPath := s.stringValue(args[1])
+        if okMethod && okPath {
+            s.add(call, FrameworkChi, strings.ToUpper(method), joinRoutePath(prefix, routePath), args[2])
+        }
+    case name == "Route" && len(args) == 2:
+        if routePath, ok := s.stringValue(args[0]); ok {
+            s.bindRouterParam(args[1], joinRoutePath(prefix, routePath))
+        }
+    case name == "Group" && len(args) == 1:
+        s.bindRouterParam(args[0], prefix)
+    }
+}
+
+// bindRouterParam associa o prefixo ao parâmetro do roteador de uma função literal
+// (r.Route("/users", func(r chi.Router) { ... }))
+func (s *routeScanner) bindRouterParam(expr ast.Expr, prefix string) {
+    lit, ok := expr.(*ast.FuncLit)
+    if !ok || len(lit.Type.Params.List) == 0 || len(lit.Type.Params.List[0].Names) == 0 {
+        return
+    }
+    if obj := s.pkg.Info.Defs[lit.Type.Params.List[0].Names[0]]; obj != nil {
+        s.prefixes[obj] = prefix
+    }
+}
+
+// isNetHTTP verifica se o receptor é o pacote net/http ou um *http.ServeMux
+func (s *routeScanner) isNetHTTP(x ast.Expr, frameworks map[string]bool) bool {
+    if id, ok := x.(*ast.Ident); ok {
+        if pkgName, ok := s.pkg.Info.Uses[id].(*types.PkgName); ok {
+            return pkgName.Imported().Path() == "net/http"
+        }
+    }
+    if t := s.pkg.Info.TypeOf(x); t != nil && t != types.Typ[types.Invalid] {
+        if ptr, ok := t.(*types.Pointer); ok {
+            t = ptr.Elem()
+        }
+        if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil {
+            return named.Obj().Pkg().Path() == "net/http" && named.Obj().Name() == "ServeMux"
+        }
+        return false
+    }
+    // Sem informação de tipos, só assume net/http quando nenhum outro roteador é importado
+    return frameworks[FrameworkNetHTTP] && len(frameworks) == 1
+}
+
+// add registra uma rota resolvendo o handler
+func (s *routeScanner) add(call *ast.CallExpr, framework, method, routePath string, handler ast.Expr) {
+    pos := s.fset.Position(call.Pos())
+    route := Route{
+        Method:    method,
+        Path:      routePath,
+        Framework: framework,
+        Package:   s.pkg.ImportPath,
+        File:      pos.Filename,
+        Line:      pos.Line,
+    }
+    s.resolveHandler(&route, handler)
+    s.routes = append(s.routes, route)
+}
+
+// resolveHandler identifica a função usada como handler, desembrulhando
+// conversões como http.HandlerFunc(fn), e analisa seu corpo
+func (s *routeScanner) resolveHandler(route *Route, expr ast.Expr) {
+    expr = ast.Unparen(expr)
+    if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+        if tv, ok := s.pkg.Info.Types[call.Fun]; ok && tv.IsType() {
+            expr = ast.Unparen(call.Args[0])
+        }
+    }
+
+    var fn *types.Func
+    switch e := expr.(type) {
+    case *ast.Ident:
+        fn, _ = s.pkg.Info.Uses[e].(*types.Func)
+    case *ast.SelectorExpr:
+        if sel, ok := s.pkg.Info.Selections[e]; ok {
+            fn, _ = sel.Obj().(*types.Func)
+        } else {
+            fn, _ = s.pkg.Info.Uses[e.Sel].(*types.Func)
+        }
+    case *ast.FuncLit:
+        pos := s.fset.Position(e.Pos())
+        route.Handler = "func literal (linha " + strconv.Itoa(pos.Line) + ")"
+        route.Request, route.Responses = inspectHandlerBody(e.Body, s.pkg.Info)
+        return
+    }
+    if fn == nil {
+        route.Handler = types.ExprString(expr)
+        return
+    }
+
+    fn = fn.Origin()
+    route.Handler = analysis.ShortFuncName(fn)
+    route.HandlerID = fn.FullName()
+    if h, ok := s.handlers[fn]; ok {
+        if h.decl.Doc != nil {
+            route.Doc = strings.TrimSpace(h.decl.Doc.Text())
+        }
+        if h.decl.Body != nil {
+            route.Request, route.Responses = inspectHandlerBody(h.decl.Body, h.pkg.Info)
+        }
+    }
+}
+
+// requestDecoders são os métodos que decodificam o corpo da requisição no argumento
+var requestDecoders = map[string]bool{
+    "Decode": true, "Bind": true, "BindJSON": true, "ShouldBind": true, "ShouldBindJSON": true,
+}
+
+// inspectHandlerBody procura no corpo do handler o tipo decodificado da requisição
+// (json.Decoder.Decode, json.Unmarshal, Bind* do gin/echo) e as respostas JSON
+// escritas (json.Encoder.Encode após WriteHeader, c.JSON(status, v))
+func inspectHandlerBody(body *ast.BlockStmt, info *types.Info) (string, []RouteResponse) {
+    request := ""
+    var responses []RouteResponse
+    seen := make(map[RouteResponse]bool)
+    addResponse := func(status int, t types.Type) {
+        resp := RouteResponse{Status: status, Type: routeTypeName(t)}
+        if !seen[resp] {
+            seen[resp] = true
+            responses = append(responses, resp)
+        }
+    }
+
+    status := 200
+    ast.Inspect(body, func(n ast.Node) bool {
+        call, ok := n.(*ast.CallExpr)
+        if !ok {
+            return true
+        }
+        sel, ok := call.Fun.(*ast.SelectorExpr)
+        if !ok {
+            return true
+        }
+        args := call.Args
+        switch name := sel.Sel.Name; {
+        case requestDecoders[name] && len(args) == 1 && request == "":
+            request = routeTypeName(info.TypeOf(args[0]))
+        case name == "Unmarshal" && len(args) == 2 && request == "":
+            request = routeTypeName(info.TypeOf(args[1]))
+        case name == "WriteHeader" && len(args) == 1:
+            if code, ok := intValue(info, args[0]); ok {
+                status = code
+            }
+        case name == "Encode" && len(args) == 1:
+            addResponse(status, info.TypeOf(args[0]))
+        case name == "JSON" && len(args) == 2:
+            if code, ok := intValue(info, args[0]); ok {
+                addResponse(code, info.TypeOf(args[1]))
+            }
+        }
+        return true
+    })
+    return request, responses
+}
+
+// routeTypeName descreve o tipo de um corpo JSON com import paths completos,
+// ignorando ponteiros e tipos não resolvidos
+func routeTypeName(t types.Type) string {
+    if t == nil {
+        return ""
+    }
+    if ptr, ok := t.(*types.Pointer); ok {
+        t = ptr.Elem()
+    }
+    if basic, ok := t.(*types.Basic); ok && basic.Kind() == types.Invalid {
+        return ""
+    }
+    return types.TypeString(t, nil)
+}
+
+// intValue avalia uma expressão inteira constante (ex.: http.StatusCreated)
+func intValue(info *types.Info, expr ast.Expr) (int, bool) {
+    if tv, ok := info.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.Int {
+        if v, exact := constant.Int64Val(tv.Value); exact {
+            return int(v), true
+        }
+    }
+    return 0, false
+}
+
+// prefixOf retorna o prefixo conhecido de um roteador (grupo ou sub-rota)
+func (s *routeScanner) prefixOf(x ast.Expr) string {
+    // r.With(middleware).Get(...) mantém o prefixo de r
+    if call, ok := ast.Unparen(x).(*ast.CallExpr); ok {
+        if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "With" {
+            return s.prefixOf(sel.X)
+        }
+    }
+    if obj := s.objectOf(x); obj != nil {
+        return s.prefixes[obj]
+    }
+    return ""
+}
+
+// objectOf retorna o objeto referenciado por um identificador ou campo (s.router)
+func (s *routeScanner) objectOf(x ast.Expr) types.Object {
+    var id *ast.Ident
+    switch e := ast.Unparen(x).(type) {
+    case *ast.Ident:
+        id = e
+    case *ast.SelectorExpr:
+        if selection, ok := s.pkg.Info.Selections[e]; ok {
+            return selection.Obj()
+        }
+        id = e.Sel
+    default:
+        return nil
+    }
+    if obj := s.pkg.Info.Defs[id]; obj != nil {
+        return obj
+    }
+    return s.pkg.Info.Uses[id]
+}
+
+// stringValue avalia uma expressão string constante (literal ou const)
+func (s *routeScanner) stringValue(expr ast.Expr) (string, bool) {
+    return analysis.ConstString(s.pkg.Info, expr)
+}
+
+// splitServeMuxPattern separa o método do padrão do ServeMux ("GET /users/{id}")
+func splitServeMuxPattern(pattern string) (string, string) {
+    pattern = strings.TrimSpace(pattern)
+    method, rest, found := strings.Cut(pattern, " ")
+    if !found || strings.HasPrefix(method, "/") {
+        return "ANY", pattern
+    }
+    return method, strings.TrimSpace(rest)
+}
+
+// joinRoutePath concatena o prefixo de um grupo ao caminho da rota
+func joinRoutePath(prefix, routePath string) string {
+    if prefix == "" {
+        return routePath
+    }
+    if routePath == "" || routePath == "/" {
+        return prefix
+    }
+    joined := path.Join(prefix, routePath)
+    if strings.HasSuffix(routePath, "/") && !strings.HasSuffix(joined, "/") {
+        joined += "/"
+    }
+    return joined
+}
+
+// PathParams retorna os parâmetros de caminho da rota, em qualquer das sintaxes
+// suportadas: {id}, {id:[0-9]+}, {path...} (net/http e chi) e :id, *path (gin e echo)
+func (r Route) PathParams() []string {
+    var params []string
+    for _, segment := range strings.Split(r.Path, "/") {
+        switch {
+        case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+            name := strings.Trim(segment, "{}")
+            name, _, _ = strings.Cut(name, ":")
+            name = strings.TrimSuffix(name, "...")
+            if name != "$" && name != "" {
+                params = append(params, name)
+            }
+        case strings.HasPrefix(segment, ":") || (strings.HasPrefix(segment, "*") && len(segment) > 1):
+            params = append(params, segment[1:])
+        }
+    }
+    return params
+}
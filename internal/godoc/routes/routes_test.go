@@ -0,0 +1,192 @@
+package routes_test
+
+import (
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+	"github.com/edgardnogueira/aimap/internal/godoc/routes"
+)
+
+func TestRoutes(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "api/std.go": `package api
+
+import "net/http"
+
+// GetUser retorna um usuário
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+func Routes() *http.ServeMux {
+    mux := http.NewServeMux()
+    mux.HandleFunc("GET /users/{id}", GetUser)
+    mux.Handle("/health", http.HandlerFunc(GetUser))
+    return mux
+}
+`,
+        "web/chi.go": `package web
+
+import (
+    "net/http"
+
+    "github.com/go-chi/chi/v5"
+)
+
+func list(w http.ResponseWriter, r *http.Request) {}
+
+func Router() http.Handler {
+    r := chi.NewRouter()
+    r.Route("/v1", func(r chi.Router) {
+        r.Get("/items/{itemID}", list)
+    })
+    return r
+}
+`,
+        "gin/gin.go": `package gin
+
+import "github.com/gin-gonic/gin"
+
+func show(c *gin.Context) {}
+
+func Setup(r *gin.Engine) {
+    api := r.Group("/api")
+    api.POST("/orders/:id", show)
+}
+`,
+    })
+
+    found := routes.Build(pass)
+
+    got := make(map[string]routes.Route)
+    for _, r := range found {
+        got[r.Method+" "+r.Path] = r
+    }
+    cases := []struct {
+        key       string
+        handler   string
+        framework string
+    }{
+        {"GET /users/{id}", "api.GetUser", routes.FrameworkNetHTTP},
+        {"ANY /health", "api.GetUser", routes.FrameworkNetHTTP},
+        {"GET /v1/items/{itemID}", "web.list", routes.FrameworkChi},
+        {"POST /api/orders/:id", "gin.show", routes.FrameworkGin},
+    }
+    for _, tc := range cases {
+        r, ok := got[tc.key]
+        if !ok {
+            t.Errorf("rota %q não encontrada em %v", tc.key, found)
+            continue
+        }
+        if r.Handler != tc.handler || r.Framework != tc.framework {
+            t.Errorf("rota %q = handler %q, framework %q; want %q, %q", tc.key, r.Handler, r.Framework, tc.handler, tc.framework)
+        }
+    }
+    if r := got["GET /users/{id}"]; r.Doc != "GetUser retorna um usuário" {
+        t.Errorf("Doc = %q", r.Doc)
+    }
+    if params := got["POST /api/orders/:id"].PathParams(); len(params) != 1 || params[0] != "id" {
+        t.Errorf("PathParams = %v; want [id]", params)
+    }
+}
+
+func TestRoutesReceiverType(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        // Cópia mínima do chi, para que os tipos do roteador sejam resolvidos
+        "third_party/chi/go.mod": "module github.com/go-chi/chi/v5\n\ngo 1.22\n",
+        "third_party/chi/chi.go": `package chi
+
+import "net/http"
+
+type Router interface {
+    Get(pattern string, h http.HandlerFunc)
+}
+
+type Mux struct{}
+
+func (m *Mux) Get(pattern string, h http.HandlerFunc) {}
+
+func NewRouter() *Mux { return &Mux{} }
+`,
+        "web/web.go": `package web
+
+import (
+    "net/http"
+
+    "github.com/go-chi/chi/v5"
+)
+
+type Cache struct{}
+
+func (c *Cache) Get(key string, value interface{}) {}
+
+// api embute o roteador e implementa chi.Router
+type api struct {
+    chi.Router
+}
+
+func list(w http.ResponseWriter, r *http.Request) {}
+
+func Setup(cache *Cache, a *api) {
+    cache.Get("/items", list)
+    a.Get("/items", list)
+    chi.NewRouter().Get("/health", list)
+}
+`,
+        // Sem export data do gin e do echo, a origem do roteador decide o framework
+        "mixed/mixed.go": `package mixed
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/labstack/echo/v4"
+)
+
+type Client struct{}
+
+func (c *Client) GET(url string, h interface{}) {}
+
+func show(c *gin.Context) {}
+
+func create(c echo.Context) error { return nil }
+
+func auth(next echo.HandlerFunc) echo.HandlerFunc { return next }
+
+type server struct {
+    router *echo.Echo
+}
+
+func Setup(client *Client, s *server) {
+    client.GET("/remote", show)
+    r := gin.Default()
+    r.GET("/gin", show)
+    s.router.POST("/echo", create, auth)
+    e := echo.New()
+    g := e.Group("/v1")
+    g.PUT("/items", create, auth)
+    _ = http.StatusOK
+}
+`,
+    })
+
+    found := routes.Build(pass)
+
+    got := make(map[string]string)
+    for _, r := range found {
+        got[r.Method+" "+r.Path] = r.Framework + " " + r.Handler
+    }
+    want := map[string]string{
+        "GET /items":    "chi web.list",
+        "GET /health":   "chi web.list",
+        "GET /gin":      "gin mixed.show",
+        "POST /echo":    "echo mixed.create",
+        "PUT /v1/items": "echo mixed.create",
+    }
+    for key, route := range want {
+        if got[key] != route {
+            t.Errorf("rota %q = %q; want %q", key, got[key], route)
+        }
+    }
+    if len(found) != len(want) {
+        t.Errorf("rotas = %v; want só as chamadas sobre roteadores", got)
+    }
+}
@@ -0,0 +1,252 @@
+package schema
+
+import (
+	"log/slog"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/routes"
+	"github.com/edgardnogueira/aimap/internal/swagger"
+)
+
+// operationIDPattern reconhece os trechos do caminho que não podem compor um operationId
+var operationIDPattern = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// routeParamPattern reconhece os parâmetros de caminho nas sintaxes do chi/net/http e do gin/echo
+var routeParamPattern = regexp.MustCompile(`\{([^}:.]+)(?::[^}]*)?(?:\.\.\.)?\}|[:*]([A-Za-z_][A-Za-z0-9_]*)`)
+
+// openAPIBuilder converte rotas e structs documentadas em um documento OpenAPI 3
+type openAPIBuilder struct {
+    structs structIndex
+    names   map[string]string // chave da struct -> nome do schema em components
+    schemas map[string]swagger.SchemaType
+}
+
+// OpenAPI gera um documento OpenAPI 3 a partir das rotas extraídas. Os schemas de
+// requisição e resposta vêm das structs e de suas tags json, os parâmetros de
+// caminho dos padrões das rotas e as descrições dos comentários de documentação
+func OpenAPI(project *godoc.ProjectDoc, title, version string) *swagger.SwaggerDoc {
+    b := &openAPIBuilder{
+        structs: newStructIndex(project),
+        names:   make(map[string]string),
+        schemas: make(map[string]swagger.SchemaType),
+    }
+
+    doc := &swagger.SwaggerDoc{
+        OpenAPI: "3.0.3",
+        Info:    swagger.Info{Title: title, Version: version},
+        Paths:   make(map[string]swagger.PathItem),
+    }
+
+    // Handlers montados em mais de uma rota recebem o método e o caminho no operationId
+    handlerRoutes := make(map[string]int)
+    for _, route := range project.Routes {
+        if route.HandlerID != "" {
+            handlerRoutes[route.HandlerID]++
+        }
+    }
+    operationIDs := make(map[string]bool)
+    anyOperations := make(map[string]bool) // caminhos cujo GET veio de uma rota para qualquer método
+
+    for _, route := range project.Routes {
+        routePath := openAPIPath(route.Path)
+        item, ok := doc.Paths[routePath]
+        if !ok {
+            item = make(swagger.PathItem)
+            doc.Paths[routePath] = item
+        }
+
+        method := strings.ToLower(route.Method)
+        op := swagger.Operation{
+            Description: route.Doc,
+            Tags:        []string{path.Base(route.Package)},
+            Responses:   make(map[string]swagger.Response),
+        }
+        if route.Doc != "" {
+            op.Summary = strings.SplitN(route.Doc, "\n", 2)[0]
+        }
+        isAny := method == "any"
+        if isAny {
+            // OpenAPI não tem um método curinga; documenta como GET
+            method = "get"
+            op.Description = strings.TrimSpace(op.Description + "\n\nAceita qualquer método HTTP.")
+        }
+        if _, taken := item[method]; taken {
+            switch {
+            case isAny:
+                slog.Warn("Rota para qualquer método omitida do OpenAPI: o caminho já tem GET", "path", route.Path, "handler", route.Handler)
+                continue
+            case method == "get" && anyOperations[routePath]:
+                // O GET explícito prevalece sobre a rota para qualquer método
+                slog.Warn("Rota para qualquer método substituída pelo GET no OpenAPI", "path", route.Path, "handler", route.Handler)
+            default:
+                slog.Warn("Rota duplicada omitida do OpenAPI", "method", route.Method, "path", route.Path, "handler", route.Handler)
+                continue
+            }
+        }
+        if method == "get" {
+            anyOperations[routePath] = isAny
+        }
+
+        for _, param := range route.PathParams() {
+            op.Parameters = append(op.Parameters, swagger.Parameter{
+                Name:     param,
+                In:       "path",
+                Required: true,
+                Schema:   &swagger.SchemaType{Type: "string"},
+            })
+        }
+        if route.Request != "" {
+            op.RequestBody = &swagger.RequestBody{
+                Required: true,
+                Content:  map[string]swagger.MediaType{"application/json": {Schema: b.schemaFor(route.Request, route.Package, nil)}},
+            }
+        }
+        for _, resp := range route.Responses {
+            response := swagger.Response{Description: http.StatusText(resp.Status)}
+            if resp.Type != "" {
+                response.Content = map[string]swagger.MediaType{"application/json": {Schema: b.schemaFor(resp.Type, route.Package, nil)}}
+            }
+            op.Responses[strconv.Itoa(resp.Status)] = response
+        }
+        if len(op.Responses) == 0 {
+            op.Responses["200"] = swagger.Response{Description: http.StatusText(http.StatusOK)}
+        }
+
+        op.OperationID = uniqueOperationID(route, method, handlerRoutes[route.HandlerID] > 1, operationIDs)
+        item[method] = op
+    }
+
+    if len(b.schemas) > 0 {
+        doc.Components = &swagger.Components{Schemas: b.schemas}
+    }
+    return doc
+}
+
+// schemaFor converte uma descrição de tipo Go em schema. Qualificadores podem ser
+// import paths completos (tipos das rotas) ou nomes de pacote (campos de structs),
+// resolvidos pelos imports do arquivo
+func (b *openAPIBuilder) schemaFor(typeName, importPath string, imports []string) swagger.SchemaType {
+    typeName = strings.TrimPrefix(strings.TrimSpace(typeName), "*")
+
+    switch {
+    case typeName == "[]byte":
+        return swagger.SchemaType{Type: "string", Format: "byte"}
+    case strings.HasPrefix(typeName, "[]"):
+        items := b.schemaFor(typeName[2:], importPath, imports)
+        return swagger.SchemaType{Type: "array", Items: &items}
+    case strings.HasPrefix(typeName, "["):
+        // Array de tamanho fixo ([N]T)
+        if end := strings.Index(typeName, "]"); end > 0 {
+            items := b.schemaFor(typeName[end+1:], importPath, imports)
+            return swagger.SchemaType{Type: "array", Items: &items}
+        }
+    case strings.HasPrefix(typeName, "map["):
+        if end := analysis.MatchingBracket(typeName, 3); end > 0 {
+            values := b.schemaFor(typeName[end+1:], importPath, imports)
+            return swagger.SchemaType{Type: "object", AdditionalProperties: &values}
+        }
+    }
+
+    switch typeName {
+    case "string":
+        return swagger.SchemaType{Type: "string"}
+    case "bool":
+        return swagger.SchemaType{Type: "boolean"}
+    case "int", "int8", "int16", "uint", "uint8", "uint16", "uint32", "byte", "rune", "int32":
+        return swagger.SchemaType{Type: "integer", Format: "int32"}
+    case "int64", "uint64":
+        return swagger.SchemaType{Type: "integer", Format: "int64"}
+    case "float32":
+        return swagger.SchemaType{Type: "number", Format: "float"}
+    case "float64":
+        return swagger.SchemaType{Type: "number", Format: "double"}
+    case "time.Time":
+        return swagger.SchemaType{Type: "string", Format: "date-time"}
+    case "time.Duration":
+        return swagger.SchemaType{Type: "integer", Format: "int64"}
+    case "interface{}", "any", "json.RawMessage", "encoding/json.RawMessage":
+        return swagger.SchemaType{}
+    }
+
+    key := b.structs.resolve(typeName, importPath, imports)
+    source, ok := b.structs[key]
+    if !ok {
+        // Tipo não documentado como struct (alias, tipo externo): schema livre
+        return swagger.SchemaType{}
+    }
+    return swagger.SchemaType{Ref: "#/components/schemas/" + b.structSchema(key, source)}
+}
+
+// structSchema registra o schema de uma struct em components e retorna seu nome
+func (b *openAPIBuilder) structSchema(key string, source schemaSource) string {
+    if name, ok := b.names[key]; ok {
+        return name
+    }
+    name := source.str.Name
+    if _, taken := b.schemas[name]; taken {
+        name = path.Base(source.importPath) + "." + name
+    }
+    b.names[key] = name
+    // Reserva o nome antes de descer nos campos para suportar tipos recursivos
+    b.schemas[name] = swagger.SchemaType{Type: "object"}
+
+    schema := swagger.SchemaType{
+        Type:        "object",
+        Description: source.str.Doc,
+        Properties:  make(map[string]swagger.SchemaType),
+    }
+    for _, f := range b.structs.serializedFields(source, "json") {
+        prop := b.schemaFor(f.field.Type, f.source.importPath, f.source.imports)
+        if f.tag != nil && f.tag.AsString {
+            prop = swagger.SchemaType{Type: "string"}
+        }
+        // Em OpenAPI 3.0 campos irmãos de $ref são ignorados
+        if f.field.Doc != "" && prop.Ref == "" {
+            prop.Description = f.field.Doc
+        }
+        schema.Properties[f.name] = prop
+        if f.required(RequiredFields) {
+            schema.Required = append(schema.Required, f.name)
+        }
+    }
+    sort.Strings(schema.Required)
+    b.schemas[name] = schema
+    return name
+}
+
+// openAPIPath converte o caminho da rota para a sintaxe de parâmetros do OpenAPI ({id})
+func openAPIPath(routePath string) string {
+    routePath = strings.ReplaceAll(routePath, "{$}", "")
+    return routeParamPattern.ReplaceAllStringFunc(routePath, func(m string) string {
+        sub := routeParamPattern.FindStringSubmatch(m)
+        if sub[1] != "" {
+            return "{" + sub[1] + "}"
+        }
+        return "{" + sub[2] + "}"
+    })
+}
+
+// uniqueOperationID gera o operationId a partir do handler resolvido. Quando o handler
+// atende mais de uma rota, ou o nome já foi usado, o método e o caminho entram no
+// identificador, que precisa ser único no documento
+func uniqueOperationID(route routes.Route, method string, shared bool, used map[string]bool) string {
+    if route.HandlerID == "" {
+        return ""
+    }
+    id := strings.ReplaceAll(route.Handler, ".", "_")
+    if shared || used[id] {
+        id += "_" + method + "_" + strings.Trim(operationIDPattern.ReplaceAllString(route.Path, "_"), "_")
+    }
+    for base, i := id, 2; used[id]; i++ {
+        id = base + "_" + strconv.Itoa(i)
+    }
+    used[id] = true
+    return id
+}
@@ -0,0 +1,131 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+	"github.com/edgardnogueira/aimap/internal/godoc/schema"
+)
+
+func TestOpenAPI(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "api/api.go": `package api
+
+import (
+    "encoding/json"
+    "net/http"
+)
+
+// Item é um item do catálogo
+type Item struct {
+    ID    int64  ` + "`json:\"id\"`" + `
+    Name  string ` + "`json:\"name,omitempty\"`" + `
+    Owner *Item  ` + "`json:\"owner,omitempty\"`" + `
+}
+
+// CreateItem cria um item
+func CreateItem(w http.ResponseWriter, r *http.Request) {
+    var item Item
+    json.NewDecoder(r.Body).Decode(&item)
+    w.WriteHeader(http.StatusCreated)
+    json.NewEncoder(w).Encode(item)
+}
+
+func Routes(mux *http.ServeMux) {
+    mux.HandleFunc("POST /shops/{shop}/items", CreateItem)
+}
+`,
+    })
+
+    cfg := config.GolangConfig{Paths: []string{root}, Routes: config.RoutesConfig{Enabled: true}}
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+
+    spec := schema.OpenAPI(doc, "app", "1.0.0")
+    op, ok := spec.Paths["/shops/{shop}/items"]["post"]
+    if !ok {
+        t.Fatalf("operação POST não encontrada: %+v", spec.Paths)
+    }
+    if op.Summary != "CreateItem cria um item" {
+        t.Errorf("Summary = %q", op.Summary)
+    }
+    if len(op.Parameters) != 1 || op.Parameters[0].Name != "shop" || op.Parameters[0].In != "path" {
+        t.Errorf("Parameters = %+v", op.Parameters)
+    }
+    if op.RequestBody == nil || op.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/Item" {
+        t.Errorf("RequestBody = %+v", op.RequestBody)
+    }
+    if _, ok := op.Responses["201"]; !ok {
+        t.Errorf("Responses = %+v; want 201", op.Responses)
+    }
+
+    item := spec.Components.Schemas["Item"]
+    if item.Properties["owner"].Ref != "#/components/schemas/Item" {
+        t.Errorf("owner = %+v; want referência recursiva", item.Properties["owner"])
+    }
+    if len(item.Required) != 1 || item.Required[0] != "id" {
+        t.Errorf("Required = %v; want [id]", item.Required)
+    }
+}
+
+func TestOpenAPIOperationCollisions(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "api/api.go": `package api
+
+import "net/http"
+
+func ListItems(w http.ResponseWriter, r *http.Request) {}
+
+func Health(w http.ResponseWriter, r *http.Request) {}
+
+func HealthGet(w http.ResponseWriter, r *http.Request) {}
+
+func Status(w http.ResponseWriter, r *http.Request) {}
+
+func StatusAny(w http.ResponseWriter, r *http.Request) {}
+
+func Routes(mux *http.ServeMux) {
+    mux.HandleFunc("GET /items", ListItems)
+    mux.HandleFunc("GET /shops/{shop}/items", ListItems)
+    mux.HandleFunc("/health", Health)
+    mux.HandleFunc("GET /health", HealthGet)
+    mux.HandleFunc("GET /status", Status)
+    mux.HandleFunc("/status", StatusAny)
+}
+`,
+    })
+
+    cfg := config.GolangConfig{Paths: []string{root}, Routes: config.RoutesConfig{Enabled: true}}
+    doc, err := godoc.NewAnalyzer(cfg).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    spec := schema.OpenAPI(doc, "app", "1.0.0")
+
+    ids := make(map[string]bool)
+    for _, item := range spec.Paths {
+        for _, op := range item {
+            if ids[op.OperationID] {
+                t.Errorf("operationId duplicado: %s", op.OperationID)
+            }
+            ids[op.OperationID] = true
+        }
+    }
+    if got := spec.Paths["/items"]["get"].OperationID; got != "api_ListItems_get_items" {
+        t.Errorf("operationId de /items = %q", got)
+    }
+    if got := spec.Paths["/shops/{shop}/items"]["get"].OperationID; got != "api_ListItems_get_shops_shop_items" {
+        t.Errorf("operationId de /shops/{shop}/items = %q", got)
+    }
+    // O GET explícito prevalece sobre a rota para qualquer método, em qualquer ordem
+    if got := spec.Paths["/health"]["get"].OperationID; got != "api_HealthGet" {
+        t.Errorf("GET /health = %q; want api_HealthGet", got)
+    }
+    if got := spec.Paths["/status"]["get"].OperationID; got != "api_Status" {
+        t.Errorf("GET /status = %q; want api_Status", got)
+    }
+}
@@ -0,0 +1,414 @@
+// Package schema gera JSON Schema e OpenAPI a partir das structs documentadas
+package schema
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/structtag"
+)
+
+// JSONSchemaDraft é o dialeto usado nos schemas gerados
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Modos de obrigatoriedade dos campos no JSON Schema
+const (
+    RequiredTags   = "tags"   // apenas campos com validate/binding:"required" (padrão)
+    RequiredFields = "fields" // também campos sem omitempty e que não são ponteiros
+)
+
+// JSONSchema representa um schema JSON (draft 2020-12)
+type JSONSchema struct {
+    Schema               string                 `json:"$schema,omitempty"`
+    Ref                  string                 `json:"$ref,omitempty"`
+    Title                string                 `json:"title,omitempty"`
+    Description          string                 `json:"description,omitempty"`
+    Type                 string                 `json:"type,omitempty"`
+    Format               string                 `json:"format,omitempty"`
+    Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+    Required             []string               `json:"required,omitempty"`
+    AdditionalProperties interface{}            `json:"additionalProperties,omitempty"` // bool ou *JSONSchema
+    Items                *JSONSchema            `json:"items,omitempty"`
+    Enum                 []interface{}          `json:"enum,omitempty"`
+    Minimum              *float64               `json:"minimum,omitempty"`
+    Maximum              *float64               `json:"maximum,omitempty"`
+    ExclusiveMinimum     *float64               `json:"exclusiveMinimum,omitempty"`
+    ExclusiveMaximum     *float64               `json:"exclusiveMaximum,omitempty"`
+    MinLength            *int                   `json:"minLength,omitempty"`
+    MaxLength            *int                   `json:"maxLength,omitempty"`
+    MinItems             *int                   `json:"minItems,omitempty"`
+    MaxItems             *int                   `json:"maxItems,omitempty"`
+    Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// schemaSource associa uma struct documentada ao pacote e aos imports do arquivo
+type schemaSource struct {
+    str        godoc.Struct
+    importPath string
+    imports    []string
+}
+
+// structIndex indexa as structs do projeto por "import path.Nome"
+type structIndex map[string]schemaSource
+
+// newStructIndex indexa as structs de todos os diretórios analisados
+func newStructIndex(doc *godoc.ProjectDoc) structIndex {
+    idx := make(structIndex)
+    for _, dir := range doc.Directories {
+        for _, file := range dir.Files {
+            for _, str := range file.Structs {
+                idx[dir.ImportPath+"."+str.Name] = schemaSource{str: str, importPath: dir.ImportPath, imports: file.Imports}
+            }
+        }
+    }
+    return idx
+}
+
+// resolve converte um nome de tipo Go na chave "import path.Nome". Qualificadores
+// podem ser import paths completos ou nomes de pacote resolvidos pelos imports do arquivo
+func (idx structIndex) resolve(typeName, importPath string, imports []string) string {
+    dot := strings.LastIndex(typeName, ".")
+    if dot < 0 {
+        return importPath + "." + typeName
+    }
+    qualifier, name := typeName[:dot], typeName[dot+1:]
+    if strings.Contains(qualifier, "/") || strings.Contains(qualifier, ".") {
+        return typeName
+    }
+    for _, imp := range imports {
+        if analysis.ImportName(imp) == qualifier {
+            return imp + "." + name
+        }
+    }
+    return typeName
+}
+
+// FindStruct procura structs pelo nome qualificado (pkg.Tipo ou import/path.Tipo)
+func FindStruct(doc *godoc.ProjectDoc, name string) []string {
+    dot := strings.LastIndex(name, ".")
+    if dot < 0 {
+        return nil
+    }
+    qualifier, typeName := name[:dot], name[dot+1:]
+
+    var matches []string
+    for key, source := range newStructIndex(doc) {
+        if source.str.Name != typeName {
+            continue
+        }
+        if source.importPath == qualifier || path.Base(source.importPath) == qualifier ||
+            strings.HasSuffix(source.importPath, "/"+qualifier) {
+            matches = append(matches, key)
+        }
+    }
+    sort.Strings(matches)
+    return matches
+}
+
+// serializedField representa um campo como aparece no documento serializado
+type serializedField struct {
+    field  godoc.StructField
+    name   string       // chave no JSON/YAML
+    tag    *structtag.Name     // nil quando o campo não tem tag do formato
+    source schemaSource // struct que declara o campo, para resolver os tipos
+}
+
+// serializedFields lista os campos serializados de uma struct seguindo as regras
+// do encoding/json (format "json") ou do yaml.v3 (format "yaml") para tags,
+// campos embutidos e campos não exportados
+func (idx structIndex) serializedFields(source schemaSource, format string) []serializedField {
+    var fields []serializedField
+    idx.collectFields(source, format, make(map[string]bool), &fields)
+    return fields
+}
+
+func (idx structIndex) collectFields(source schemaSource, format string, visiting map[string]bool, fields *[]serializedField) {
+    visiting[source.importPath+"."+source.str.Name] = true
+    for _, field := range source.str.Fields {
+        tag := field.Tags.Tag(format)
+        if tag != nil && tag.Skip {
+            continue
+        }
+
+        embedded := field.Name == field.Type
+        typeName := strings.TrimPrefix(field.Type, "*")
+        // encoding/json achata structs embutidas sem nome na tag; o yaml.v3 só com ",inline"
+        inline := embedded && ((format == "json" && (tag == nil || tag.Name == "")) || (tag != nil && tag.Inline))
+        if inline {
+            key := idx.resolve(typeName, source.importPath, source.imports)
+            if inner, ok := idx[key]; ok && !visiting[key] {
+                idx.collectFields(inner, format, visiting, fields)
+            }
+            continue
+        }
+
+        fieldName := field.Name
+        if embedded {
+            fieldName = typeName[strings.LastIndex(typeName, ".")+1:]
+        }
+        if !ast.IsExported(fieldName) {
+            continue
+        }
+
+        name := fieldName
+        switch {
+        case tag != nil && tag.Name != "":
+            name = tag.Name
+        case format == "yaml":
+            name = strings.ToLower(fieldName)
+        }
+        *fields = append(*fields, serializedField{field: field, name: name, tag: tag, source: source})
+    }
+}
+
+// required informa se o campo é obrigatório no modo informado
+func (f serializedField) required(mode string) bool {
+    if f.field.Tags.HasRule("required") {
+        return true
+    }
+    if mode != RequiredFields {
+        return false
+    }
+    return (f.tag == nil || !f.tag.OmitEmpty) && !strings.HasPrefix(f.field.Type, "*")
+}
+
+// jsonSchemaBuilder gera JSON Schema para um grafo de structs
+type jsonSchemaBuilder struct {
+    idx      structIndex
+    format   string
+    required string
+    names    map[string]string
+    defs     map[string]*JSONSchema
+}
+
+// DefaultFormat escolhe as tags usadas como chave quando o formato não é informado:
+// yaml quando a struct só declara tags yaml (como structs de configuração), senão json
+func DefaultFormat(doc *godoc.ProjectDoc, key string) string {
+    source, ok := newStructIndex(doc)[key]
+    if !ok {
+        return "json"
+    }
+    hasYAML := false
+    for _, field := range source.str.Fields {
+        if field.Tags.Tag("json") != nil {
+            return "json"
+        }
+        if field.Tags.Tag("yaml") != nil {
+            hasYAML = true
+        }
+    }
+    if hasYAML {
+        return "yaml"
+    }
+    return "json"
+}
+
+// JSONSchemaFor gera o JSON Schema da struct identificada por "import path.Nome"
+// (ver FindStruct). format escolhe as tags usadas como chave (json ou yaml) e
+// required o modo de obrigatoriedade (RequiredTags, o padrão, ou RequiredFields)
+func JSONSchemaFor(doc *godoc.ProjectDoc, key, format, required string) (*JSONSchema, error) {
+    idx := newStructIndex(doc)
+    source, ok := idx[key]
+    if !ok {
+        return nil, fmt.Errorf("struct não encontrada: %s", key)
+    }
+    b := &jsonSchemaBuilder{
+        idx:      idx,
+        format:   format,
+        required: required,
+        names:    make(map[string]string),
+        defs:     make(map[string]*JSONSchema),
+    }
+    name := b.structSchema(key, source)
+    return &JSONSchema{
+        Schema: JSONSchemaDraft,
+        Title:  source.str.Name,
+        Ref:    "#/$defs/" + name,
+        Defs:   b.defs,
+    }, nil
+}
+
+// structSchema registra o schema de uma struct em $defs e retorna seu nome
+func (b *jsonSchemaBuilder) structSchema(key string, source schemaSource) string {
+    if name, ok := b.names[key]; ok {
+        return name
+    }
+    name := source.str.Name
+    if _, taken := b.defs[name]; taken {
+        name = path.Base(source.importPath) + "." + name
+    }
+    b.names[key] = name
+
+    schema := &JSONSchema{
+        Type:                 "object",
+        Description:          source.str.Doc,
+        Properties:           make(map[string]*JSONSchema),
+        AdditionalProperties: false,
+    }
+    // Registra antes de descer nos campos para suportar tipos recursivos
+    b.defs[name] = schema
+
+    for _, f := range b.idx.serializedFields(source, b.format) {
+        prop := b.schemaFor(f.field.Type, f.source)
+        if f.tag != nil && f.tag.AsString {
+            prop = &JSONSchema{Type: "string"}
+        }
+        applyValidation(prop, f.field.Tags)
+        if f.field.Doc != "" {
+            prop.Description = f.field.Doc
+        }
+        schema.Properties[f.name] = prop
+        if f.required(b.required) {
+            schema.Required = append(schema.Required, f.name)
+        }
+    }
+    sort.Strings(schema.Required)
+    return name
+}
+
+// schemaFor converte uma descrição de tipo Go (como em godoc.StructField.Type) em schema
+func (b *jsonSchemaBuilder) schemaFor(typeName string, source schemaSource) *JSONSchema {
+    typeName = strings.TrimPrefix(strings.TrimSpace(typeName), "*")
+
+    switch {
+    case typeName == "[]byte" && b.format == "json":
+        return &JSONSchema{Type: "string", Format: "byte"}
+    case strings.HasPrefix(typeName, "["):
+        if end := strings.Index(typeName, "]"); end > 0 {
+            return &JSONSchema{Type: "array", Items: b.schemaFor(typeName[end+1:], source)}
+        }
+    case strings.HasPrefix(typeName, "map["):
+        if end := analysis.MatchingBracket(typeName, 3); end > 0 {
+            return &JSONSchema{Type: "object", AdditionalProperties: b.schemaFor(typeName[end+1:], source)}
+        }
+    }
+
+    switch typeName {
+    case "string":
+        return &JSONSchema{Type: "string"}
+    case "bool":
+        return &JSONSchema{Type: "boolean"}
+    case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "byte", "rune", "uintptr":
+        return &JSONSchema{Type: "integer"}
+    case "float32", "float64":
+        return &JSONSchema{Type: "number"}
+    case "time.Time":
+        return &JSONSchema{Type: "string", Format: "date-time"}
+    case "time.Duration":
+        // O yaml.v3 aceita durações como texto ("5s"); o encoding/json usa nanossegundos
+        if b.format == "yaml" {
+            return &JSONSchema{Type: "string"}
+        }
+        return &JSONSchema{Type: "integer"}
+    }
+
+    key := b.idx.resolve(typeName, source.importPath, source.imports)
+    inner, ok := b.idx[key]
+    if !ok {
+        // Interface, alias ou tipo externo: aceita qualquer valor
+        return &JSONSchema{}
+    }
+    return &JSONSchema{Ref: "#/$defs/" + b.structSchema(key, inner)}
+}
+
+// applyValidation traduz as regras de validate/binding para palavras-chave do JSON Schema.
+// Regras após "dive" se aplicam aos itens de slices e valores de mapas
+func applyValidation(schema *JSONSchema, tags *structtag.Tags) {
+    if tags == nil {
+        return
+    }
+    target := schema
+    for _, rule := range tags.Validate {
+        if rule.Name == "dive" {
+            switch {
+            case target.Items != nil:
+                target = target.Items
+            default:
+                if values, ok := target.AdditionalProperties.(*JSONSchema); ok {
+                    target = values
+                } else {
+                    return
+                }
+            }
+            continue
+        }
+        applyRule(target, rule)
+    }
+}
+
+// applyRule aplica uma regra ao schema conforme o tipo do valor
+func applyRule(schema *JSONSchema, rule structtag.Rule) {
+    number, numErr := strconv.ParseFloat(rule.Param, 64)
+    count, countErr := strconv.Atoi(rule.Param)
+
+    switch rule.Name {
+    case "email":
+        schema.Format = "email"
+    case "url", "uri", "http_url":
+        schema.Format = "uri"
+    case "uuid", "uuid4":
+        schema.Format = "uuid"
+    case "ipv4", "ip4_addr":
+        schema.Format = "ipv4"
+    case "ipv6", "ip6_addr":
+        schema.Format = "ipv6"
+    case "hostname", "hostname_rfc1123":
+        schema.Format = "hostname"
+    case "oneof":
+        schema.Enum = nil
+        for _, value := range strings.Fields(rule.Param) {
+            if schema.Type == "integer" || schema.Type == "number" {
+                if n, err := strconv.ParseFloat(value, 64); err == nil {
+                    schema.Enum = append(schema.Enum, n)
+                    continue
+                }
+            }
+            schema.Enum = append(schema.Enum, value)
+        }
+    case "min", "gte", "max", "lte", "len":
+        switch schema.Type {
+        case "string":
+            if countErr == nil {
+                if rule.Name != "max" && rule.Name != "lte" {
+                    schema.MinLength = &count
+                }
+                if rule.Name != "min" && rule.Name != "gte" {
+                    schema.MaxLength = &count
+                }
+            }
+        case "array":
+            if countErr == nil {
+                if rule.Name != "max" && rule.Name != "lte" {
+                    schema.MinItems = &count
+                }
+                if rule.Name != "min" && rule.Name != "gte" {
+                    schema.MaxItems = &count
+                }
+            }
+        case "integer", "number":
+            if numErr == nil {
+                if rule.Name != "max" && rule.Name != "lte" {
+                    schema.Minimum = &number
+                }
+                if rule.Name != "min" && rule.Name != "gte" {
+                    schema.Maximum = &number
+                }
+            }
+        }
+    case "gt":
+        if numErr == nil && (schema.Type == "integer" || schema.Type == "number") {
+            schema.ExclusiveMinimum = &number
+        }
+    case "lt":
+        if numErr == nil && (schema.Type == "integer" || schema.Type == "number") {
+            schema.ExclusiveMaximum = &number
+        }
+    }
+}
+
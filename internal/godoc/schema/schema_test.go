@@ -0,0 +1,205 @@
+package schema_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/config"
+	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+	"github.com/edgardnogueira/aimap/internal/godoc/schema"
+	"gopkg.in/yaml.v3"
+)
+
+func TestJSONSchemaFor(t *testing.T) {
+    root := godoctest.WriteModule(t, map[string]string{
+        "cfg/cfg.go": `package cfg
+
+import "time"
+
+type Meta struct {
+    Owner string ` + "`json:\"owner\"`" + `
+}
+
+// Config é a configuração do serviço
+type Config struct {
+    Meta
+    Name    string            ` + "`json:\"name\" validate:\"required,min=3\"`" + `
+    Mode    string            ` + "`json:\"mode,omitempty\" validate:\"oneof=dev prod\"`" + `
+    Port    *int              ` + "`json:\"port\" validate:\"gte=1,lte=65535\"`" + `
+    Labels  map[string]string ` + "`json:\"labels,omitempty\"`" + `
+    Hosts   []string          ` + "`json:\"hosts\" binding:\"dive,hostname\"`" + `
+    Started time.Time         ` + "`json:\"started\"`" + `
+    Ignored string            ` + "`json:\"-\"`" + `
+}
+`,
+    })
+
+    doc, err := godoc.NewAnalyzer(config.GolangConfig{Paths: []string{root}}).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    matches := schema.FindStruct(doc, "cfg.Config")
+    if len(matches) != 1 {
+        t.Fatalf("FindStruct = %v", matches)
+    }
+    if format := schema.DefaultFormat(doc, matches[0]); format != "json" {
+        t.Errorf("DefaultFormat(cfg.Config) = %q; want json", format)
+    }
+    generated, err := schema.JSONSchemaFor(doc, matches[0], "json", schema.RequiredFields)
+    if err != nil {
+        t.Fatalf("Erro ao gerar schema: %v", err)
+    }
+
+    cfg := generated.Defs["Config"]
+    if cfg == nil {
+        t.Fatalf("$defs sem Config: %v", generated.Defs)
+    }
+    if _, ok := cfg.Properties["owner"]; !ok {
+        t.Error("campo embutido owner não foi achatado")
+    }
+    if _, ok := cfg.Properties["Ignored"]; ok {
+        t.Error("campo com json:\"-\" não deveria aparecer")
+    }
+    if name := cfg.Properties["name"]; name.MinLength == nil || *name.MinLength != 3 {
+        t.Errorf("name.minLength = %v; want 3", name.MinLength)
+    }
+    if mode := cfg.Properties["mode"]; len(mode.Enum) != 2 {
+        t.Errorf("mode.enum = %v", mode.Enum)
+    }
+    if port := cfg.Properties["port"]; port.Type != "integer" || port.Maximum == nil || *port.Maximum != 65535 {
+        t.Errorf("port = %+v", port)
+    }
+    if labels := cfg.Properties["labels"]; labels.Type != "object" || labels.AdditionalProperties == nil {
+        t.Errorf("labels = %+v", labels)
+    }
+    if hosts := cfg.Properties["hosts"]; hosts.Items == nil || hosts.Items.Format != "hostname" {
+        t.Errorf("hosts = %+v", hosts)
+    }
+    if started := cfg.Properties["started"]; started.Format != "date-time" {
+        t.Errorf("started.format = %q", started.Format)
+    }
+    want := []string{"hosts", "name", "owner", "started"}
+    if strings.Join(cfg.Required, ",") != strings.Join(want, ",") {
+        t.Errorf("required = %v; want %v", cfg.Required, want)
+    }
+}
+
+func TestJSONSchemaValidatesConfigFile(t *testing.T) {
+    // O config.yml do repositório deve ser válido para o schema gerado sem -tag, que
+    // usa as tags yaml de config.Config
+    doc, err := godoc.NewAnalyzer(config.GolangConfig{Paths: []string{"../../config"}}).Analyze()
+    if err != nil {
+        t.Fatalf("Erro ao analisar projeto: %v", err)
+    }
+    matches := schema.FindStruct(doc, "config.Config")
+    if len(matches) != 1 {
+        t.Fatalf("FindStruct = %v", matches)
+    }
+    format := schema.DefaultFormat(doc, matches[0])
+    if format != "yaml" {
+        t.Errorf("DefaultFormat(config.Config) = %q; want yaml", format)
+    }
+    generated, err := schema.JSONSchemaFor(doc, matches[0], format, schema.RequiredTags)
+    if err != nil {
+        t.Fatalf("Erro ao gerar schema: %v", err)
+    }
+
+    data, err := os.ReadFile("../../../config.yml")
+    if err != nil {
+        t.Fatalf("Erro ao ler config.yml: %v", err)
+    }
+    var value interface{}
+    if err := yaml.Unmarshal(data, &value); err != nil {
+        t.Fatalf("Erro ao interpretar config.yml: %v", err)
+    }
+    for _, problem := range validateSchema(generated, generated, value, "") {
+        t.Error(problem)
+    }
+}
+
+// validateSchema confere tipos, propriedades obrigatórias e desconhecidas, itens e enums
+func validateSchema(root, node *schema.JSONSchema, value interface{}, at string) []string {
+    if node.Ref != "" {
+        return validateSchema(root, root.Defs[strings.TrimPrefix(node.Ref, "#/$defs/")], value, at)
+    }
+    var problems []string
+    fail := func(format string, args ...interface{}) {
+        problems = append(problems, at+": "+fmt.Sprintf(format, args...))
+    }
+    if value == nil {
+        return nil // chave presente sem valor: o YAML usa o valor zero
+    }
+    switch node.Type {
+    case "object":
+        object, ok := value.(map[string]interface{})
+        if !ok {
+            fail("esperado objeto, obtido %T", value)
+            return problems
+        }
+        for _, name := range node.Required {
+            if _, ok := object[name]; !ok {
+                fail("propriedade obrigatória ausente: %s", name)
+            }
+        }
+        for name, v := range object {
+            prop, ok := node.Properties[name]
+            if !ok {
+                if extra, ok := node.AdditionalProperties.(*schema.JSONSchema); ok {
+                    prop = extra
+                } else if node.AdditionalProperties == false {
+                    fail("propriedade desconhecida: %s", name)
+                    continue
+                } else {
+                    continue
+                }
+            }
+            problems = append(problems, validateSchema(root, prop, v, at+"."+name)...)
+        }
+    case "array":
+        list, ok := value.([]interface{})
+        if !ok {
+            fail("esperado array, obtido %T", value)
+            return problems
+        }
+        for i, v := range list {
+            if node.Items != nil {
+                problems = append(problems, validateSchema(root, node.Items, v, fmt.Sprintf("%s[%d]", at, i))...)
+            }
+        }
+    case "string":
+        if _, ok := value.(string); !ok {
+            fail("esperado string, obtido %T", value)
+        }
+    case "integer":
+        if _, ok := value.(int); !ok {
+            fail("esperado inteiro, obtido %T", value)
+        }
+    case "number":
+        switch value.(type) {
+        case int, float64:
+        default:
+            fail("esperado número, obtido %T", value)
+        }
+    case "boolean":
+        if _, ok := value.(bool); !ok {
+            fail("esperado booleano, obtido %T", value)
+        }
+    }
+    if len(node.Enum) > 0 && !containsValue(node.Enum, value) {
+        fail("valor %v fora de %v", value, node.Enum)
+    }
+    return problems
+}
+
+// containsValue informa se o valor está na lista
+func containsValue(list []interface{}, value interface{}) bool {
+    for _, v := range list {
+        if fmt.Sprint(v) == fmt.Sprint(value) {
+            return true
+        }
+    }
+    return false
+}
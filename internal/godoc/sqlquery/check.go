@@ -0,0 +1,178 @@
+package sqlquery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// SchemaTable descreve uma tabela ou view do banco usada para validar as consultas
+type SchemaTable struct {
+    Schema  string   `json:"schema,omitempty" yaml:"schema,omitempty"`
+    Name    string   `json:"name"             yaml:"name"`
+    Columns []string `json:"columns"          yaml:"columns"`
+}
+
+// Report relaciona as instruções SQL do código ao esquema do banco
+type Report struct {
+    Database string          `json:"database,omitempty" yaml:"database,omitempty"`
+    Tables   []TableUsage `json:"tables"             yaml:"tables"`
+    Unused   []string        `json:"unused,omitempty"   yaml:"unused,omitempty"` // tabelas do esquema não acessadas pelo código
+    Issues   []Issue      `json:"issues,omitempty"   yaml:"issues,omitempty"`
+}
+
+// Issue representa uma referência do código que não existe no esquema
+type Issue struct {
+    Kind     string `json:"kind"             yaml:"kind"` // missing_table, missing_column
+    Table    string `json:"table"            yaml:"table"`
+    Column   string `json:"column,omitempty" yaml:"column,omitempty"`
+    Function string `json:"function"         yaml:"function"`
+    File     string `json:"file"             yaml:"file"`
+    Line     int    `json:"line"             yaml:"line"`
+}
+
+// Check agrupa as consultas pelas tabelas do esquema e aponta tabelas e colunas
+// inexistentes. Sem esquema, apenas o uso das tabelas é calculado
+func Check(queries []Query, schema []SchemaTable) *Report {
+    index := make(map[string]*SchemaTable)
+    columns := make(map[*SchemaTable]map[string]bool)
+    for i := range schema {
+        t := &schema[i]
+        columns[t] = make(map[string]bool)
+        for _, c := range t.Columns {
+            columns[t][strings.ToLower(c)] = true
+        }
+        if t.Schema != "" {
+            index[strings.ToLower(t.Schema+"."+t.Name)] = t
+        }
+        // Nomes não qualificados resolvem para a primeira tabela com o nome
+        if _, ok := index[strings.ToLower(t.Name)]; !ok {
+            index[strings.ToLower(t.Name)] = t
+        }
+    }
+    canonical := func(t *SchemaTable) string {
+        if t.Schema != "" {
+            return t.Schema + "." + t.Name
+        }
+        return t.Name
+    }
+
+    report := &Report{}
+    resolved := make([]Query, len(queries))
+    for i, q := range queries {
+        resolved[i] = q
+        resolved[i].Tables = nil
+        if len(schema) == 0 {
+            resolved[i].Tables = q.Tables
+            continue
+        }
+
+        issue := func(kind, table, column string) {
+            report.Issues = append(report.Issues, Issue{
+                Kind: kind, Table: table, Column: column,
+                Function: q.Function, File: q.File, Line: q.Line,
+            })
+        }
+        var tables []*SchemaTable
+        missing := false
+        for _, name := range q.Tables {
+            t, ok := index[strings.ToLower(name)]
+            if !ok {
+                missing = true
+                resolved[i].Tables = append(resolved[i].Tables, name)
+                issue("missing_table", name, "")
+                continue
+            }
+            tables = append(tables, t)
+            resolved[i].Tables = analysis.AppendUnique(resolved[i].Tables, canonical(t))
+        }
+
+        for _, c := range q.Columns {
+            if c.Table != "" {
+                if t, ok := index[strings.ToLower(c.Table)]; ok && !columns[t][strings.ToLower(c.Name)] {
+                    issue("missing_column", canonical(t), c.Name)
+                }
+                continue
+            }
+            // Coluna sem tabela: só é verificada quando todas as tabelas da instrução são conhecidas
+            if missing || q.Derived || len(tables) == 0 {
+                continue
+            }
+            found := false
+            for _, t := range tables {
+                found = found || columns[t][strings.ToLower(c.Name)]
+            }
+            if !found {
+                issue("missing_column", strings.Join(resolved[i].Tables, ", "), c.Name)
+            }
+        }
+    }
+
+    report.Tables = TableUsages(resolved)
+    if len(schema) > 0 {
+        used := make(map[string]bool)
+        for _, usage := range report.Tables {
+            used[usage.Table] = true
+        }
+        for i := range schema {
+            if name := canonical(&schema[i]); !used[name] {
+                report.Unused = append(report.Unused, name)
+            }
+        }
+        sort.Strings(report.Unused)
+    }
+    return report
+}
+
+// Markdown formata o relatório de consultas SQL
+func (r *Report) Markdown() string {
+    var sb strings.Builder
+    if r.Database != "" {
+        sb.WriteString(fmt.Sprintf("# Consultas SQL: %s\n\n", r.Database))
+    } else {
+        sb.WriteString("# Consultas SQL\n\n")
+    }
+
+    sb.WriteString("## Tabelas acessadas pelo código\n\n")
+    if len(r.Tables) == 0 {
+        sb.WriteString("Nenhuma instrução SQL encontrada.\n\n")
+    } else {
+        sb.WriteString("| Tabela | Operações | Código | Instruções |\n")
+        sb.WriteString("|--------|-----------|--------|------------|\n")
+        for _, t := range r.Tables {
+            functions := make([]string, len(t.Functions))
+            for i, f := range t.Functions {
+                functions[i] = analysis.CodeOrEmpty(f)
+            }
+            sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %d |\n",
+                t.Table, strings.Join(t.Operations, ", "), strings.Join(functions, " "), t.Queries))
+        }
+        sb.WriteString("\n")
+    }
+
+    if len(r.Issues) > 0 {
+        sb.WriteString("## Referências inexistentes no esquema\n\n")
+        sb.WriteString("| Tipo | Tabela | Coluna | Código | Local |\n")
+        sb.WriteString("|------|--------|--------|--------|-------|\n")
+        for _, issue := range r.Issues {
+            kind := "tabela inexistente"
+            if issue.Kind == "missing_column" {
+                kind = "coluna inexistente"
+            }
+            sb.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s | %s:%d |\n",
+                kind, issue.Table, analysis.CodeOrEmpty(issue.Column), analysis.CodeOrEmpty(issue.Function), issue.File, issue.Line))
+        }
+        sb.WriteString("\n")
+    }
+
+    if len(r.Unused) > 0 {
+        sb.WriteString("## Tabelas sem uso no código\n\n")
+        for _, name := range r.Unused {
+            sb.WriteString(fmt.Sprintf("- `%s`\n", name))
+        }
+        sb.WriteString("\n")
+    }
+    return sb.String()
+}
@@ -0,0 +1,560 @@
+// Package sqlquery extrai as instruções SQL do código Go e dos arquivos do sqlc e
+// as confere com o schema do banco
+package sqlquery
+
+import (
+	"bufio"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Info reúne as instruções SQL encontradas no projeto e as tabelas acessadas
+type Info struct {
+    Queries []Query      `json:"queries"          yaml:"queries"`
+    Tables  []TableUsage `json:"tables,omitempty" yaml:"tables,omitempty"`
+}
+
+// Query representa uma instrução SQL embutida no código Go ou em um arquivo de consultas do sqlc
+type Query struct {
+    Source    string      `json:"source"             yaml:"source"` // go, sqlc
+    Package   string      `json:"package,omitempty"  yaml:"package,omitempty"`
+    Function  string      `json:"function,omitempty" yaml:"function,omitempty"` // função Go ou nome da consulta sqlc
+    Operation string      `json:"operation"          yaml:"operation"` // SELECT, INSERT, UPDATE, DELETE, ...
+    Statement string      `json:"statement"          yaml:"statement"`
+    Tables    []string    `json:"tables,omitempty"   yaml:"tables,omitempty"`
+    Columns   []Column `json:"columns,omitempty"  yaml:"columns,omitempty"`
+    Derived   bool        `json:"derived,omitempty"  yaml:"derived,omitempty"` // usa subconsulta no FROM; colunas sem tabela não são verificadas
+    File      string      `json:"file"               yaml:"file"`
+    Line      int         `json:"line"               yaml:"line"`
+}
+
+// Column representa uma coluna referenciada por uma instrução. Table fica
+// vazio quando a coluna não é qualificada e a instrução usa mais de uma tabela
+type Column struct {
+    Table string `json:"table,omitempty" yaml:"table,omitempty"`
+    Name  string `json:"name"            yaml:"name"`
+}
+
+// TableUsage lista o código que acessa uma tabela
+type TableUsage struct {
+    Table      string   `json:"table"      yaml:"table"`
+    Operations []string `json:"operations" yaml:"operations"`
+    Functions  []string `json:"functions"  yaml:"functions"` // funções Go ou consultas sqlc
+    Queries    int      `json:"queries"    yaml:"queries"`
+}
+
+// sqlMethods são os métodos de database/sql, sqlx, pgx e gorm que recebem SQL
+var sqlMethods = map[string]bool{
+    "Query": true, "QueryContext": true, "QueryRow": true, "QueryRowContext": true,
+    "Exec": true, "ExecContext": true, "Prepare": true, "PrepareContext": true,
+    "Get": true, "GetContext": true, "Select": true, "SelectContext": true,
+    "Queryx": true, "QueryxContext": true, "QueryRowx": true, "QueryRowxContext": true,
+    "NamedExec": true, "NamedExecContext": true, "NamedQuery": true, "NamedQueryContext": true,
+    "MustExec": true, "MustExecContext": true, "Raw": true,
+}
+
+// sqlStatementKeywords são as palavras que iniciam uma instrução SQL reconhecida
+var sqlStatementKeywords = map[string]bool{
+    "SELECT": true, "INSERT": true, "UPDATE": true, "DELETE": true, "WITH": true,
+    "MERGE": true, "REPLACE": true, "CREATE": true, "ALTER": true, "DROP": true, "TRUNCATE": true,
+}
+
+// Build extrai as instruções SQL do código Go e dos arquivos do sqlc encontrados
+// em roots, exceto os caminhos descartados por ignore
+func Build(pass *analysis.Pass, roots []string, ignore func(path string) bool) *Info {
+    info := &Info{}
+    for _, pkg := range pass.Packages {
+        if pkg.Types != nil {
+            info.Queries = append(info.Queries, goQueries(pass.Fset, pkg)...)
+        }
+    }
+    info.Queries = append(info.Queries, sqlcQueries(roots, ignore)...)
+    if len(info.Queries) == 0 {
+        return nil
+    }
+    info.Tables = TableUsages(info.Queries)
+    return info
+}
+
+// goQueries encontra chamadas como db.QueryContext(ctx, "SELECT ...") com SQL constante
+func goQueries(fset *token.FileSet, pkg *analysis.Package) []Query {
+    var result []Query
+    pkg.FuncDecls(func(decl *ast.FuncDecl, obj *types.Func) {
+        if decl.Body == nil {
+            return
+        }
+        function := analysis.LocalFuncName(obj, pkg.Types)
+        ast.Inspect(decl.Body, func(n ast.Node) bool {
+            call, ok := n.(*ast.CallExpr)
+            if !ok {
+                return true
+            }
+            sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+            if !ok || !sqlMethods[sel.Sel.Name] {
+                return true
+            }
+            for _, arg := range call.Args {
+                statement, ok := analysis.ConstString(pkg.Info, arg)
+                if !ok || !looksLikeSQL(statement) {
+                    continue
+                }
+                pos := fset.Position(arg.Pos())
+                query := parseSQL(statement)
+                query.Source = "go"
+                query.Package = pkg.ImportPath
+                query.Function = function
+                query.File = pos.Filename
+                query.Line = pos.Line
+                result = append(result, query)
+                break
+            }
+            return true
+        })
+    })
+    return result
+}
+
+// sqlcQueries lê os arquivos .sql com blocos "-- name: Nome :tipo" do sqlc
+func sqlcQueries(roots []string, ignore func(path string) bool) []Query {
+    var result []Query
+    for _, root := range roots {
+        _ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+            if err != nil || ignore(path) {
+                if d != nil && d.IsDir() && err == nil {
+                    return filepath.SkipDir
+                }
+                return nil
+            }
+            if d.IsDir() || !strings.HasSuffix(path, ".sql") {
+                return nil
+            }
+            queries, err := parseSQLCFile(path)
+            if err != nil {
+                slog.Warn("Erro ao ler arquivo SQL", "file", path, "error", err)
+                return nil
+            }
+            result = append(result, queries...)
+            return nil
+        })
+    }
+    return result
+}
+
+// parseSQLCFile separa as consultas nomeadas de um arquivo do sqlc
+func parseSQLCFile(path string) ([]Query, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+
+    var result []Query
+    var current *Query
+    var body strings.Builder
+    flush := func() {
+        if current == nil {
+            return
+        }
+        statement := strings.TrimSpace(body.String())
+        query := parseSQL(statement)
+        query.Source = "sqlc"
+        query.Function = current.Function
+        query.File = current.File
+        query.Line = current.Line
+        result = append(result, query)
+        body.Reset()
+    }
+
+    scanner := bufio.NewScanner(file)
+    line := 0
+    for scanner.Scan() {
+        line++
+        text := scanner.Text()
+        trimmed := strings.TrimSpace(text)
+        if rest, ok := strings.CutPrefix(trimmed, "-- name:"); ok {
+            flush()
+            name := strings.Fields(rest)
+            current = &Query{File: path, Line: line}
+            if len(name) > 0 {
+                current.Function = name[0]
+            }
+            continue
+        }
+        if current != nil {
+            body.WriteString(text)
+            body.WriteString("\n")
+        }
+    }
+    flush()
+    return result, scanner.Err()
+}
+
+// looksLikeSQL informa se o texto começa com uma palavra-chave de instrução SQL
+func looksLikeSQL(s string) bool {
+    tokens := tokenizeSQL(s)
+    return len(tokens) > 1 && tokens[0].kind == sqlIdent && sqlStatementKeywords[tokens[0].upper]
+}
+
+// TableUsages agrupa as instruções pelas tabelas que acessam
+func TableUsages(queries []Query) []TableUsage {
+    byTable := make(map[string]*TableUsage)
+    for _, q := range queries {
+        for _, table := range q.Tables {
+            usage, ok := byTable[table]
+            if !ok {
+                usage = &TableUsage{Table: table}
+                byTable[table] = usage
+            }
+            usage.Queries++
+            usage.Operations = analysis.AppendUnique(usage.Operations, q.Operation)
+            if q.Function != "" {
+                usage.Functions = analysis.AppendUnique(usage.Functions, q.Function)
+            }
+        }
+    }
+
+    var result []TableUsage
+    for _, table := range analysis.SortedKeys(byTable) {
+        usage := byTable[table]
+        sort.Strings(usage.Operations)
+        sort.Strings(usage.Functions)
+        result = append(result, *usage)
+    }
+    return result
+}
+
+// Tipos de token SQL
+const (
+    sqlIdent  = iota // identificador ou palavra-chave
+    sqlQuoted        // identificador entre aspas duplas ou crases
+    sqlString        // literal de texto
+    sqlNumber
+    sqlParam // $1, ?, :nome, @nome
+    sqlPunct
+)
+
+// sqlToken é um token de uma instrução SQL
+type sqlToken struct {
+    kind  int
+    text  string
+    upper string
+}
+
+// tokenizeSQL divide a instrução em tokens, descartando comentários
+func tokenizeSQL(s string) []sqlToken {
+    var tokens []sqlToken
+    isIdent := func(c byte) bool {
+        return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+    }
+    for i := 0; i < len(s); {
+        c := s[i]
+        switch {
+        case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+            i++
+        case c == '-' && i+1 < len(s) && s[i+1] == '-':
+            for i < len(s) && s[i] != '\n' {
+                i++
+            }
+        case c == '/' && i+1 < len(s) && s[i+1] == '*':
+            end := strings.Index(s[i+2:], "*/")
+            if end < 0 {
+                return tokens
+            }
+            i += end + 4
+        case c == '\'':
+            j := i + 1
+            for j < len(s) {
+                if s[j] == '\'' {
+                    if j+1 < len(s) && s[j+1] == '\'' {
+                        j += 2
+                        continue
+                    }
+                    break
+                }
+                j++
+            }
+            tokens = append(tokens, sqlToken{kind: sqlString, text: s[i:min(j+1, len(s))]})
+            i = j + 1
+        case c == '"' || c == '`':
+            j := strings.IndexByte(s[i+1:], c)
+            if j < 0 {
+                j = len(s) - i - 1
+            }
+            name := s[i+1 : i+1+j]
+            tokens = append(tokens, sqlToken{kind: sqlQuoted, text: name, upper: strings.ToUpper(name)})
+            i += j + 2
+        case c == '$' && i+1 < len(s) && s[i+1] >= '0' && s[i+1] <= '9',
+            c == '?',
+            (c == ':' || c == '@') && i+1 < len(s) && isIdent(s[i+1]) && (i == 0 || s[i-1] != ':'):
+            j := i + 1
+            for j < len(s) && isIdent(s[j]) {
+                j++
+            }
+            tokens = append(tokens, sqlToken{kind: sqlParam, text: s[i:j]})
+            i = j
+        case c >= '0' && c <= '9':
+            j := i
+            for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+                j++
+            }
+            tokens = append(tokens, sqlToken{kind: sqlNumber, text: s[i:j]})
+            i = j
+        case isIdent(c):
+            j := i
+            for j < len(s) && isIdent(s[j]) {
+                j++
+            }
+            tokens = append(tokens, sqlToken{kind: sqlIdent, text: s[i:j], upper: strings.ToUpper(s[i:j])})
+            i = j
+        case c == ':' && i+1 < len(s) && s[i+1] == ':':
+            tokens = append(tokens, sqlToken{kind: sqlPunct, text: "::"})
+            i += 2
+        default:
+            tokens = append(tokens, sqlToken{kind: sqlPunct, text: string(c)})
+            i++
+        }
+    }
+    return tokens
+}
+
+// sqlKeywords são palavras reservadas que nunca são tratadas como colunas ou aliases
+var sqlKeywords = map[string]bool{}
+
+func init() {
+    for _, k := range strings.Fields(`SELECT INSERT UPDATE DELETE WITH RECURSIVE MERGE REPLACE CREATE ALTER DROP TRUNCATE
+        FROM WHERE JOIN INNER LEFT RIGHT FULL OUTER CROSS NATURAL LATERAL ON USING INTO VALUES VALUE SET
+        AND OR NOT IN IS NULL TRUE FALSE LIKE ILIKE BETWEEN EXISTS ANY ALL SOME DISTINCT AS ASC DESC
+        ORDER GROUP BY HAVING LIMIT OFFSET FETCH FIRST NEXT ROW ROWS ONLY UNION INTERSECT EXCEPT
+        CASE WHEN THEN ELSE END RETURNING CONFLICT DO NOTHING DEFAULT TABLE IF DUPLICATE KEY
+        INTERVAL CURRENT_DATE CURRENT_TIME CURRENT_TIMESTAMP CURRENT_USER LOCALTIME LOCALTIMESTAMP
+        FOR SHARE NOWAIT SKIP LOCKED OVER PARTITION WINDOW FILTER WITHIN NULLS ESCAPE COLLATE
+        IGNORE LOW_PRIORITY HIGH_PRIORITY DELAYED QUICK MATCHED SIMILAR TO UNKNOWN ARRAY`) {
+        sqlKeywords[k] = true
+    }
+}
+
+// sqlFromFunctions são funções cujo argumento usa FROM sem referenciar tabelas
+var sqlFromFunctions = map[string]bool{"EXTRACT": true, "SUBSTRING": true, "TRIM": true, "OVERLAY": true, "POSITION": true}
+
+// parseSQL identifica a operação, as tabelas e as colunas referenciadas por uma instrução.
+// É uma análise léxica aproximada, suficiente para relacionar consultas ao esquema
+func parseSQL(statement string) Query {
+    query := Query{Statement: statement}
+    tokens := tokenizeSQL(statement)
+    if len(tokens) == 0 {
+        return query
+    }
+
+    isWord := func(i int, words ...string) bool {
+        if i < 0 || i >= len(tokens) || tokens[i].kind != sqlIdent {
+            return false
+        }
+        for _, w := range words {
+            if tokens[i].upper == w {
+                return true
+            }
+        }
+        return false
+    }
+    isPunct := func(i int, p string) bool {
+        return i >= 0 && i < len(tokens) && tokens[i].kind == sqlPunct && tokens[i].text == p
+    }
+    isName := func(i int) bool {
+        return i >= 0 && i < len(tokens) && (tokens[i].kind == sqlQuoted || tokens[i].kind == sqlIdent && !sqlKeywords[tokens[i].upper])
+    }
+
+    // Profundidade de parênteses e função que abriu cada nível
+    depth := make([]int, len(tokens))
+    opener := make([]string, len(tokens))
+    var stack []string
+    for i := range tokens {
+        if isPunct(i, ")") && len(stack) > 0 {
+            stack = stack[:len(stack)-1]
+        }
+        depth[i] = len(stack)
+        if len(stack) > 0 {
+            opener[i] = stack[len(stack)-1]
+        }
+        if isPunct(i, "(") {
+            name := ""
+            if i > 0 && tokens[i-1].kind == sqlIdent {
+                name = tokens[i-1].upper
+            }
+            stack = append(stack, name)
+        }
+    }
+
+    // Operação: a primeira instrução de nível zero após as CTEs
+    query.Operation = tokens[0].upper
+    if query.Operation == "WITH" {
+        for i := 1; i < len(tokens); i++ {
+            if depth[i] == 0 && isWord(i, "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE") {
+                query.Operation = tokens[i].upper
+                break
+            }
+        }
+    }
+    dml := map[string]bool{"SELECT": true, "INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "REPLACE": true}[query.Operation]
+
+    const (
+        roleTable = iota + 1
+        roleAlias
+        roleCTE
+        roleSkip
+    )
+    role := make([]int, len(tokens))
+    ctes := make(map[string]bool)
+    aliases := make(map[string]string) // alias ou nome da tabela (minúsculas) -> tabela
+    var tables []string
+
+    // CTEs: nome [(colunas)] AS (
+    for i := range tokens {
+        if !isName(i) || (i > 0 && !isWord(i-1, "WITH", "RECURSIVE") && !isPunct(i-1, ",")) {
+            continue
+        }
+        j := i + 1
+        if isPunct(j, "(") {
+            for j < len(tokens) && !isPunct(j, ")") {
+                role[j] = roleSkip
+                j++
+            }
+            j++
+        }
+        if isWord(j, "AS") && isPunct(j+1, "(") {
+            role[i] = roleCTE
+            ctes[strings.ToLower(tokens[i].text)] = true
+        }
+    }
+
+    // Tabelas e aliases após FROM, JOIN, INTO, UPDATE, USING e TABLE
+    readName := func(i int) (string, int) {
+        if !isName(i) {
+            return "", i
+        }
+        name := tokens[i].text
+        role[i] = roleTable
+        for isPunct(i+1, ".") && isName(i+2) {
+            role[i+2] = roleTable
+            name += "." + tokens[i+2].text
+            i += 2
+        }
+        return name, i + 1
+    }
+    for i := range tokens {
+        if !isWord(i, "FROM", "JOIN", "INTO", "UPDATE", "USING", "TABLE") {
+            continue
+        }
+        if sqlFromFunctions[opener[i]] || isWord(i, "FROM") && isWord(i-1, "DISTINCT") {
+            continue
+        }
+        if isWord(i, "USING") && isPunct(i+1, "(") {
+            continue // JOIN ... USING (coluna)
+        }
+        j := i + 1
+        for {
+            for isWord(j, "ONLY", "LATERAL", "IGNORE", "LOW_PRIORITY", "QUICK") {
+                j++
+            }
+            if isPunct(j, "(") {
+                if isWord(i, "FROM", "JOIN", "USING") {
+                    query.Derived = true
+                }
+                break
+            }
+            name, next := readName(j)
+            if name == "" {
+                break
+            }
+            lower := strings.ToLower(name)
+            if ctes[lower] {
+                role[j] = roleCTE
+            } else {
+                tables = analysis.AppendUnique(tables, name)
+                aliases[lower] = name
+                if k := strings.LastIndex(lower, "."); k >= 0 {
+                    aliases[lower[k+1:]] = name
+                }
+            }
+            j = next
+            if isWord(j, "AS") {
+                j++
+            }
+            if isName(j) && !isPunct(j+1, ".") {
+                role[j] = roleAlias
+                if !ctes[lower] {
+                    aliases[strings.ToLower(tokens[j].text)] = name
+                }
+                j++
+            }
+            if !isWord(i, "FROM", "USING") || !isPunct(j, ",") {
+                break
+            }
+            j++
+        }
+    }
+    if query.Operation == "INSERT" && len(tables) > 0 {
+        aliases["excluded"] = tables[0]
+    }
+    query.Tables = tables
+    if !dml {
+        return query
+    }
+
+    // Aliases de colunas (AS nome ou expressão seguida de nome) não são colunas
+    columnAliases := make(map[string]bool)
+    for i := range tokens {
+        if role[i] != 0 || !isName(i) {
+            continue
+        }
+        prev := i - 1
+        if isWord(prev, "AS", "END") || isPunct(prev, "::") ||
+            prev >= 0 && (isName(prev) || isPunct(prev, ")") || tokens[prev].kind == sqlString || tokens[prev].kind == sqlNumber) {
+            role[i] = roleSkip
+            if isWord(prev, "AS") || !isPunct(prev, "::") {
+                columnAliases[strings.ToLower(tokens[i].text)] = true
+            }
+        }
+    }
+
+    seen := make(map[Column]bool)
+    addColumn := func(c Column) {
+        if !seen[c] {
+            seen[c] = true
+            query.Columns = append(query.Columns, c)
+        }
+    }
+    for i := range tokens {
+        if role[i] != 0 || !isName(i) || isPunct(i+1, "(") {
+            continue
+        }
+        name := tokens[i].text
+        if isPunct(i+1, ".") {
+            // Referência qualificada: alias.coluna
+            if i+2 < len(tokens) && isName(i+2) && role[i+2] == 0 {
+                if table, ok := aliases[strings.ToLower(name)]; ok {
+                    addColumn(Column{Table: table, Name: tokens[i+2].text})
+                }
+                role[i+2] = roleSkip
+            }
+            continue
+        }
+        if isPunct(i-1, ".") || columnAliases[strings.ToLower(name)] {
+            continue
+        }
+        column := Column{Name: name}
+        if len(tables) == 1 {
+            column.Table = tables[0]
+        }
+        addColumn(column)
+    }
+    return query
+}
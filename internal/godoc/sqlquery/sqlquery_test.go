@@ -0,0 +1,86 @@
+package sqlquery_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+	"github.com/edgardnogueira/aimap/internal/godoc/sqlquery"
+)
+
+func TestSQL(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "store/store.go": `package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getUser = "SELECT id, name, emial FROM users WHERE id = $1"
+
+type Store struct{ db *sql.DB }
+
+func (s *Store) Get(ctx context.Context, id int) error {
+	_, err := s.db.QueryContext(ctx, getUser, id)
+	return err
+}
+
+func (s *Store) Orders(ctx context.Context, id int) error {
+	_, err := s.db.QueryContext(ctx, "SELECT o.id, u.name FROM orders o JOIN users u ON u.id = o.user_id WHERE o.total > 0")
+	return err
+}
+`,
+        "queries/audit.sql": `-- name: InsertAudit :exec
+INSERT INTO audit_log (user_id, action) VALUES ($1, $2);
+`,
+    })
+
+    // Os arquivos do sqlc são procurados a partir da raiz do módulo
+    root := filepath.Dir(pass.Packages[0].Dir)
+    info := sqlquery.Build(pass, []string{root}, func(string) bool { return false })
+    if len(info.Queries) != 3 {
+        t.Fatalf("consultas = %+v", info.Queries)
+    }
+
+    var got []string
+    for _, q := range info.Queries {
+        var columns []string
+        for _, c := range q.Columns {
+            columns = append(columns, c.Table+"."+c.Name)
+        }
+        got = append(got, fmt.Sprintf("%s %s %s %v %v", q.Source, q.Function, q.Operation, q.Tables, columns))
+    }
+    want := []string{
+        "go Store.Get SELECT [users] [users.id users.name users.emial]",
+        "go Store.Orders SELECT [orders users] [orders.id users.name users.id orders.user_id orders.total]",
+        "sqlc InsertAudit INSERT [audit_log] [audit_log.user_id audit_log.action]",
+    }
+    if strings.Join(got, "\n") != strings.Join(want, "\n") {
+        t.Errorf("consultas =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+    }
+
+    report := sqlquery.Check(info.Queries, []sqlquery.SchemaTable{
+        {Schema: "public", Name: "users", Columns: []string{"id", "name", "email"}},
+        {Schema: "public", Name: "orders", Columns: []string{"id", "user_id", "total"}},
+        {Schema: "public", Name: "products", Columns: []string{"id"}},
+    })
+    var issues []string
+    for _, issue := range report.Issues {
+        issues = append(issues, issue.Kind+":"+issue.Table+":"+issue.Column+":"+issue.Function)
+    }
+    wantIssues := "missing_column:public.users:emial:Store.Get missing_table:audit_log::InsertAudit"
+    if got := strings.Join(issues, " "); got != wantIssues {
+        t.Errorf("problemas = %q; want %q", got, wantIssues)
+    }
+    if len(report.Unused) != 1 || report.Unused[0] != "public.products" {
+        t.Errorf("tabelas sem uso = %v", report.Unused)
+    }
+    for _, usage := range report.Tables {
+        if usage.Table == "public.users" && strings.Join(usage.Functions, ",") != "Store.Get,Store.Orders" {
+            t.Errorf("uso de public.users = %+v", usage)
+        }
+    }
+}
@@ -0,0 +1,117 @@
+// Package structtag interpreta as tags json, yaml e validate/binding dos campos de struct
+package structtag
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Tags representa as tags de um campo de struct já interpretadas
+type Tags struct {
+    JSON     *Name  `json:"json,omitempty"     yaml:"json,omitempty"`
+    YAML     *Name  `json:"yaml,omitempty"     yaml:"yaml,omitempty"`
+    Validate []Rule `json:"validate,omitempty" yaml:"validate,omitempty"` // regras de validate e binding
+}
+
+// Name representa uma tag de serialização (json ou yaml)
+type Name struct {
+    Name      string   `json:"name,omitempty"      yaml:"name,omitempty"`
+    Skip      bool     `json:"skip,omitempty"      yaml:"skip,omitempty"` // tag "-"
+    OmitEmpty bool     `json:"omitempty,omitempty" yaml:"omitempty,omitempty"`
+    Inline    bool     `json:"inline,omitempty"    yaml:"inline,omitempty"`
+    AsString  bool     `json:"string,omitempty"    yaml:"string,omitempty"` // opção ",string" do encoding/json
+    Options   []string `json:"options,omitempty"   yaml:"options,omitempty"`
+}
+
+// Rule representa uma regra do go-playground/validator (ex.: min=3)
+type Rule struct {
+    Name  string `json:"name"            yaml:"name"`
+    Param string `json:"param,omitempty" yaml:"param,omitempty"`
+}
+
+// Parse interpreta as tags json, yaml, validate e binding de um campo
+func Parse(tag string) *Tags {
+    if tag == "" {
+        return nil
+    }
+    st := reflect.StructTag(tag)
+    tags := &Tags{
+        JSON: parseName(st, "json"),
+        YAML: parseName(st, "yaml"),
+    }
+    for _, key := range []string{"validate", "binding"} {
+        if value, ok := st.Lookup(key); ok {
+            tags.Validate = append(tags.Validate, parseRules(value)...)
+        }
+    }
+    if tags.JSON == nil && tags.YAML == nil && len(tags.Validate) == 0 {
+        return nil
+    }
+    return tags
+}
+
+// parseName interpreta uma tag no formato "nome,opção,opção"
+func parseName(st reflect.StructTag, key string) *Name {
+    value, ok := st.Lookup(key)
+    if !ok {
+        return nil
+    }
+    name, rest, _ := strings.Cut(value, ",")
+    tag := &Name{Name: name}
+    if name == "-" && rest == "" {
+        return &Name{Skip: true}
+    }
+    if rest == "" {
+        return tag
+    }
+    for _, opt := range strings.Split(rest, ",") {
+        switch opt {
+        case "omitempty", "omitzero":
+            tag.OmitEmpty = true
+        case "inline":
+            tag.Inline = true
+        case "string":
+            tag.AsString = true
+        }
+        tag.Options = append(tag.Options, opt)
+    }
+    return tag
+}
+
+// parseRules separa as regras de uma tag validate/binding ("required,min=3")
+func parseRules(value string) []Rule {
+    var rules []Rule
+    for _, part := range strings.Split(value, ",") {
+        part = strings.TrimSpace(part)
+        if part == "" {
+            continue
+        }
+        name, param, _ := strings.Cut(part, "=")
+        rules = append(rules, Rule{Name: name, Param: param})
+    }
+    return rules
+}
+
+// Tag retorna a tag de serialização do formato informado (json ou yaml)
+func (t *Tags) Tag(format string) *Name {
+    if t == nil {
+        return nil
+    }
+    if format == "yaml" {
+        return t.YAML
+    }
+    return t.JSON
+}
+
+// HasRule informa se o campo declara a regra de validação
+func (t *Tags) HasRule(name string) bool {
+    if t == nil {
+        return false
+    }
+    for _, rule := range t.Validate {
+        if rule.Name == name {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,188 @@
+// Package testcover sobrepõe um arquivo go test -coverprofile às funções do projeto
+package testcover
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+)
+
+// Info resume a cobertura de testes lida de um arquivo go test -coverprofile
+type Info struct {
+    Profile    string                `json:"profile"            yaml:"profile"`
+    Mode       string                `json:"mode"               yaml:"mode"` // set, count, atomic
+    Statements int                   `json:"statements"         yaml:"statements"`
+    Covered    int                   `json:"covered"            yaml:"covered"`
+    Percent    float64               `json:"percent"            yaml:"percent"`
+    Packages   []Package `json:"packages"           yaml:"packages"`
+    Untested   []UntestedFunc        `json:"untested,omitempty" yaml:"untested,omitempty"` // funções sem nenhuma instrução coberta
+
+    blocks   map[string][]coverBlock // blocos por importpath/arquivo.go
+    endLines map[string]int          // linha final de cada função, por arquivo:linha inicial
+}
+
+// Package representa a cobertura de testes de um pacote
+type Package struct {
+    Package    string  `json:"package"    yaml:"package"`
+    Statements int     `json:"statements" yaml:"statements"`
+    Covered    int     `json:"covered"    yaml:"covered"`
+    Percent    float64 `json:"percent"    yaml:"percent"`
+}
+
+// Func representa a cobertura de testes de uma função ou método
+type Func struct {
+    Statements int     `json:"statements" yaml:"statements"`
+    Covered    int     `json:"covered"    yaml:"covered"`
+    Percent    float64 `json:"percent"    yaml:"percent"`
+}
+
+// UntestedFunc representa uma função ou método que os testes não executam
+type UntestedFunc struct {
+    Package string `json:"package" yaml:"package"`
+    Name    string `json:"name"    yaml:"name"` // Func ou Tipo.Metodo
+    File    string `json:"file"    yaml:"file"`
+    Line    int    `json:"line"    yaml:"line"`
+}
+
+// coverBlock representa um bloco do coverprofile
+type coverBlock struct {
+    startLine, endLine int
+    statements         int
+    count              int
+}
+
+// parseCoverProfile lê um arquivo gerado por go test -coverprofile, agrupando os
+// blocos por arquivo (importpath/arquivo.go). Blocos repetidos (vários binários de
+// teste ou -coverpkg) são unidos somando as execuções
+func parseCoverProfile(profilePath string) (string, map[string][]coverBlock, error) {
+    f, err := os.Open(profilePath)
+    if err != nil {
+        return "", nil, fmt.Errorf("erro ao abrir coverprofile: %w", err)
+    }
+    defer f.Close()
+
+    mode := ""
+    blocks := make(map[string][]coverBlock)
+    index := make(map[string]int)
+    scanner := bufio.NewScanner(f)
+    for lineNum := 1; scanner.Scan(); lineNum++ {
+        line := strings.TrimSpace(scanner.Text())
+        if line == "" {
+            continue
+        }
+        if strings.HasPrefix(line, "mode:") {
+            mode = strings.TrimSpace(strings.TrimPrefix(line, "mode:"))
+            continue
+        }
+        // importpath/arquivo.go:10.2,12.16 2 1
+        colon := strings.LastIndex(line, ":")
+        fields := strings.Fields(line[colon+1:])
+        if colon < 0 || len(fields) != 3 {
+            return "", nil, fmt.Errorf("linha %d inválida no coverprofile: %q", lineNum, line)
+        }
+        file := line[:colon]
+        start, end, ok := strings.Cut(fields[0], ",")
+        startLine, err1 := strconv.Atoi(strings.SplitN(start, ".", 2)[0])
+        endLine, err2 := strconv.Atoi(strings.SplitN(end, ".", 2)[0])
+        statements, err3 := strconv.Atoi(fields[1])
+        count, err4 := strconv.Atoi(fields[2])
+        if !ok || err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+            return "", nil, fmt.Errorf("linha %d inválida no coverprofile: %q", lineNum, line)
+        }
+
+        key := file + ":" + fields[0]
+        if i, ok := index[key]; ok {
+            blocks[file][i].count += count
+            continue
+        }
+        index[key] = len(blocks[file])
+        blocks[file] = append(blocks[file], coverBlock{startLine, endLine, statements, count})
+    }
+    if err := scanner.Err(); err != nil {
+        return "", nil, fmt.Errorf("erro ao ler coverprofile: %w", err)
+    }
+    return mode, blocks, nil
+}
+
+// Load lê o coverprofile e totaliza a cobertura de cada pacote
+func Load(pass *analysis.Pass, profilePath string) (*Info, error) {
+    mode, blocks, err := parseCoverProfile(profilePath)
+    if err != nil {
+        return nil, err
+    }
+    cov := &Info{Profile: profilePath, Mode: mode, blocks: blocks, endLines: make(map[string]int)}
+
+    // Totais por pacote: todos os blocos do profile, inclusive fora de funções documentadas
+    byPackage := make(map[string]*Package)
+    for file, list := range blocks {
+        pkg := path.Dir(file)
+        if byPackage[pkg] == nil {
+            byPackage[pkg] = &Package{Package: pkg}
+        }
+        for _, b := range list {
+            byPackage[pkg].Statements += b.statements
+            if b.count > 0 {
+                byPackage[pkg].Covered += b.statements
+            }
+        }
+    }
+    for _, pkg := range analysis.SortedKeys(byPackage) {
+        p := byPackage[pkg]
+        p.Percent = coveragePercent(p.Covered, p.Statements)
+        cov.Statements += p.Statements
+        cov.Covered += p.Covered
+        cov.Packages = append(cov.Packages, *p)
+    }
+    cov.Percent = coveragePercent(cov.Covered, cov.Statements)
+
+    // Linha final de cada função, indexada pelo arquivo e linha inicial
+    for _, dir := range pass.Dirs {
+        for _, file := range dir.Files {
+            for _, decl := range file.Decls {
+                if fd, ok := decl.(*ast.FuncDecl); ok {
+                    pos := pass.Fset.Position(fd.Pos())
+                    cov.endLines[fmt.Sprintf("%s:%d", pos.Filename, pos.Line)] = pass.Fset.Position(fd.End()).Line
+                }
+            }
+        }
+    }
+    return cov, nil
+}
+
+// Func retorna a cobertura da função declarada na linha do arquivo
+func (c *Info) Func(importPath, file string, line int) *Func {
+    end, ok := c.endLines[fmt.Sprintf("%s:%d", file, line)]
+    list, found := c.blocks[importPath+"/"+filepath.Base(file)]
+    if !ok || !found {
+        return nil
+    }
+    fc := &Func{}
+    for _, b := range list {
+        if b.startLine >= line && b.endLine <= end {
+            fc.Statements += b.statements
+            if b.count > 0 {
+                fc.Covered += b.statements
+            }
+        }
+    }
+    if fc.Statements == 0 {
+        return nil
+    }
+    fc.Percent = coveragePercent(fc.Covered, fc.Statements)
+    return fc
+}
+
+// coveragePercent calcula a porcentagem de instruções cobertas
+func coveragePercent(covered, total int) float64 {
+    if total == 0 {
+        return 0
+    }
+    return float64(covered) * 100 / float64(total)
+}
@@ -0,0 +1,58 @@
+package testcover_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgardnogueira/aimap/internal/godoc/godoctest"
+	"github.com/edgardnogueira/aimap/internal/godoc/testcover"
+)
+
+func TestTestCoverage(t *testing.T) {
+    pass := godoctest.LoadPass(t, map[string]string{
+        "calc/calc.go": `package calc
+
+type Calc struct{}
+
+func Add(a, b int) int {
+    return a + b
+}
+
+func (c *Calc) Div(a, b int) int {
+    if b == 0 {
+        return 0
+    }
+    return a / b
+}
+`,
+    })
+    profile := filepath.Join(t.TempDir(), "coverage.out")
+    lines := "mode: set\n" +
+        "example.com/app/calc/calc.go:5.24,7.2 1 0\n" +
+        "example.com/app/calc/calc.go:9.34,10.15 1 1\n" +
+        "example.com/app/calc/calc.go:10.15,12.6 1 0\n" +
+        "example.com/app/calc/calc.go:13.5,13.17 1 1\n"
+    if err := os.WriteFile(profile, []byte(lines), 0644); err != nil {
+        t.Fatal(err)
+    }
+
+    cov, err := testcover.Load(pass, profile)
+    if err != nil {
+        t.Fatalf("Erro ao ler cobertura: %v", err)
+    }
+    if cov.Statements != 4 || cov.Covered != 2 || cov.Percent != 50 {
+        t.Fatalf("cobertura = %+v; want 2/4 (50%%)", cov)
+    }
+    if len(cov.Packages) != 1 || cov.Packages[0].Package != "example.com/app/calc" {
+        t.Errorf("pacotes = %+v", cov.Packages)
+    }
+
+    file := filepath.Join(pass.Packages[0].Dir, "calc.go")
+    if add := cov.Func("example.com/app/calc", file, 5); add == nil || add.Covered != 0 || add.Statements != 1 {
+        t.Errorf("cobertura de Add = %+v; want 0/1", add)
+    }
+    if div := cov.Func("example.com/app/calc", file, 9); div == nil || div.Covered != 2 || div.Statements != 3 {
+        t.Errorf("cobertura de Calc.Div = %+v; want 2/3", div)
+    }
+}
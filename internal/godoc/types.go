@@ -1,20 +1,71 @@
 package godoc
 
+import (
+	"github.com/edgardnogueira/aimap/internal/godoc/analysis"
+	"github.com/edgardnogueira/aimap/internal/godoc/apidiff"
+	"github.com/edgardnogueira/aimap/internal/godoc/boot"
+	"github.com/edgardnogueira/aimap/internal/godoc/callgraph"
+	"github.com/edgardnogueira/aimap/internal/godoc/classdiagram"
+	"github.com/edgardnogueira/aimap/internal/godoc/cli"
+	"github.com/edgardnogueira/aimap/internal/godoc/concurrency"
+	"github.com/edgardnogueira/aimap/internal/godoc/configref"
+	"github.com/edgardnogueira/aimap/internal/godoc/deadcode"
+	"github.com/edgardnogueira/aimap/internal/godoc/depgraph"
+	"github.com/edgardnogueira/aimap/internal/godoc/errcatalog"
+	"github.com/edgardnogueira/aimap/internal/godoc/gomod"
+	"github.com/edgardnogueira/aimap/internal/godoc/logcatalog"
+	"github.com/edgardnogueira/aimap/internal/godoc/messaging"
+	"github.com/edgardnogueira/aimap/internal/godoc/metrics"
+	"github.com/edgardnogueira/aimap/internal/godoc/pprof"
+	"github.com/edgardnogueira/aimap/internal/godoc/routes"
+	"github.com/edgardnogueira/aimap/internal/godoc/sqlquery"
+	"github.com/edgardnogueira/aimap/internal/godoc/structtag"
+	"github.com/edgardnogueira/aimap/internal/godoc/testcover"
+)
+
 // ProjectDoc representa a documentação completa do projeto
 type ProjectDoc struct {
-    Directories []DirectoryDoc `json:"directories" yaml:"directories"`
+    Modules      []gomod.Module   `json:"modules,omitempty"      yaml:"modules,omitempty"`
+    Workspace    *gomod.Workspace `json:"workspace,omitempty"    yaml:"workspace,omitempty"`
+    Directories  []DirectoryDoc   `json:"directories"            yaml:"directories"`
+    CallGraph    *callgraph.Graph `json:"call_graph,omitempty"   yaml:"call_graph,omitempty"`
+    Dependencies *depgraph.Graph  `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+    Classes      *classdiagram.Graph `json:"classes,omitempty"      yaml:"classes,omitempty"`
+    Routes       []routes.Route          `json:"routes,omitempty"       yaml:"routes,omitempty"`
+    CLI          []cli.App        `json:"cli,omitempty"          yaml:"cli,omitempty"` // comandos e flags dos binários
+    Configuration []configref.Setting `json:"configuration,omitempty" yaml:"configuration,omitempty"` // variáveis de ambiente e flags
+    SQL          *sqlquery.Info    `json:"sql,omitempty"          yaml:"sql,omitempty"`
+    BuildMatrix  *BuildMatrix     `json:"build_matrix,omitempty" yaml:"build_matrix,omitempty"`
+    Metrics      []metrics.Package `json:"metrics,omitempty"      yaml:"metrics,omitempty"`
+    DocCoverage  *DocCoverage     `json:"doc_coverage,omitempty" yaml:"doc_coverage,omitempty"`
+    TestCoverage *testcover.Info   `json:"test_coverage,omitempty" yaml:"test_coverage,omitempty"` // sobreposição do coverprofile
+    Profile      *pprof.Info      `json:"profile,omitempty"      yaml:"profile,omitempty"` // sobreposição do perfil pprof
+    DeadCode     []deadcode.Symbol `json:"dead_code,omitempty"    yaml:"dead_code,omitempty"` // declarações não utilizadas
+    Messaging    *messaging.Bus   `json:"messaging,omitempty"    yaml:"messaging,omitempty"` // tópicos, produtores e consumidores
+    Boot         *boot.Map        `json:"boot,omitempty"         yaml:"boot,omitempty"` // inicialização dos binários
+    Diagnostics  []analysis.Diagnostic     `json:"diagnostics,omitempty"  yaml:"diagnostics,omitempty"`
+    API          map[string]*apidiff.Package `json:"-" yaml:"-"` // API exportada por import path, comparada pelo apidiff
 }
 
 // DirectoryDoc representa a documentação de um diretório
 type DirectoryDoc struct {
-    Path  string    `json:"path"  yaml:"path"`
-    Files []FileDoc `json:"files" yaml:"files"`
+    Path       string   `json:"path"               yaml:"path"`
+    ImportPath string   `json:"import_path"        yaml:"import_path"`
+    Module     string   `json:"module,omitempty"   yaml:"module,omitempty"` // módulo ao qual o pacote pertence
+    Files    []FileDoc  `json:"files"              yaml:"files"`
+    Examples []Example  `json:"examples,omitempty" yaml:"examples,omitempty"` // exemplos do pacote
+    Tests    []TestFunc `json:"tests,omitempty"    yaml:"tests,omitempty"`
+    Concurrency *concurrency.Info `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+    Errors      *errcatalog.Catalog `json:"errors,omitempty"      yaml:"errors,omitempty"`
+    Logging     *logcatalog.Catalog `json:"logging,omitempty"     yaml:"logging,omitempty"`
 }
 
 // FileDoc representa a documentação de um arquivo
 type FileDoc struct {
     FileName   string      `json:"file_name"  yaml:"file_name"`
     Package    string      `json:"package"    yaml:"package"`
+    Constraint string      `json:"constraint,omitempty" yaml:"constraint,omitempty"` // restrição de build (//go:build e sufixos _GOOS/_GOARCH)
+    Platforms  []string    `json:"platforms,omitempty"  yaml:"platforms,omitempty"`  // alvos da matriz de build em que o arquivo é compilado
     Imports    []string    `json:"imports"    yaml:"imports"`
     Interfaces []Interface `json:"interfaces" yaml:"interfaces"`
     Structs    []Struct    `json:"structs"    yaml:"structs"`
@@ -30,6 +81,7 @@ type Interface struct {
     File    string   `json:"file"    yaml:"file"`
     Line    int      `json:"line"    yaml:"line"`
     Methods []Method `json:"methods" yaml:"methods"`
+    Examples []Example `json:"examples,omitempty" yaml:"examples,omitempty"`
 }
 
 // Method representa um método de interface
@@ -43,10 +95,13 @@ type Method struct {
 
 // StructField representa um campo de struct
 type StructField struct {
-    Name string `json:"name" yaml:"name"`
-    Type string `json:"type" yaml:"type"`
-    Tag  string `json:"tag"  yaml:"tag"`
-    Doc  string `json:"doc"  yaml:"doc"`
+    Name string          `json:"name"           yaml:"name"`
+    Type string          `json:"type"           yaml:"type"`
+    Tag  string          `json:"tag"            yaml:"tag"`
+    Tags *structtag.Tags `json:"tags,omitempty" yaml:"tags,omitempty"` // tags json, yaml e validate/binding interpretadas
+    Doc  string          `json:"doc"            yaml:"doc"`
+    File string          `json:"file"           yaml:"file"`
+    Line int             `json:"line"           yaml:"line"`
 }
 
 // Struct representa uma struct Go
@@ -57,15 +112,22 @@ type Struct struct {
     Line    int           `json:"line"         yaml:"line"`
     Fields  []StructField `json:"fields"       yaml:"fields"`
     Methods []MethodInfo  `json:"methods"      yaml:"methods"`
+    Examples []Example    `json:"examples,omitempty" yaml:"examples,omitempty"`
 }
 
 // MethodInfo representa um método de struct
 type MethodInfo struct {
-    Name string `json:"name" yaml:"name"`
-    Doc  string `json:"doc"  yaml:"doc"`
-    Sig  string `json:"sig"  yaml:"sig"`
-    File string `json:"file" yaml:"file"`
-    Line int    `json:"line" yaml:"line"`
+    Name     string   `json:"name"                yaml:"name"`
+    Doc      string   `json:"doc"                 yaml:"doc"`
+    Sig      string   `json:"sig"                 yaml:"sig"`
+    File     string   `json:"file"                yaml:"file"`
+    Line     int      `json:"line"                yaml:"line"`
+    Calls    []string `json:"calls,omitempty"     yaml:"calls,omitempty"`
+    CalledBy []string     `json:"called_by,omitempty" yaml:"called_by,omitempty"`
+    Metrics  *metrics.Func `json:"metrics,omitempty"   yaml:"metrics,omitempty"`
+    Examples []Example    `json:"examples,omitempty"  yaml:"examples,omitempty"`
+    Coverage *testcover.Func `json:"coverage,omitempty"  yaml:"coverage,omitempty"` // cobertura de testes (coverage_profile)
+    Profile  *pprof.Func     `json:"profile,omitempty"   yaml:"profile,omitempty"`  // participação no perfil pprof
 }
 
 // ConstVar representa uma constante ou variável
@@ -75,15 +137,22 @@ type ConstVar struct {
     File string `json:"file" yaml:"file"`
     Line int    `json:"line" yaml:"line"`
     Type string `json:"type" yaml:"type"`
+    Value string `json:"value,omitempty" yaml:"value,omitempty"` // expressão de inicialização
 }
 
 // FuncInfo representa uma função
 type FuncInfo struct {
-    Name string `json:"name" yaml:"name"`
-    Doc  string `json:"doc"  yaml:"doc"`
-    File string `json:"file" yaml:"file"`
-    Line int    `json:"line" yaml:"line"`
-    Sig  string `json:"sig"  yaml:"sig"`
+    Name     string   `json:"name"                yaml:"name"`
+    Doc      string   `json:"doc"                 yaml:"doc"`
+    File     string   `json:"file"                yaml:"file"`
+    Line     int      `json:"line"                yaml:"line"`
+    Sig      string   `json:"sig"                 yaml:"sig"`
+    Calls    []string `json:"calls,omitempty"     yaml:"calls,omitempty"`
+    CalledBy []string     `json:"called_by,omitempty" yaml:"called_by,omitempty"`
+    Metrics  *metrics.Func `json:"metrics,omitempty"   yaml:"metrics,omitempty"`
+    Examples []Example    `json:"examples,omitempty"  yaml:"examples,omitempty"`
+    Coverage *testcover.Func `json:"coverage,omitempty"  yaml:"coverage,omitempty"` // cobertura de testes (coverage_profile)
+    Profile  *pprof.Func     `json:"profile,omitempty"   yaml:"profile,omitempty"`  // participação no perfil pprof
 }
 
 // DirNode representa um nó na árvore de diretórios
@@ -77,6 +77,9 @@ func (a *Analyzer) Analyze() (*Resources, error) {
             Namespace: node.Namespace,
             Labels:    node.Labels,
             Relations: node.Relations,
+            Containers: node.Containers,
+            File:      node.File,
+            Line:      node.Line,
         }
         resources.Resources = append(resources.Resources, resource)
     }
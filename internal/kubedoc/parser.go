@@ -55,7 +55,10 @@ func (p *Parser) ParseFile(filename string) error {
     
     decode := serializer.NewCodecFactory(scheme.Scheme).UniversalDeserializer().Decode
     
+    line := 1
     for i, doc := range documents {
+        start := line + leadingBlankLines(doc)
+        line += strings.Count(doc, "\n")
         if strings.TrimSpace(doc) == "" {
             continue
         }
@@ -69,7 +72,7 @@ func (p *Parser) ParseFile(filename string) error {
             continue
         }
 
-        if err := p.parseResource(obj, kind.Kind); err != nil {
+        if err := p.parseResource(obj, kind.Kind, filename, start); err != nil {
             slog.Error("Erro ao processar recurso", 
                 "kind", kind.Kind,
                 "error", err)
@@ -80,7 +83,19 @@ func (p *Parser) ParseFile(filename string) error {
     return nil
 }
 
-func (p *Parser) parseResource(obj runtime.Object, kind string) error {
+// leadingBlankLines conta as linhas em branco no início de um documento YAML
+func leadingBlankLines(doc string) int {
+    count := 0
+    for _, l := range strings.Split(doc, "\n") {
+        if strings.TrimSpace(l) != "" {
+            break
+        }
+        count++
+    }
+    return count
+}
+
+func (p *Parser) parseResource(obj runtime.Object, kind, filename string, line int) error {
     // Extrai metadados comuns
     metadata, err := meta.Accessor(obj)
     if err != nil {
@@ -92,6 +107,8 @@ func (p *Parser) parseResource(obj runtime.Object, kind string) error {
         Kind:      kind,
         Namespace: metadata.GetNamespace(),
         Labels:    metadata.GetLabels(),
+        File:      filename,
+        Line:      line,
     }
 
     // Converte o spec para map[string]interface{}
@@ -99,6 +116,7 @@ func (p *Parser) parseResource(obj runtime.Object, kind string) error {
     if err == nil {
         if spec, ok := rawObj["spec"].(map[string]interface{}); ok {
             node.RawSpec = spec
+            node.Containers = podContainers(spec)
         }
     }
 
@@ -111,6 +129,51 @@ func (p *Parser) parseResource(obj runtime.Object, kind string) error {
     return nil
 }
 
+// podContainers extrai os containers do template de pods de um spec
+// (spec.template para Deployment/StatefulSet/DaemonSet/Job, spec.jobTemplate para CronJob)
+func podContainers(spec map[string]interface{}) []Container {
+    if jobTemplate, ok := spec["jobTemplate"].(map[string]interface{}); ok {
+        if jobSpec, ok := jobTemplate["spec"].(map[string]interface{}); ok {
+            spec = jobSpec
+        }
+    }
+    template, ok := spec["template"].(map[string]interface{})
+    if !ok {
+        return nil
+    }
+    podSpec, ok := template["spec"].(map[string]interface{})
+    if !ok {
+        return nil
+    }
+    list, _ := podSpec["containers"].([]interface{})
+    var containers []Container
+    for _, item := range list {
+        raw, ok := item.(map[string]interface{})
+        if !ok {
+            continue
+        }
+        c := Container{}
+        c.Name, _ = raw["name"].(string)
+        c.Image, _ = raw["image"].(string)
+        c.Command = stringList(raw["command"])
+        c.Args = stringList(raw["args"])
+        containers = append(containers, c)
+    }
+    return containers
+}
+
+// stringList converte uma lista do objeto não estruturado em []string
+func stringList(value interface{}) []string {
+    list, _ := value.([]interface{})
+    var result []string
+    for _, item := range list {
+        if s, ok := item.(string); ok {
+            result = append(result, s)
+        }
+    }
+    return result
+}
+
 func (p *Parser) parseRelations(node *ResourceNode, obj runtime.Object) {
     switch obj := obj.(type) {
     case *appsv1.Deployment:
@@ -12,6 +12,17 @@ type Resource struct {
     Namespace string            `json:"namespace,omitempty" yaml:"namespace,omitempty"`
     Labels    map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
     Relations []Relation        `json:"relations,omitempty" yaml:"relations,omitempty"`
+    Containers []Container      `json:"containers,omitempty" yaml:"containers,omitempty"`
+    File      string            `json:"file,omitempty" yaml:"file,omitempty"`
+    Line      int               `json:"line,omitempty" yaml:"line,omitempty"`
+}
+
+// Container representa um container do template de pods de uma carga (Deployment, StatefulSet, Job...)
+type Container struct {
+    Name    string   `json:"name" yaml:"name"`
+    Image   string   `json:"image,omitempty" yaml:"image,omitempty"`
+    Command []string `json:"command,omitempty" yaml:"command,omitempty"`
+    Args    []string `json:"args,omitempty" yaml:"args,omitempty"`
 }
 
 // Relation representa uma relação entre recursos Kubernetes
@@ -42,6 +53,9 @@ type ResourceNode struct {
     Labels    map[string]string
     Relations []Relation
     RawSpec   map[string]interface{}
+    Containers []Container
+    File      string
+    Line      int // linha onde o documento YAML começa
 }
 
 // GetResources returns all parsed resources
@@ -5,14 +5,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/edgardnogueira/aimap/internal/config"
 	"github.com/edgardnogueira/aimap/internal/godoc"
+	"github.com/edgardnogueira/aimap/internal/godoc/messaging"
 	"github.com/edgardnogueira/aimap/internal/kubedoc"
 	"github.com/edgardnogueira/aimap/internal/output/html"
 	"github.com/edgardnogueira/aimap/internal/output/markdown"
+	"github.com/edgardnogueira/aimap/internal/swagger"
+	"github.com/edgardnogueira/aimap/pkg/utils"
 )
 
 // Generator é responsável por gerar a documentação final
@@ -23,6 +27,7 @@ type Generator struct {
     godocGen      *godoc.Generator
     k8sData       *kubedoc.Resources
     goConfig      config.GolangConfig
+    sourceURL     string // template dos links para o código-fonte (output.source_url_template)
 }
 // NewGenerator cria um novo gerador de documentação
 func NewGenerator(format, outputPath string) *Generator {
@@ -40,6 +45,12 @@ func (g *Generator) AddGoDocumentation(doc *godoc.ProjectDoc, cfg config.GolangC
     return nil
 }
 
+// SetSourceURLTemplate define o template dos links para o código-fonte. Vazio
+// gera links relativos ao diretório de saída
+func (g *Generator) SetSourceURLTemplate(template string) {
+    g.sourceURL = template
+}
+
 // AddKubernetesDocumentation adiciona documentação Kubernetes ao gerador
 func (g *Generator) AddKubernetesDocumentation(doc *kubedoc.Resources) error {
     g.k8sData = doc
@@ -52,6 +63,7 @@ func (g *Generator) Generate() error {
     if err := os.MkdirAll(g.outputPath, 0755); err != nil {
         return fmt.Errorf("erro ao criar diretório de saída: %w", err)
     }
+    g.mapMessagingWorkloads()
 
     var content string
     var err error
@@ -74,11 +86,232 @@ func (g *Generator) Generate() error {
     }
 
     outputFile := filepath.Join(g.outputPath, fmt.Sprintf("documentation.%s", g.getFileExtension()))
-    return os.WriteFile(outputFile, []byte(content), 0644)
+    if err := os.WriteFile(outputFile, []byte(content), 0644); err != nil {
+        return err
+    }
+
+    if err := g.writeCallGraphs(); err != nil {
+        return err
+    }
+    if err := g.writeClassDiagrams(); err != nil {
+        return err
+    }
+    if err := g.writeCLIPages(); err != nil {
+        return err
+    }
+    if err := g.writeSequenceDiagrams(); err != nil {
+        return err
+    }
+    if err := g.writeProfileGraph(); err != nil {
+        return err
+    }
+    return g.writeRouteHTTPFiles()
+}
+
+// writeCallGraphs grava os grafos de chamadas em arquivos Mermaid e DOT
+func (g *Generator) writeCallGraphs() error {
+    if g.godocGen == nil {
+        return nil
+    }
+    diagrams := g.godocGen.GenerateCallGraphDiagrams()
+    if len(diagrams) == 0 {
+        return nil
+    }
+
+    dir := filepath.Join(g.outputPath, "callgraph")
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return fmt.Errorf("erro ao criar diretório de grafos de chamadas: %w", err)
+    }
+
+    for _, d := range diagrams {
+        base := filepath.Join(dir, d.Kind+"_"+sanitizeFileName(d.Name))
+        if err := os.WriteFile(base+".mmd", []byte(d.Mermaid), 0644); err != nil {
+            return fmt.Errorf("erro ao escrever grafo Mermaid: %w", err)
+        }
+        if err := os.WriteFile(base+".dot", []byte(d.DOT), 0644); err != nil {
+            return fmt.Errorf("erro ao escrever grafo DOT: %w", err)
+        }
+    }
+    return nil
+}
+
+// writeClassDiagrams grava os diagramas de classes em arquivos Mermaid e PlantUML
+func (g *Generator) writeClassDiagrams() error {
+    if g.godocGen == nil {
+        return nil
+    }
+    diagrams := g.godocGen.GenerateClassDiagrams()
+    if len(diagrams) == 0 {
+        return nil
+    }
+
+    dir := filepath.Join(g.outputPath, "classes")
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return fmt.Errorf("erro ao criar diretório de diagramas de classes: %w", err)
+    }
+
+    for _, d := range diagrams {
+        base := filepath.Join(dir, d.Kind+"_"+sanitizeFileName(d.Name))
+        if err := os.WriteFile(base+".mmd", []byte(d.Mermaid), 0644); err != nil {
+            return fmt.Errorf("erro ao escrever diagrama de classes Mermaid: %w", err)
+        }
+        if err := os.WriteFile(base+".puml", []byte(d.PlantUML), 0644); err != nil {
+            return fmt.Errorf("erro ao escrever diagrama de classes PlantUML: %w", err)
+        }
+    }
+    return nil
+}
+
+// writeSequenceDiagrams grava os diagramas de sequência em arquivos Mermaid e PlantUML
+func (g *Generator) writeSequenceDiagrams() error {
+    if g.godocGen == nil {
+        return nil
+    }
+    diagrams := g.godocGen.GenerateSequenceDiagrams()
+    if len(diagrams) == 0 {
+        return nil
+    }
+
+    dir := filepath.Join(g.outputPath, "sequence")
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return fmt.Errorf("erro ao criar diretório de diagramas de sequência: %w", err)
+    }
+
+    for _, d := range diagrams {
+        base := filepath.Join(dir, d.Kind+"_"+sanitizeFileName(d.Name))
+        if err := os.WriteFile(base+".mmd", []byte(d.Mermaid), 0644); err != nil {
+            return fmt.Errorf("erro ao escrever diagrama de sequência Mermaid: %w", err)
+        }
+        if err := os.WriteFile(base+".puml", []byte(d.PlantUML), 0644); err != nil {
+            return fmt.Errorf("erro ao escrever diagrama de sequência PlantUML: %w", err)
+        }
+    }
+    return nil
+}
+
+// writeProfileGraph grava o grafo de calor do perfil pprof em Mermaid e DOT
+func (g *Generator) writeProfileGraph() error {
+    if g.godocData == nil || g.godocData.Profile == nil {
+        return nil
+    }
+
+    dir := filepath.Join(g.outputPath, "pprof")
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return fmt.Errorf("erro ao criar diretório do perfil pprof: %w", err)
+    }
+
+    base := filepath.Join(dir, "callgraph")
+    if err := os.WriteFile(base+".mmd", []byte(g.godocData.Profile.Mermaid()), 0644); err != nil {
+        return fmt.Errorf("erro ao escrever grafo de calor Mermaid: %w", err)
+    }
+    if err := os.WriteFile(base+".dot", []byte(g.godocData.Profile.DOT()), 0644); err != nil {
+        return fmt.Errorf("erro ao escrever grafo de calor DOT: %w", err)
+    }
+    return nil
+}
+
+// mapMessagingWorkloads liga os binários da topologia de mensageria às cargas do
+// Kubernetes pelo nome do recurso, dos containers, das imagens e dos comandos
+func (g *Generator) mapMessagingWorkloads() {
+    if g.godocData == nil || g.godocData.Messaging == nil || g.k8sData == nil {
+        return
+    }
+    var workloads []messaging.Workload
+    for _, r := range g.k8sData.Resources {
+        if len(r.Containers) == 0 {
+            continue
+        }
+        w := messaging.Workload{Kind: r.Kind, Name: r.Name, Aliases: []string{r.Name}}
+        for _, c := range r.Containers {
+            w.Aliases = append(w.Aliases, c.Name)
+            if c.Image != "" {
+                // registry/org/nome:tag ou nome@sha256:...
+                image := c.Image[strings.LastIndex(c.Image, "/")+1:]
+                image = strings.SplitN(strings.SplitN(image, "@", 2)[0], ":", 2)[0]
+                w.Aliases = append(w.Aliases, image)
+            }
+            if len(c.Command) > 0 {
+                w.Aliases = append(w.Aliases, filepath.Base(c.Command[0]))
+            }
+        }
+        workloads = append(workloads, w)
+    }
+    g.godocData.Messaging.MapWorkloads(workloads)
+}
+
+// writeCLIPages grava uma página de referência Markdown por binário com CLI detectada
+func (g *Generator) writeCLIPages() error {
+    if g.godocData == nil || len(g.godocData.CLI) == 0 {
+        return nil
+    }
+
+    dir := filepath.Join(g.outputPath, "cli")
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return fmt.Errorf("erro ao criar diretório de referência da CLI: %w", err)
+    }
+
+    for _, app := range g.godocData.CLI {
+        path := filepath.Join(dir, sanitizeFileName(app.Name)+".md")
+        if err := os.WriteFile(path, []byte(app.Markdown()), 0644); err != nil {
+            return fmt.Errorf("erro ao escrever referência da CLI: %w", err)
+        }
+    }
+    return nil
+}
+
+// writeRouteHTTPFiles grava uma coleção .http por pacote com as rotas extraídas do código
+func (g *Generator) writeRouteHTTPFiles() error {
+    if g.godocData == nil || !g.goConfig.Routes.HTTPFiles || len(g.godocData.Routes) == 0 {
+        return nil
+    }
+
+    dir := filepath.Join(g.outputPath, "http")
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return fmt.Errorf("erro ao criar diretório de arquivos .http: %w", err)
+    }
+
+    byPackage := make(map[string][]swagger.HTTPEndpoint)
+    var order []string
+    for _, route := range g.godocData.Routes {
+        method := route.Method
+        if method == "ANY" {
+            method = "GET"
+        }
+        op := swagger.Operation{Summary: route.Handler}
+        if route.Doc != "" {
+            op.Description = strings.SplitN(route.Doc, "\n", 2)[0]
+        }
+        if route.HandlerID != "" {
+            op.OperationID = strings.ReplaceAll(route.Handler, ".", "_")
+        }
+        if _, ok := byPackage[route.Package]; !ok {
+            order = append(order, route.Package)
+        }
+        byPackage[route.Package] = append(byPackage[route.Package], swagger.HTTPEndpoint{
+            Method:    method,
+            Path:      route.Path,
+            Operation: op,
+        })
+    }
+
+    for _, pkg := range order {
+        filename := filepath.Join(dir, sanitizeFileName(pkg)+".http")
+        if err := swagger.WriteHTTPFile(filename, pkg, byPackage[pkg]); err != nil {
+            return fmt.Errorf("erro ao gerar arquivo %s: %w", filename, err)
+        }
+    }
+    return nil
+}
+
+// sanitizeFileName converte um import path ou nome de função em nome de arquivo
+func sanitizeFileName(name string) string {
+    replacer := strings.NewReplacer("/", "_", "(", "", ")", "", "*", "", " ", "_")
+    return replacer.Replace(name)
 }
 // generateHTML gera documentação em formato HTML
 func (g *Generator) generateHTML() (string, error) {
     tmpl := html.NewTemplate()
+    tmpl.SetSourceLinker(utils.NewSourceLinker(g.sourceURL, g.outputPath).URL)
     
     // Cria o gerador godoc
     godocGen := godoc.NewGenerator(g.godocData, "", g.goConfig)
@@ -98,6 +331,7 @@ func (g *Generator) generateHTML() (string, error) {
 }
 func (g *Generator) generateMarkdown() (string, error) {
     tmpl := markdown.NewTemplate()
+    tmpl.SetSourceLinker(utils.NewSourceLinker(g.sourceURL, g.outputPath).URL)
     
     // Gera o diagrama Mermaid
     mermaidDiagram := ""
@@ -105,11 +339,27 @@ func (g *Generator) generateMarkdown() (string, error) {
         mermaidDiagram = g.godocGen.GenerateMermaidDiagram()
     }
     
+    // Gera os diagramas de classes e de sequência e os grafos de chamadas e de dependências
+    var classDiagrams []godoc.ClassDiagram
+    var callGraphs []godoc.CallGraphDiagram
+    var sequences []godoc.SequenceDiagram
+    dependencyDiagram := ""
+    if g.godocGen != nil {
+        classDiagrams = g.godocGen.GenerateClassDiagrams()
+        callGraphs = g.godocGen.GenerateCallGraphDiagrams()
+        sequences = g.godocGen.GenerateSequenceDiagrams()
+        dependencyDiagram = g.godocGen.GenerateDependencyDiagram()
+    }
+
     data := markdown.TemplateData{
-        Go:        g.godocData,
-        K8s:       g.k8sData,
-        Config:    g.goConfig,
-        GoMermaid: mermaidDiagram,
+        Go:           g.godocData,
+        K8s:          g.k8sData,
+        Config:       g.goConfig,
+        GoMermaid:    mermaidDiagram,
+        GoClasses:    classDiagrams,
+        GoCallGraphs: callGraphs,
+        GoSequences:  sequences,
+        GoDependencies: dependencyDiagram,
     }
 
     return tmpl.Generate(data)
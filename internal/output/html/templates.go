@@ -57,12 +57,25 @@ func NewTemplate() *Template {
         "shouldShowExamples": func(data TemplateData) bool {
             return data.Config.ReportOptions.ShowExamples
         },
+        // source é substituída por SetSourceLinker; sem ela nenhum link é gerado
+        "source": func(file string, line int) string {
+            return ""
+        },
     })
 
     template.Must(t.Parse(baseTemplate))
+    template.Must(t.New("examples").Parse(examplesTemplate))
+    template.Must(t.New("location").Parse(locationTemplate))
+    template.Must(t.New("annotations").Parse(annotationsTemplate))
+    template.Must(t.New("project").Parse(projectTemplate))
     return &Template{tmpl: t}
 }
 
+// SetSourceLinker define a função que gera o link para o código-fonte de cada item
+func (t *Template) SetSourceLinker(link func(file string, line int) string) {
+    t.tmpl.Funcs(template.FuncMap{"source": link})
+}
+
 // Generate gera a documentação HTML
 func (t *Template) Generate(data interface{}) (string, error) {
     var buf bytes.Buffer
@@ -72,6 +85,191 @@ func (t *Template) Generate(data interface{}) (string, error) {
     return buf.String(), nil
 }
 
+// examplesTemplate renderiza uma lista de exemplos com código e output
+const examplesTemplate = `{{range .}}
+<details>
+    <summary>{{.Name}}</summary>
+    {{if .Doc}}<div class="doc-comment">{{.Doc}}</div>{{end}}
+    <pre><code>{{.Code}}</code></pre>
+    {{if .Output}}<p>Output{{if .Unordered}} (sem ordem){{end}}:</p>
+    <pre><code>{{.Output}}</code></pre>{{end}}
+</details>
+{{end}}`
+
+// locationTemplate renderiza arquivo:linha de um item, com link quando houver
+const locationTemplate = `{{$url := source .File .Line}}{{if $url}}<a href="{{$url}}">{{.File}}:{{.Line}}</a>{{else}}{{.File}}:{{.Line}}{{end}}`
+
+// annotationsTemplate renderiza a cobertura de testes e o perfil de uma função
+const annotationsTemplate = `{{with .Coverage}}<span class="tag">cobertura: {{if eq .Covered 0}}⚠️ sem testes{{else}}{{printf "%.0f" .Percent}}%{{end}} ({{.Covered}}/{{.Statements}})</span>{{end}}
+{{with .Profile}}<span class="tag">perfil: flat {{printf "%.1f" .FlatPercent}}%, cumulativo {{printf "%.1f" .CumPercent}}%</span>{{end}}`
+
+// projectTemplate renderiza as seções que cobrem o projeto inteiro
+const projectTemplate = `
+{{with .DocCoverage}}
+<details>
+    <summary>Cobertura de Documentação: {{printf "%.1f" .Percent}}%</summary>
+    <p>{{.Documented}}/{{.Total}} símbolos exportados documentados</p>
+    <table>
+        <tr><th>Pacote</th><th>Documentados</th><th>Total</th><th>Cobertura</th></tr>
+        {{range .Packages}}
+        <tr><td><code>{{.Name}}</code></td><td>{{.Documented}}</td><td>{{.Total}}</td><td>{{printf "%.1f" .Percent}}%</td></tr>
+        {{end}}
+    </table>
+    <details>
+        <summary>Cobertura por arquivo</summary>
+        <table>
+            <tr><th>Arquivo</th><th>Documentados</th><th>Total</th><th>Cobertura</th></tr>
+            {{range .Files}}
+            <tr><td>{{.Name}}</td><td>{{.Documented}}</td><td>{{.Total}}</td><td>{{printf "%.1f" .Percent}}%</td></tr>
+            {{end}}
+        </table>
+    </details>
+    {{if .Undocumented}}
+    <details>
+        <summary>Símbolos sem documentação ({{len .Undocumented}})</summary>
+        <div class="indent">
+            {{range .Undocumented}}
+            <div>{{.Kind}} <code>{{.Name}}</code> — {{template "location" .}}</div>
+            {{end}}
+        </div>
+    </details>
+    {{end}}
+</details>
+{{end}}
+
+{{with .TestCoverage}}
+<details>
+    <summary>Cobertura de Testes: {{printf "%.1f" .Percent}}%</summary>
+    <p>{{.Covered}}/{{.Statements}} instruções cobertas · profile <code>{{.Profile}}</code> (modo {{.Mode}})</p>
+    <table>
+        <tr><th>Pacote</th><th>Cobertas</th><th>Instruções</th><th>Cobertura</th></tr>
+        {{range .Packages}}
+        <tr><td><code>{{.Package}}</code></td><td>{{.Covered}}</td><td>{{.Statements}}</td><td>{{if eq .Covered 0}}⚠️ {{end}}{{printf "%.1f" .Percent}}%</td></tr>
+        {{end}}
+    </table>
+    {{if .Untested}}
+    <details>
+        <summary>⚠️ Funções sem testes ({{len .Untested}})</summary>
+        <div class="indent">
+            {{range .Untested}}
+            <div><code>{{.Package}}</code> <code>{{.Name}}</code> — {{template "location" .}}</div>
+            {{end}}
+        </div>
+    </details>
+    {{end}}
+</details>
+{{end}}
+
+{{with .Profile}}
+<details>
+    <summary>Hot Paths</summary>
+    <p>Perfil <code>{{.File}}</code> · amostra <code>{{.SampleType}}</code> · total {{.Total}}</p>
+    <table>
+        <tr><th>Função</th><th>Flat</th><th>Cumulativo</th></tr>
+        {{range .Functions}}
+        <tr><td><code>{{.Name}}</code></td><td>{{printf "%.1f" .FlatPercent}}%</td><td>{{printf "%.1f" .CumPercent}}%</td></tr>
+        {{end}}
+    </table>
+    {{range .HotPaths}}
+    <div>{{printf "%.1f" .Percent}}% — {{range $i, $f := .Frames}}{{if $i}} → {{end}}<code>{{$f}}</code>{{end}}</div>
+    {{end}}
+    <div class="mermaid">
+        {{.Mermaid}}
+    </div>
+</details>
+{{end}}
+
+{{if .Configuration}}
+<details>
+    <summary>Referência de Configuração</summary>
+    <table>
+        <tr><th>Nome</th><th>Origem</th><th>Tipo</th><th>Padrão</th><th>Descrição</th><th>Lido em</th></tr>
+        {{range .Configuration}}
+        <tr><td><code>{{if eq .Source "flag"}}-{{end}}{{.Name}}</code>{{if .Required}} (obrigatória){{end}}</td><td>{{.Source}}{{if .Set}} ({{.Set}}){{end}}</td><td>{{if .Type}}<code>{{.Type}}</code>{{end}}</td><td>{{if .Default}}<code>{{.Default}}</code>{{end}}</td><td>{{.Description}}</td><td>{{range .Reads}}{{$url := source .File .Line}}{{if $url}}<a href="{{$url}}">{{if .Function}}{{.Function}}{{else}}{{.Package}}{{end}}</a>{{else}}{{if .Function}}{{.Function}}{{else}}{{.Package}}{{end}}{{end}} {{end}}</td></tr>
+        {{end}}
+    </table>
+</details>
+{{end}}
+
+{{with .SQL}}
+<details>
+    <summary>Consultas SQL</summary>
+    <table>
+        <tr><th>Tabela</th><th>Operações</th><th>Código</th><th>Instruções</th></tr>
+        {{range .Tables}}
+        <tr><td><code>{{.Table}}</code></td><td>{{join .Operations ", "}}</td><td>{{range .Functions}}<code>{{.}}</code> {{end}}</td><td>{{.Queries}}</td></tr>
+        {{end}}
+    </table>
+    <table>
+        <tr><th>Local</th><th>Código</th><th>Operação</th><th>Tabelas</th><th>Colunas</th></tr>
+        {{range .Queries}}
+        <tr><td>{{template "location" .}}</td><td>{{if .Function}}<code>{{.Function}}</code>{{end}}</td><td>{{.Operation}}</td><td>{{range .Tables}}<code>{{.}}</code> {{end}}</td><td>{{range .Columns}}<code>{{if .Table}}{{.Table}}.{{end}}{{.Name}}</code> {{end}}</td></tr>
+        {{end}}
+    </table>
+</details>
+{{end}}
+
+{{with $boot := .Boot}}
+<details>
+    <summary>Inicialização dos Binários</summary>
+    {{range $bin := .Binaries}}
+    <h4>Como <code>{{$bin.Name}}</code> inicializa</h4>
+    <p>Pacotes do projeto: {{range $i, $p := $bin.Order}}{{if $i}} → {{end}}<code>{{$p}}</code>{{end}}</p>
+    <ol>
+        {{range $boot.BinaryPackages $bin}}
+        <li><code>{{.Package}}</code>
+            <ul>
+                {{range .Vars}}<li>var <code>{{join .Names ", "}} = {{.Init}}</code> — {{template "location" .}}{{if .Effects}}<ul>{{range .Effects}}<li>{{.}}</li>{{end}}</ul>{{end}}</li>{{end}}
+                {{range .Inits}}<li><code>init()</code> — {{template "location" .}}{{if .Effects}}<ul>{{range .Effects}}<li>{{.}}</li>{{end}}</ul>{{end}}</li>{{end}}
+            </ul>
+        </li>
+        {{end}}
+        <li><code>main()</code> — {{template "location" $bin}}{{if $bin.Main}}<ul>{{range $bin.Main}}<li>{{.}}</li>{{end}}</ul>{{end}}</li>
+    </ol>
+    {{end}}
+</details>
+{{end}}
+
+{{if .DeadCode}}
+<details>
+    <summary>Código Não Utilizado ({{len .DeadCode}})</summary>
+    <p>Para manter uma declaração, use <code>//aimap:ignore dead-code</code> no comentário ou na mesma linha.</p>
+    <table>
+        <tr><th>Pacote</th><th>Símbolo</th><th>Tipo</th><th>Local</th></tr>
+        {{range .DeadCode}}
+        <tr><td><code>{{.Package}}</code></td><td><code>{{.Name}}</code>{{if .Exported}} (exportado){{end}}</td><td>{{.Kind}}</td><td>{{template "location" .}}</td></tr>
+        {{end}}
+    </table>
+</details>
+{{end}}
+
+{{with $bus := .Messaging}}
+<details>
+    <summary>Topologia de Mensageria</summary>
+    <table>
+        <tr><th>Broker</th><th>Tópico</th><th>Produtores</th><th>Consumidores</th></tr>
+        {{range $bus.Topics}}
+        <tr><td>{{.Broker}}</td><td>{{if .Dynamic}}<em><code>{{.Name}}</code></em>{{else}}<code>{{.Name}}</code>{{end}}</td><td>{{range .Producers}}<code>{{.}}</code>{{range index $bus.Workloads .}} ({{.}}){{end}} {{end}}</td><td>{{range .Consumers}}<code>{{.}}</code>{{range index $bus.Workloads .}} ({{.}}){{end}} {{end}}</td></tr>
+        {{end}}
+    </table>
+    {{if $bus.Bindings}}
+    <p>Bindings: {{range $bus.Bindings}}<code>{{.Exchange}}</code> → <code>{{.Queue}}</code>{{if .Key}} (<code>{{.Key}}</code>){{end}}; {{end}}</p>
+    {{end}}
+    <div class="mermaid">
+        {{$bus.Mermaid}}
+    </div>
+    <details>
+        <summary>Chamadas de publicação e assinatura ({{len $bus.Endpoints}})</summary>
+        <table>
+            <tr><th>Papel</th><th>Tópico</th><th>Cliente</th><th>Grupo</th><th>Função</th><th>Local</th></tr>
+            {{range $bus.Endpoints}}
+            <tr><td>{{if eq .Role "producer"}}publica{{else}}consome{{end}}</td><td><code>{{.Topic}}</code></td><td>{{.Client}}</td><td>{{.Group}}</td><td><code>{{.Function}}</code></td><td>{{template "location" .}}</td></tr>
+            {{end}}
+        </table>
+    </details>
+</details>
+{{end}}`
+
 // baseTemplate é o template HTML base
 const baseTemplate = `<!DOCTYPE html>
 <html>
@@ -122,12 +320,35 @@ const baseTemplate = `<!DOCTYPE html>
             color: #718096;
             font-size: 0.875em;
         }
+
+        .source {
+            color: #718096;
+            font-size: 0.75em;
+            margin-left: 0.5rem;
+        }
         
         .doc-comment {
             color: #718096;
             font-style: italic;
             margin: 0.5rem 0;
         }
+
+        table {
+            border-collapse: collapse;
+            margin: 0.5rem 0;
+            font-size: 0.875em;
+        }
+
+        th, td {
+            border: 1px solid #e2e8f0;
+            padding: 0.25rem 0.5rem;
+            text-align: left;
+            vertical-align: top;
+        }
+
+        th {
+            background: #f7fafc;
+        }
     </style>
 </head>
 <body>
@@ -138,11 +359,11 @@ const baseTemplate = `<!DOCTYPE html>
         <h2>Documentação Go</h2>
         {{range .Go.Directories}}
         <details>
-            <summary>{{.Path}}</summary>
+            <summary>{{if .ImportPath}}<code>{{.ImportPath}}</code> ({{.Path}}){{else}}{{.Path}}{{end}}</summary>
             {{range .Files}}
             <div class="indent">
                 <details>
-                    <summary>{{.FileName}}</summary>
+                    <summary>{{.FileName}} {{with source .FileName 0}}<a class="source" href="{{.}}">fonte</a>{{end}}</summary>
                     <div class="indent">
                         <p>Package: <code>{{.Package}}</code></p>
 
@@ -163,11 +384,11 @@ const baseTemplate = `<!DOCTYPE html>
                             <div class="indent">
                                 {{range .Interfaces}}
                                 <details>
-                                    <summary>{{.Name}}</summary>
+                                    <summary>{{.Name}} {{with source .File .Line}}<a class="source" href="{{.}}">fonte</a>{{end}}</summary>
                                     {{if .Doc}}<div class="doc-comment">{{.Doc}}</div>{{end}}
                                     {{range .Methods}}
                                     <div class="indent">
-                                        <code>{{.Name}}{{.Sig}}</code>
+                                        <code>{{.Name}}{{.Sig}}</code> {{with source .File .Line}}<a class="source" href="{{.}}">fonte</a>{{end}}
                                         {{if .Doc}}<div class="doc-comment">{{.Doc}}</div>{{end}}
                                     </div>
                                     {{end}}
@@ -183,7 +404,7 @@ const baseTemplate = `<!DOCTYPE html>
                             <div class="indent">
                                 {{range .Structs}}
                                 <details>
-                                    <summary>{{.Name}}</summary>
+                                    <summary>{{.Name}} {{with source .File .Line}}<a class="source" href="{{.}}">fonte</a>{{end}}</summary>
                                     {{if .Doc}}<div class="doc-comment">{{.Doc}}</div>{{end}}
                                     
                                     {{if .Fields}}
@@ -207,7 +428,8 @@ const baseTemplate = `<!DOCTYPE html>
                                         <div class="indent">
                                             {{range .Methods}}
                                             <div>
-                                                <code>{{.Name}}{{.Sig}}</code>
+                                                <code>{{.Name}}{{.Sig}}</code> {{with source .File .Line}}<a class="source" href="{{.}}">fonte</a>{{end}}
+                                                {{template "annotations" .}}
                                                 {{if .Doc}}<div class="doc-comment">{{.Doc}}</div>{{end}}
                                             </div>
                                             {{end}}
@@ -226,8 +448,10 @@ const baseTemplate = `<!DOCTYPE html>
                             <div class="indent">
                                 {{range .Functions}}
                                 <div>
-                                    <code>{{.Name}}{{.Sig}}</code>
+                                    <code>{{.Name}}{{.Sig}}</code> {{with source .File .Line}}<a class="source" href="{{.}}">fonte</a>{{end}}
+                                    {{template "annotations" .}}
                                     {{if .Doc}}<div class="doc-comment">{{.Doc}}</div>{{end}}
+                                    {{if shouldShowExamples $}}{{template "examples" .Examples}}{{end}}
                                 </div>
                                 {{end}}
                             </div>
@@ -237,8 +461,149 @@ const baseTemplate = `<!DOCTYPE html>
                 </details>
             </div>
             {{end}}
+
+            {{if and .Examples (shouldShowExamples $)}}
+            <div class="indent">
+                <details>
+                    <summary>Exemplos do pacote</summary>
+                    {{template "examples" .Examples}}
+                </details>
+            </div>
+            {{end}}
+
+            {{if and .Tests (shouldShowTests $)}}
+            <div class="indent">
+                <details>
+                    <summary>Testes</summary>
+                    <div class="indent">
+                        {{range .Tests}}
+                        <div>
+                            <code>{{.Name}}</code> <span class="tag">{{.Kind}} · {{.File}}:{{.Line}}</span> {{with source .File .Line}}<a class="source" href="{{.}}">fonte</a>{{end}}
+                            {{if .References}}<div class="doc-comment">{{join .References ", "}}</div>{{end}}
+                        </div>
+                        {{end}}
+                    </div>
+                </details>
+            </div>
+            {{end}}
+
+            {{with .Concurrency}}
+            <div class="indent">
+                <details>
+                    <summary>Concorrência</summary>
+                    {{if .Goroutines}}
+                    <h4>Goroutines</h4>
+                    <table>
+                        <tr><th>Iniciada em</th><th>Executa</th><th>Via</th><th>Local</th></tr>
+                        {{range .Goroutines}}
+                        <tr><td><code>{{.Function}}</code></td><td><code>{{.Target}}</code></td><td>{{.Via}}</td><td>{{template "location" .}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{end}}
+                    {{if .Channels}}
+                    <h4>Canais</h4>
+                    <table>
+                        <tr><th>Canal</th><th>Tipo</th><th>Declaração</th><th>Envia</th><th>Recebe</th></tr>
+                        {{range .Channels}}
+                        <tr><td><code>{{.Name}}</code></td><td><code>{{.Type}}</code></td><td>{{.Kind}}</td><td>{{range .Senders}}<code>{{.}}</code> {{end}}</td><td>{{range .Receivers}}<code>{{.}}</code> {{end}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{end}}
+                    {{if .Locks}}
+                    <h4>Travas e atômicos</h4>
+                    <table>
+                        <tr><th>Campo</th><th>Tipo</th><th>Usado por</th></tr>
+                        {{range .Locks}}
+                        <tr><td><code>{{.Name}}</code></td><td><code>{{.Type}}</code></td><td>{{range .Methods}}<code>{{.}}</code> {{end}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{end}}
+                    {{if .SyncGroups}}
+                    <h4>WaitGroup / errgroup</h4>
+                    <table>
+                        <tr><th>Função</th><th>Tipo</th><th>Chamadas</th></tr>
+                        {{range .SyncGroups}}
+                        <tr><td><code>{{.Function}}</code></td><td>{{.Kind}}</td><td>{{range .Calls}}<code>{{.}}</code> {{end}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{end}}
+                </details>
+            </div>
+            {{end}}
+
+            {{with .Errors}}
+            <div class="indent">
+                <details>
+                    <summary>Catálogo de Erros</summary>
+                    {{if .Sentinels}}
+                    <h4>Erros sentinela</h4>
+                    <table>
+                        <tr><th>Erro</th><th>Mensagem</th><th>Local</th></tr>
+                        {{range .Sentinels}}
+                        <tr><td><code>{{.Name}}</code></td><td>{{if .Message}}"{{.Message}}"{{end}}</td><td>{{template "location" .}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{end}}
+                    {{if .Types}}
+                    <h4>Tipos de erro</h4>
+                    <table>
+                        <tr><th>Tipo</th><th>Mensagem</th><th>Local</th></tr>
+                        {{range .Types}}
+                        <tr><td><code>{{.Receiver}}</code></td><td>{{if .Message}}"{{.Message}}"{{end}}</td><td>{{template "location" .}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{end}}
+                    {{if .Returns}}
+                    <h4>Erros retornados por função</h4>
+                    <table>
+                        <tr><th>Função</th><th>Tipo</th><th>Erro</th><th>Mensagem</th></tr>
+                        {{range .Returns}}
+                        <tr><td><code>{{.Function}}</code></td><td>{{.Kind}}</td><td>{{if .Error}}<code>{{.Error}}</code>{{end}}</td><td>{{if .Message}}"{{.Message}}"{{end}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{end}}
+                    {{if .Wraps}}
+                    <h4>Wraps (<code>fmt.Errorf</code> com <code>%w</code>)</h4>
+                    <table>
+                        <tr><th>Função</th><th>Formato</th><th>Envolve</th><th>Local</th></tr>
+                        {{range .Wraps}}
+                        <tr><td><code>{{.Function}}</code></td><td>"{{.Format}}"</td><td>{{range .Wrapped}}<code>{{.}}</code> {{end}}</td><td>{{template "location" .}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{end}}
+                    {{if .Checks}}
+                    <h4>Verificações</h4>
+                    <table>
+                        <tr><th>Função</th><th>Verificação</th><th>Alvo</th><th>Local</th></tr>
+                        {{range .Checks}}
+                        <tr><td><code>{{.Function}}</code></td><td>{{.Kind}}</td><td><code>{{.Target}}</code></td><td>{{template "location" .}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{end}}
+                </details>
+            </div>
+            {{end}}
+
+            {{with .Logging}}
+            <div class="indent">
+                <details>
+                    <summary>Catálogo de Logs</summary>
+                    <table>
+                        <tr><th>Nível</th><th>Mensagem</th><th>Chaves</th><th>Função</th><th>Local</th></tr>
+                        {{range .Calls}}
+                        <tr><td>{{if .Level}}{{.Level}}{{else}}{{.Method}}{{end}}</td><td>{{if .Message}}"{{.Message}}"{{else if .Dynamic}}<em>(dinâmica)</em>{{end}}</td><td>{{range .Keys}}<code>{{.}}</code> {{end}}</td><td><code>{{.Function}}</code></td><td>{{template "location" .}}</td></tr>
+                        {{end}}
+                    </table>
+                    {{if .Keys}}
+                    <p>Chaves: {{range .Keys}}<code>{{.Name}}</code> ({{.Count}}){{if .Variants}} ⚠ também {{range .Variants}}<code>{{.}}</code> {{end}}{{end}}; {{end}}</p>
+                    {{end}}
+                </details>
+            </div>
+            {{end}}
         </details>
         {{end}}
+
+        {{template "project" .Go}}
     </section>
     {{end}}
 
@@ -264,7 +629,7 @@ const baseTemplate = `<!DOCTYPE html>
         <!-- Template para recursos Kubernetes -->
         {{range .K8s.Resources}}
         <details>
-            <summary>{{.Kind}}: {{.Name}}</summary>
+            <summary>{{.Kind}}: {{.Name}} {{with source .File .Line}}<a class="source" href="{{.}}">fonte</a>{{end}}</summary>
             <div class="indent">
                 {{if .Namespace}}
                 <p>Namespace: <code>{{.Namespace}}</code></p>
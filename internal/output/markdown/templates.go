@@ -12,10 +12,14 @@ type Template struct {
     tmpl *template.Template
 }
 type TemplateData struct {
-    Go        interface{}
-    K8s       interface{}
-    Config    interface{}
-    GoMermaid string
+    Go           interface{}
+    K8s          interface{}
+    Config       interface{}
+    GoMermaid    string
+    GoClasses    interface{}
+    GoCallGraphs interface{}
+    GoSequences  interface{}
+    GoDependencies string
 }
 
 // NewTemplate cria um novo template Markdown
@@ -28,12 +32,25 @@ func NewTemplate() *Template {
         "codeBlock": func(lang, code string) string {
             return "```" + lang + "\n" + code + "\n```"
         },
+        "percent": func(ratio float64) float64 {
+            return ratio * 100
+        },
+        "join": strings.Join,
+        // source é substituída por SetSourceLinker; sem ela nenhum link é gerado
+        "source": func(file string, line int) string {
+            return ""
+        },
     })
 
     template.Must(t.Parse(baseTemplate))
     return &Template{tmpl: t}
 }
 
+// SetSourceLinker define a função que gera o link para o código-fonte de cada item
+func (t *Template) SetSourceLinker(link func(file string, line int) string) {
+    t.tmpl.Funcs(template.FuncMap{"source": link})
+}
+
 // Generate gera a documentação Markdown
 func (t *Template) Generate(data interface{}) (string, error) {
     var buf bytes.Buffer
@@ -49,10 +66,311 @@ const baseTemplate = `# Documentação do Projeto
 {{if .Go}}
 ## Documentação Go
 
+{{if .GoClasses}}
+### Diagramas de Classes
+
+{{range .GoClasses}}
+#### {{if eq .Kind "focus"}}Tipo em foco{{else}}Pacote{{end}}: ` + "`{{.Name}}`" + `
+
+` + "```mermaid" + `
+{{.Mermaid}}
+` + "```" + `
+{{end}}
+{{else}}
 ### Diagrama de Classes
 ` + "```mermaid" + `
 {{.GoMermaid}}
 ` + "```" + `
+{{end}}
+
+{{if .Go.Modules}}
+### Módulos
+
+{{range .Go.Modules}}
+#### ` + "`{{.Path}}`" + `
+
+- **Diretório:** {{.Dir}}
+- **Versão do Go:** {{.GoVersion}}{{if .Toolchain}}
+- **Toolchain:** {{.Toolchain}}{{end}}
+
+{{with .DirectRequires}}
+**Dependências diretas:**
+
+| Módulo | Versão |
+|--------|--------|
+{{range .}}| ` + "`{{.Path}}`" + ` | {{.Version}} |
+{{end}}
+{{end}}
+{{with .IndirectRequires}}
+<details>
+<summary>Dependências indiretas ({{len .}})</summary>
+
+| Módulo | Versão |
+|--------|--------|
+{{range .}}| ` + "`{{.Path}}`" + ` | {{.Version}} |
+{{end}}
+
+</details>
+{{end}}
+{{if .Replaces}}
+**Replace:**
+
+{{range .Replaces}}- ` + "`{{.Old}}{{if .OldVersion}} {{.OldVersion}}{{end}}`" + ` => ` + "`{{.New}}{{if .NewVersion}} {{.NewVersion}}{{end}}`" + `
+{{end}}
+{{end}}
+{{if .Excludes}}
+**Exclude:**
+
+{{range .Excludes}}- ` + "`{{.Path}} {{.Version}}`" + `
+{{end}}
+{{end}}
+{{end}}
+
+{{with .Go.Workspace}}
+#### Workspace (` + "`go.work`" + `)
+
+- **Arquivo:** {{.File}}
+- **Versão do Go:** {{.GoVersion}}
+
+{{range .Uses}}- use ` + "`{{.}}`" + `
+{{end}}
+{{end}}
+{{end}}
+
+{{if .Go.Routes}}
+### Endpoints HTTP
+
+| Método | Rota | Handler | Framework | Local |
+|--------|------|---------|-----------|-------|
+{{range .Go.Routes}}| {{.Method}} | ` + "`{{.Path}}`" + ` | ` + "`{{.Handler}}`" + ` | {{.Framework}} | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}
+{{end}}
+
+{{if .Go.CLI}}
+### Referência de CLI
+
+{{range .Go.CLI}}
+#### ` + "`{{.Name}}`" + ` ({{.Framework}})
+
+Referência completa: [cli/{{.Name}}.md](cli/{{.Name}}.md)
+
+| Comando | Descrição | Flags | Handler | Local |
+|---------|-----------|-------|---------|-------|
+{{range .AllCommands}}| ` + "`{{.Path}}`" + ` | {{.Short}} | {{len .Flags}} | {{if .Handler}}` + "`{{.Handler}}`" + `{{end}} | {{if .File}}{{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}}{{end}} |
+{{end}}
+{{end}}
+{{end}}
+
+{{if .Go.Configuration}}
+### Referência de Configuração
+
+| Nome | Origem | Tipo | Padrão | Descrição | Lido em |
+|------|--------|------|--------|-----------|---------|
+{{range .Go.Configuration}}| ` + "`{{if eq .Source \"flag\"}}-{{end}}{{.Name}}`" + `{{if .Required}} (obrigatória){{end}} | {{.Source}}{{if .Set}} ({{.Set}}){{end}} | {{if .Type}}` + "`{{.Type}}`" + `{{end}} | {{if .Default}}` + "`{{.Default}}`" + `{{end}} | {{.Description}} | {{range .Reads}}{{$url := source .File .Line}}{{if $url}}[{{if .Function}}{{.Function}}{{else}}{{.Package}}{{end}}]({{$url}}){{else}}{{if .Function}}{{.Function}}{{else}}{{.Package}}{{end}}{{end}} {{end}}|
+{{end}}
+{{end}}
+
+{{with .Go.SQL}}
+### Consultas SQL
+
+| Tabela | Operações | Código | Instruções |
+|--------|-----------|--------|------------|
+{{range .Tables}}| ` + "`{{.Table}}`" + ` | {{join .Operations ", "}} | {{range .Functions}}` + "`{{.}}` " + `{{end}}| {{.Queries}} |
+{{end}}
+
+| Local | Código | Operação | Tabelas | Colunas |
+|-------|--------|----------|---------|---------|
+{{range .Queries}}| {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} | {{if .Function}}` + "`{{.Function}}`" + `{{end}} | {{.Operation}} | {{range .Tables}}` + "`{{.}}` " + `{{end}}| {{range .Columns}}` + "`{{if .Table}}{{.Table}}.{{end}}{{.Name}}`" + ` {{end}}|
+{{end}}
+{{end}}
+
+{{if .GoCallGraphs}}
+### Grafos de Chamadas
+
+{{range .GoCallGraphs}}
+#### {{if eq .Kind "entry_point"}}Ponto de entrada{{else}}Pacote{{end}}: ` + "`{{.Name}}`" + `
+
+` + "```mermaid" + `
+{{.Mermaid}}
+` + "```" + `
+{{end}}
+{{end}}
+
+{{if .GoSequences}}
+### Diagramas de Sequência
+
+{{range .GoSequences}}
+#### {{if eq .Kind "route"}}Rota{{else}}Ponto de entrada{{end}}: ` + "`{{.Name}}`" + `
+
+` + "```mermaid" + `
+{{.Mermaid}}
+` + "```" + `
+{{end}}
+{{end}}
+
+{{if .GoDependencies}}
+### Dependências entre Pacotes
+
+` + "```mermaid" + `
+{{.GoDependencies}}
+` + "```" + `
+{{if .Go.Dependencies.Cycles}}
+**Ciclos de imports:**
+
+{{range .Go.Dependencies.Cycles}}
+- {{range .}}` + "`{{.}}` " + `{{end}}
+{{end}}
+{{end}}
+{{end}}
+
+{{with .Go.BuildMatrix}}
+### Disponibilidade por Plataforma
+
+{{if .Symbols}}
+| Pacote | Símbolo | Tipo |{{range .Targets}} {{.}} |{{end}}
+|--------|---------|------|{{range .Targets}}---|{{end}}
+{{range $sym := .Symbols}}| ` + "`{{$sym.Package}}`" + ` | ` + "`{{$sym.Name}}`" + ` | {{$sym.Kind}} |{{range $.Go.BuildMatrix.Targets}} {{if $sym.Has .}}✅{{else}}—{{end}} |{{end}}
+{{end}}
+{{else}}
+Todos os símbolos existem em todos os alvos ({{range $i, $t := .Targets}}{{if $i}}, {{end}}{{$t}}{{end}}).
+{{end}}
+{{end}}
+
+{{if .Go.Metrics}}
+### Métricas de Código
+
+| Pacote | LOC | Exportados | Não exportados | % exportado | Ca | Ce | Instabilidade | Hotspots |
+|--------|-----|------------|----------------|-------------|----|----|---------------|----------|
+{{range .Go.Metrics}}| ` + "`{{.ImportPath}}`" + ` | {{.LOC}} | {{.Exported}} | {{.Unexported}} | {{printf "%.0f" (percent .ExportedRatio)}}% | {{.Afferent}} | {{.Efferent}} | {{printf "%.2f" .Instability}} | {{range .Hotspots}}⚠️ {{.}} {{end}} |
+{{end}}
+{{end}}
+
+{{if .Go.DocCoverage}}
+### Cobertura de Documentação
+
+{{.Go.DocCoverage.Badge}}
+
+**Total:** {{.Go.DocCoverage.Documented}}/{{.Go.DocCoverage.Total}} símbolos exportados documentados ({{printf "%.1f" .Go.DocCoverage.Percent}}%)
+
+| Pacote | Documentados | Total | Cobertura |
+|--------|--------------|-------|-----------|
+{{range .Go.DocCoverage.Packages}}| ` + "`{{.Name}}`" + ` | {{.Documented}} | {{.Total}} | {{printf "%.1f" .Percent}}% |
+{{end}}
+
+<details>
+<summary>Cobertura por arquivo</summary>
+
+| Arquivo | Documentados | Total | Cobertura |
+|---------|--------------|-------|-----------|
+{{range .Go.DocCoverage.Files}}| {{.Name}} | {{.Documented}} | {{.Total}} | {{printf "%.1f" .Percent}}% |
+{{end}}
+
+</details>
+
+{{if .Go.DocCoverage.Undocumented}}
+<details>
+<summary>Símbolos sem documentação ({{len .Go.DocCoverage.Undocumented}})</summary>
+
+{{range .Go.DocCoverage.Undocumented}}- {{.Kind}} ` + "`{{.Name}}`" + ` — {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}}
+{{end}}
+
+</details>
+{{end}}
+{{end}}
+
+{{with .Go.TestCoverage}}
+### Cobertura de Testes
+
+**Total:** {{.Covered}}/{{.Statements}} instruções cobertas ({{printf "%.1f" .Percent}}%) · profile ` + "`{{.Profile}}`" + ` (modo {{.Mode}})
+
+| Pacote | Cobertas | Instruções | Cobertura |
+|--------|----------|------------|-----------|
+{{range .Packages}}| ` + "`{{.Package}}`" + ` | {{.Covered}} | {{.Statements}} | {{if eq .Covered 0}}⚠️ {{end}}{{printf "%.1f" .Percent}}% |
+{{end}}
+
+{{if .Untested}}
+<details>
+<summary>⚠️ Funções sem testes ({{len .Untested}})</summary>
+
+{{range .Untested}}- ` + "`{{.Package}}`" + ` ` + "`{{.Name}}`" + ` — {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}}
+{{end}}
+
+</details>
+{{end}}
+{{end}}
+
+{{with .Go.Profile}}
+### Hot Paths
+
+Perfil ` + "`{{.File}}`" + ` · amostra ` + "`{{.SampleType}}`" + ` · total {{.Total}}
+
+| Função | Flat | Cumulativo |
+|--------|------|------------|
+{{range .Functions}}| ` + "`{{.Name}}`" + ` | {{printf "%.1f" .FlatPercent}}% | {{printf "%.1f" .CumPercent}}% |
+{{end}}
+{{if .HotPaths}}
+**Caminhos mais quentes:**
+
+{{range .HotPaths}}- {{printf "%.1f" .Percent}}% — {{range $i, $f := .Frames}}{{if $i}} → {{end}}` + "`{{$f}}`" + `{{end}}
+{{end}}
+{{end}}
+
+` + "```mermaid" + `
+{{.Mermaid}}
+` + "```" + `
+{{end}}
+
+{{with $boot := .Go.Boot}}
+### Inicialização dos Binários
+
+Ordem em que cada binário executa as variáveis de pacote com chamadas de função, as funções ` + "`init()`" + ` (pacotes importados primeiro, na ordem da especificação do Go) e, por fim, ` + "`main()`" + `.
+{{range $bin := .Binaries}}
+#### Como ` + "`{{$bin.Name}}`" + ` inicializa
+
+Pacotes do projeto: {{range $i, $p := $bin.Order}}{{if $i}} → {{end}}` + "`{{$p}}`" + `{{end}}
+
+{{range $boot.BinaryPackages $bin}}1. ` + "`{{.Package}}`" + `
+{{range .Vars}}   - var ` + "`` {{join .Names \", \"}} = {{.Init}} ``" + ` — {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}}
+{{range .Effects}}     - {{.}}
+{{end}}{{end}}{{range .Inits}}   - ` + "`init()`" + ` — {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}}
+{{range .Effects}}     - {{.}}
+{{end}}{{end}}{{end}}1. ` + "`main()`" + ` — {{$url := source $bin.File $bin.Line}}{{if $url}}[{{$bin.File}}:{{$bin.Line}}]({{$url}}){{else}}{{$bin.File}}:{{$bin.Line}}{{end}}
+{{range $bin.Main}}   - {{.}}
+{{end}}
+{{end}}
+{{if .Packages}}
+<details>
+<summary>Funções init() e variáveis de pacote por pacote</summary>
+
+| Pacote | Declaração | Efeitos | Local |
+|--------|------------|---------|-------|
+{{range .Packages}}{{$pkg := .Package}}{{range .Vars}}| ` + "`{{$pkg}}`" + ` | var ` + "`{{join .Names \", \"}}`" + ` | {{range .Effects}}{{.}}; {{end}} | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}{{range .Inits}}| ` + "`{{$pkg}}`" + ` | ` + "`init()`" + ` | {{range .Effects}}{{.}}; {{end}} | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}{{end}}
+
+</details>
+{{end}}
+{{end}}
+
+{{if .Go.DeadCode}}
+### Código Não Utilizado
+
+Declarações que nenhum código do módulo alcança: símbolos não exportados e símbolos exportados de pacotes ` + "`internal`" + ` e ` + "`main`" + `. Para manter uma declaração, use ` + "`//aimap:ignore dead-code`" + ` no comentário ou na mesma linha.
+
+| Pacote | Símbolo | Tipo | Local |
+|--------|---------|------|-------|
+{{range .Go.DeadCode}}| ` + "`{{.Package}}`" + ` | ` + "`{{.Name}}`" + `{{if .Exported}} (exportado){{end}} | {{.Kind}} | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}
+{{end}}
+
+{{if .Go.Diagnostics}}
+### Diagnósticos
+
+| Severidade | Regra | Local | Mensagem |
+|------------|-------|-------|----------|
+{{range .Go.Diagnostics}}| {{.Severity}} | ` + "`{{.Rule}}`" + ` | {{.File}}{{if .Line}}:{{.Line}}{{end}} | {{.Message}} |
+{{end}}
+{{end}}
 
 ### Estrutura do Projeto
 ` + "```" + `
@@ -64,13 +382,17 @@ const baseTemplate = `# Documentação do Projeto
 ` + "```" + `
 
 {{range .Go.Directories}}
-### 📁 {{.Path}}
+### 📁 {{if .ImportPath}}` + "`{{.ImportPath}}`" + `{{else}}{{.Path}}{{end}}
 
+{{if .ImportPath}}**Diretório:** {{.Path}}{{if .Module}} · **Módulo:** ` + "`{{.Module}}`" + `{{end}}
+{{end}}
 {{range .Files}}
-#### 📄 {{.FileName}}
+#### 📄 {{.FileName}}{{with source .FileName 0}} · [fonte]({{.}}){{end}}
 
 **Package:** ` + "`{{.Package}}`" + `
-
+{{if .Constraint}}
+**Restrição de build:** ` + "`{{.Constraint}}`" + `{{if .Platforms}} ({{range $i, $p := .Platforms}}{{if $i}}, {{end}}{{$p}}{{end}}){{end}}
+{{end}}
 {{if .Imports}}
 ##### Imports
 
@@ -83,14 +405,28 @@ const baseTemplate = `# Documentação do Projeto
 ##### Interfaces
 
 {{range .Interfaces}}
-###### Interface ` + "`{{.Name}}`" + `
+###### Interface ` + "`{{.Name}}`" + `{{with source .File .Line}} · [fonte]({{.}}){{end}}
 
 {{if .Doc}}
 {{.Doc}}
 {{end}}
+{{range .Examples}}
+**Exemplo ` + "`{{.Name}}`" + `**{{if .Doc}}: {{.Doc}}{{end}}
+
+` + "```go" + `
+{{.Code}}
+` + "```" + `
+{{if .Output}}
+Output{{if .Unordered}} (sem ordem){{end}}:
+
+` + "```" + `
+{{.Output}}
+` + "```" + `
+{{end}}
+{{end}}
 
 {{range .Methods}}
-- ` + "`{{.Name}}{{.Sig}}`" + `
+- ` + "`{{.Name}}{{.Sig}}`" + `{{with source .File .Line}} · [fonte]({{.}}){{end}}
 {{if .Doc}}  - {{.Doc}}{{end}}
 {{end}}
 
@@ -101,11 +437,25 @@ const baseTemplate = `# Documentação do Projeto
 ##### Structs
 
 {{range .Structs}}
-###### Struct ` + "`{{.Name}}`" + `
+###### Struct ` + "`{{.Name}}`" + `{{with source .File .Line}} · [fonte]({{.}}){{end}}
 
 {{if .Doc}}
 {{.Doc}}
 {{end}}
+{{range .Examples}}
+**Exemplo ` + "`{{.Name}}`" + `**{{if .Doc}}: {{.Doc}}{{end}}
+
+` + "```go" + `
+{{.Code}}
+` + "```" + `
+{{if .Output}}
+Output{{if .Unordered}} (sem ordem){{end}}:
+
+` + "```" + `
+{{.Output}}
+` + "```" + `
+{{end}}
+{{end}}
 
 {{if .Fields}}
 **Fields:**
@@ -120,8 +470,15 @@ const baseTemplate = `# Documentação do Projeto
 **Methods:**
 
 {{range .Methods}}
-- ` + "`{{.Name}}{{.Sig}}`" + `
+- ` + "`{{.Name}}{{.Sig}}`" + `{{with source .File .Line}} · [fonte]({{.}}){{end}}
 {{if .Doc}}  - {{.Doc}}{{end}}
+{{range .Examples}}  - Exemplo ` + "`{{.Name}}`" + `{{if .Output}} (output: ` + "`{{.Output}}`" + `){{end}}
+{{end}}
+{{if .Metrics}}  - Métricas: complexidade {{.Metrics.Cyclomatic}}, instruções {{.Metrics.Statements}}, parâmetros {{.Metrics.Params}}, aninhamento {{.Metrics.MaxNesting}}{{range .Metrics.Hotspots}} ⚠️ {{.}}{{end}}{{end}}
+{{with .Coverage}}  - Cobertura de testes: {{if eq .Covered 0}}⚠️ sem testes{{else}}{{printf "%.0f" .Percent}}%{{end}} ({{.Covered}}/{{.Statements}} instruções){{end}}
+{{with .Profile}}  - Perfil: flat {{printf "%.1f" .FlatPercent}}%, cumulativo {{printf "%.1f" .CumPercent}}%{{end}}
+{{if .Calls}}  - Chama: {{range .Calls}}` + "`{{.}}` " + `{{end}}{{end}}
+{{if .CalledBy}}  - Chamado por: {{range .CalledBy}}` + "`{{.}}` " + `{{end}}{{end}}
 {{end}}
 {{end}}
 
@@ -132,16 +489,174 @@ const baseTemplate = `# Documentação do Projeto
 ##### Functions
 
 {{range .Functions}}
-###### ` + "`{{.Name}}{{.Sig}}`" + `
+###### ` + "`{{.Name}}{{.Sig}}`" + `{{with source .File .Line}} · [fonte]({{.}}){{end}}
 
 {{if .Doc}}
 {{.Doc}}
 {{end}}
+{{if .Metrics}}
+*Métricas:* complexidade {{.Metrics.Cyclomatic}}, instruções {{.Metrics.Statements}}, parâmetros {{.Metrics.Params}}, aninhamento {{.Metrics.MaxNesting}}{{range .Metrics.Hotspots}} ⚠️ **{{.}}**{{end}}
+{{end}}
+{{with .Coverage}}
+*Cobertura de testes:* {{if eq .Covered 0}}⚠️ **sem testes**{{else}}{{printf "%.0f" .Percent}}%{{end}} ({{.Covered}}/{{.Statements}} instruções)
+{{end}}
+{{with .Profile}}
+*Perfil:* flat {{printf "%.1f" .FlatPercent}}%, cumulativo {{printf "%.1f" .CumPercent}}%
+{{end}}
+{{range .Examples}}
+**Exemplo ` + "`{{.Name}}`" + `**{{if .Doc}}: {{.Doc}}{{end}}
+
+` + "```go" + `
+{{.Code}}
+` + "```" + `
+{{if .Output}}
+Output{{if .Unordered}} (sem ordem){{end}}:
+
+` + "```" + `
+{{.Output}}
+` + "```" + `
+{{end}}
+{{end}}
+{{if .Calls}}
+**Chama:** {{range .Calls}}` + "`{{.}}` " + `{{end}}
+{{end}}
+{{if .CalledBy}}
+**Chamado por:** {{range .CalledBy}}` + "`{{.}}` " + `{{end}}
+{{end}}
 {{end}}
 {{end}}
 
 ---
 {{end}}
+
+{{if .Examples}}
+#### Exemplos do pacote
+
+{{range .Examples}}
+**Exemplo ` + "`{{.Name}}`" + `**{{if .Doc}}: {{.Doc}}{{end}}
+
+` + "```go" + `
+{{.Code}}
+` + "```" + `
+{{if .Output}}
+Output{{if .Unordered}} (sem ordem){{end}}:
+
+` + "```" + `
+{{.Output}}
+` + "```" + `
+{{end}}
+{{end}}
+{{end}}
+
+{{if .Tests}}
+#### Testes
+
+| Função | Tipo | Local | Símbolos referenciados |
+|--------|------|-------|------------------------|
+{{range .Tests}}| ` + "`{{.Name}}`" + ` | {{.Kind}} | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} | {{range .References}}` + "`{{.}}` " + `{{end}} |
+{{end}}
+{{end}}
+
+{{with .Concurrency}}
+#### Concorrência
+
+{{if .Goroutines}}
+**Goroutines**
+
+| Iniciada em | Executa | Via | Local |
+|-------------|---------|-----|-------|
+{{range .Goroutines}}| ` + "`{{.Function}}`" + ` | ` + "`{{.Target}}`" + ` | {{.Via}} | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}
+{{end}}
+
+{{if .Channels}}
+**Canais**
+
+| Canal | Tipo | Declaração | Envia | Recebe |
+|-------|------|------------|-------|--------|
+{{range .Channels}}| ` + "`{{.Name}}`" + ` | ` + "`{{.Type}}`" + ` | {{.Kind}} | {{range .Senders}}` + "`{{.}}` " + `{{end}} | {{range .Receivers}}` + "`{{.}}` " + `{{end}} |
+{{end}}
+{{end}}
+
+{{if .Locks}}
+**Travas e atômicos**
+
+| Campo | Tipo | Usado por |
+|-------|------|-----------|
+{{range .Locks}}| ` + "`{{.Name}}`" + ` | ` + "`{{.Type}}`" + ` | {{range .Methods}}` + "`{{.}}` " + `{{end}} |
+{{end}}
+{{end}}
+
+{{if .SyncGroups}}
+**WaitGroup / errgroup**
+
+| Função | Tipo | Chamadas |
+|--------|------|----------|
+{{range .SyncGroups}}| ` + "`{{.Function}}`" + ` | {{.Kind}} | {{range .Calls}}` + "`{{.}}` " + `{{end}} |
+{{end}}
+{{end}}
+{{end}}
+
+{{with .Errors}}
+#### Catálogo de Erros
+
+{{if .Sentinels}}
+**Erros sentinela**
+
+| Erro | Mensagem | Local |
+|------|----------|-------|
+{{range .Sentinels}}| ` + "`{{.Name}}`" + ` | {{if .Message}}"{{.Message}}"{{end}} | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}
+{{end}}
+
+{{if .Types}}
+**Tipos de erro**
+
+| Tipo | Mensagem | Local |
+|------|----------|-------|
+{{range .Types}}| ` + "`{{.Receiver}}`" + ` | {{if .Message}}"{{.Message}}"{{end}} | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}
+{{end}}
+
+{{if .Returns}}
+**Erros retornados por função**
+
+| Função | Tipo | Erro | Mensagem |
+|--------|------|------|----------|
+{{range .Returns}}| ` + "`{{.Function}}`" + ` | {{.Kind}} | {{if .Error}}` + "`{{.Error}}`" + `{{end}} | {{if .Message}}"{{.Message}}"{{end}} |
+{{end}}
+{{end}}
+
+{{if .Wraps}}
+**Wraps (` + "`fmt.Errorf` com `%w`" + `)**
+
+| Função | Formato | Envolve | Local |
+|--------|---------|---------|-------|
+{{range .Wraps}}| ` + "`{{.Function}}`" + ` | "{{.Format}}" | {{range .Wrapped}}` + "`{{.}}` " + `{{end}} | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}
+{{end}}
+
+{{if .Checks}}
+**Verificações**
+
+| Função | Verificação | Alvo | Local |
+|--------|-------------|------|-------|
+{{range .Checks}}| ` + "`{{.Function}}`" + ` | {{.Kind}} | ` + "`{{.Target}}`" + ` | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}
+{{end}}
+{{end}}
+
+{{with .Logging}}
+#### Catálogo de Logs
+
+| Nível | Mensagem | Chaves | Função | Local |
+|-------|----------|--------|--------|-------|
+{{range .Calls}}| {{if .Level}}{{.Level}}{{else}}{{.Method}}{{end}} | {{if .Message}}"{{.Message}}"{{else if .Dynamic}}_(dinâmica)_{{end}} | {{range .Keys}}` + "`{{.}}` " + `{{end}}| ` + "`{{.Function}}`" + ` | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}
+{{if .Keys}}
+**Chaves:** {{range .Keys}}` + "`{{.Name}}`" + ` ({{.Count}}){{if .Variants}} ⚠ também {{range .Variants}}` + "`{{.}}` " + `{{end}}{{end}}; {{end}}
+{{end}}
+{{end}}
 {{end}}
 {{end}}
 
@@ -149,7 +664,7 @@ const baseTemplate = `# Documentação do Projeto
 ## Documentação Kubernetes
 
 {{range .K8s.Resources}}
-### {{.Kind}}: {{.Name}}
+### {{.Kind}}: {{.Name}}{{with source .File .Line}} · [fonte]({{.}}){{end}}
 
 {{if .Namespace}}
 **Namespace:** ` + "`{{.Namespace}}`" + `
@@ -186,4 +701,31 @@ graph TD
     {{end}}
 {{end}}
 ` + "```" + `
+{{with .Go}}{{with $bus := .Messaging}}
+## Topologia de Mensageria
+
+Produtores e consumidores de cada tópico (Kafka), subject (NATS), exchange ou fila (RabbitMQ), por binário{{if $bus.Workloads}} e pelas cargas do Kubernetes que o executam{{end}}. Nomes em itálico não são constantes no código.
+
+| Broker | Tópico | Produtores | Consumidores |
+|--------|--------|------------|--------------|
+{{range $bus.Topics}}| {{.Broker}} | {{if .Dynamic}}_` + "`{{.Name}}`" + `_{{else}}` + "`{{.Name}}`" + `{{end}} | {{range .Producers}}` + "`{{.}}`" + `{{range index $bus.Workloads .}} ({{.}}){{end}} {{end}}| {{range .Consumers}}` + "`{{.}}`" + `{{range index $bus.Workloads .}} ({{.}}){{end}} {{end}}|
+{{end}}
+{{if $bus.Bindings}}
+**Bindings:** {{range $bus.Bindings}}` + "`{{.Exchange}}`" + ` → ` + "`{{.Queue}}`" + `{{if .Key}} (` + "`{{.Key}}`" + `){{end}}; {{end}}
+{{end}}
+
+` + "```mermaid" + `
+{{$bus.Mermaid}}
+` + "```" + `
+
+<details>
+<summary>Chamadas de publicação e assinatura ({{len $bus.Endpoints}})</summary>
+
+| Papel | Tópico | Cliente | Grupo | Função | Local |
+|-------|--------|---------|-------|--------|-------|
+{{range $bus.Endpoints}}| {{if eq .Role "producer"}}publica{{else}}consome{{end}} | ` + "`{{.Topic}}`" + ` | {{.Client}} | {{.Group}} | ` + "`{{.Function}}`" + ` | {{$url := source .File .Line}}{{if $url}}[{{.File}}:{{.Line}}]({{$url}}){{else}}{{.File}}:{{.Line}}{{end}} |
+{{end}}
+
+</details>
+{{end}}{{end}}
 `
\ No newline at end of file
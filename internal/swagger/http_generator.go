@@ -8,6 +8,12 @@ import (
 	"strings"
 )
 
+// WriteHTTPFile gera um arquivo .http com os endpoints informados, no mesmo
+// formato usado para as tags de um documento Swagger
+func WriteHTTPFile(filename, tag string, endpoints []HTTPEndpoint) error {
+	return generateHTTPFile(filename, tag, endpoints)
+}
+
 func generateHTTPFile(filename, tag string, endpoints []HTTPEndpoint) error {
 	var content strings.Builder
 
@@ -17,10 +23,11 @@ func generateHTTPFile(filename, tag string, endpoints []HTTPEndpoint) error {
 
 	for _, endpoint := range endpoints {
 		// Adiciona comentário com descrição
+		// Apenas a primeira linha, para não quebrar o formato .http
 		if endpoint.Operation.Description != "" {
-			content.WriteString(fmt.Sprintf("### %s\n", endpoint.Operation.Description))
+			content.WriteString(fmt.Sprintf("### %s\n", strings.SplitN(endpoint.Operation.Description, "\n", 2)[0]))
 		} else if endpoint.Operation.Summary != "" {
-			content.WriteString(fmt.Sprintf("### %s\n", endpoint.Operation.Summary))
+			content.WriteString(fmt.Sprintf("### %s\n", strings.SplitN(endpoint.Operation.Summary, "\n", 2)[0]))
 		}
 
 		// Início da requisição
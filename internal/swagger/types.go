@@ -11,8 +11,8 @@ import (
 
 // SwaggerDoc representa a estrutura do documento Swagger/OpenAPI
 type SwaggerDoc struct {
-	Swagger     string                 `json:"swagger"`
-	OpenAPI     string                 `json:"openapi"`
+	Swagger     string                 `json:"swagger,omitempty"`
+	OpenAPI     string                 `json:"openapi,omitempty"`
 	Info        Info                   `json:"info"`
 	Host        string                 `json:"host,omitempty"`
 	BasePath    string                 `json:"basePath,omitempty"`
@@ -70,12 +70,15 @@ type MediaType struct {
 }
 
 type SchemaType struct {
-	Type        string                 `json:"type,omitempty"`
-	Format      string                 `json:"format,omitempty"`
-	Properties  map[string]SchemaType  `json:"properties,omitempty"`
-	Items       *SchemaType           `json:"items,omitempty"`
-	Ref         string                 `json:"$ref,omitempty"`
-	Example     interface{}            `json:"example,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Properties           map[string]SchemaType  `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *SchemaType            `json:"items,omitempty"`
+	AdditionalProperties *SchemaType            `json:"additionalProperties,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Example              interface{}            `json:"example,omitempty"`
 }
 
 type Components struct {
@@ -112,6 +115,7 @@ func (doc *SwaggerDoc) GenerateHTTPFiles(outputDir string) error {
 	endpointsByTag := make(map[string][]HTTPEndpoint)
 	for path, pathItem := range doc.Paths {
 		for method, op := range pathItem {
+			op.RequestBody = doc.resolveRequestBody(op.RequestBody)
 			endpoint := HTTPEndpoint{
 				Method:      strings.ToUpper(method),
 				Path:        path,
@@ -143,6 +147,53 @@ func (doc *SwaggerDoc) GenerateHTTPFiles(outputDir string) error {
 	return nil
 }
 
+// resolveRequestBody substitui as referências ($ref) do corpo da requisição
+// pelos schemas declarados, para que o exemplo do .http tenha os campos
+func (doc *SwaggerDoc) resolveRequestBody(body *RequestBody) *RequestBody {
+	if body == nil {
+		return nil
+	}
+	resolved := *body
+	resolved.Content = make(map[string]MediaType, len(body.Content))
+	for contentType, mediaType := range body.Content {
+		mediaType.Schema = doc.resolveSchema(mediaType.Schema, 0)
+		resolved.Content[contentType] = mediaType
+	}
+	return &resolved
+}
+
+// resolveSchema expande referências locais (#/components/schemas ou #/definitions),
+// limitando a profundidade para tipos recursivos
+func (doc *SwaggerDoc) resolveSchema(schema SchemaType, depth int) SchemaType {
+	if depth > 5 {
+		return SchemaType{Type: "object"}
+	}
+	if schema.Ref != "" {
+		name := schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]
+		if doc.Components != nil {
+			if target, ok := doc.Components.Schemas[name]; ok {
+				return doc.resolveSchema(target, depth+1)
+			}
+		}
+		if target, ok := doc.Definitions[name]; ok {
+			return doc.resolveSchema(target, depth+1)
+		}
+		return schema
+	}
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]SchemaType, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			properties[name] = doc.resolveSchema(prop, depth+1)
+		}
+		schema.Properties = properties
+	}
+	if schema.Items != nil {
+		items := doc.resolveSchema(*schema.Items, depth+1)
+		schema.Items = &items
+	}
+	return schema
+}
+
 // getBaseURL determina a URL base para as requisições
 func (doc *SwaggerDoc) getBaseURL() string {
 	// OpenAPI 3.0
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SourceLinker gera links para a posição de um símbolo no código-fonte
+type SourceLinker struct {
+    template  string // ex.: https://git.example.com/repo/blob/{rev}/{path}#L{line}
+    rev       string
+    root      string // raiz do repositório; {path} é relativo a ela
+    outputDir string // destino dos links relativos quando não há template
+}
+
+// NewSourceLinker cria um SourceLinker. Com template vazio gera links relativos
+// ao diretório de saída, úteis para visualizar o HTML localmente. A revisão e a
+// raiz do repositório vêm do git local; fora de um repositório usa o diretório
+// atual e a revisão HEAD
+func NewSourceLinker(template, outputDir string) *SourceLinker {
+    l := &SourceLinker{template: template, outputDir: outputDir, rev: "HEAD"}
+
+    if root, err := gitOutput("rev-parse", "--show-toplevel"); err == nil {
+        l.root = root
+    } else if cwd, err := os.Getwd(); err == nil {
+        l.root = cwd
+    }
+    if template != "" && strings.Contains(template, "{rev}") {
+        if rev, err := gitOutput("rev-parse", "HEAD"); err == nil {
+            l.rev = rev
+        } else {
+            slog.Warn("Revisão git não encontrada, usando HEAD nos links de código", "error", err)
+        }
+    }
+    return l
+}
+
+// URL retorna o link para o arquivo e linha (linha <= 0 aponta para o arquivo)
+func (l *SourceLinker) URL(file string, line int) string {
+    if l == nil || file == "" {
+        return ""
+    }
+    abs, err := filepath.Abs(file)
+    if err != nil {
+        return ""
+    }
+
+    if l.template == "" {
+        outputDir, err := filepath.Abs(l.outputDir)
+        if err != nil {
+            return ""
+        }
+        rel, err := filepath.Rel(outputDir, abs)
+        if err != nil {
+            return ""
+        }
+        link := filepath.ToSlash(rel)
+        if line > 0 {
+            link += "#L" + strconv.Itoa(line)
+        }
+        return link
+    }
+
+    path := abs
+    if rel, err := filepath.Rel(l.root, abs); err == nil && !strings.HasPrefix(rel, "..") {
+        path = rel
+    }
+    url := l.template
+    if line <= 0 {
+        // Remove o fragmento da linha (ex.: "#L{line}") ao apontar para o arquivo inteiro
+        if i := strings.LastIndex(url, "#"); i >= 0 && strings.Contains(url[i:], "{line}") {
+            url = url[:i]
+        }
+    }
+    return strings.NewReplacer(
+        "{rev}", l.rev,
+        "{path}", filepath.ToSlash(path),
+        "{line}", strconv.Itoa(line),
+    ).Replace(url)
+}
+
+// gitOutput executa um comando git no diretório atual
+func gitOutput(args ...string) (string, error) {
+    out, err := exec.Command("git", args...).Output()
+    if err != nil {
+        return "", err
+    }
+    return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSourceLinker(t *testing.T) {
+    root := t.TempDir()
+    file := filepath.Join(root, "internal", "app", "app.go")
+
+    t.Run("Template", func(t *testing.T) {
+        l := &SourceLinker{
+            template: "https://git.example.com/repo/blob/{rev}/{path}#L{line}",
+            rev:      "abc123",
+            root:     root,
+        }
+        cases := []struct {
+            line int
+            want string
+        }{
+            {12, "https://git.example.com/repo/blob/abc123/internal/app/app.go#L12"},
+            {0, "https://git.example.com/repo/blob/abc123/internal/app/app.go"},
+        }
+        for _, tc := range cases {
+            if got := l.URL(file, tc.line); got != tc.want {
+                t.Errorf("URL(%q, %d) = %q; want %q", file, tc.line, got, tc.want)
+            }
+        }
+    })
+
+    t.Run("Relativo", func(t *testing.T) {
+        l := &SourceLinker{root: root, outputDir: filepath.Join(root, "docs")}
+        want := "../internal/app/app.go#L7"
+        if got := l.URL(file, 7); got != want {
+            t.Errorf("URL(%q, 7) = %q; want %q", file, got, want)
+        }
+    })
+
+    t.Run("Nil", func(t *testing.T) {
+        var l *SourceLinker
+        if got := l.URL(file, 1); got != "" {
+            t.Errorf("URL em linker nil = %q; want vazio", got)
+        }
+    })
+}